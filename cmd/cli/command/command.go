@@ -3,13 +3,39 @@ package command
 import (
 	"crossjoin.com/gorxestra/cmd/cli/command/add"
 	"crossjoin.com/gorxestra/cmd/cli/command/delete"
+	"crossjoin.com/gorxestra/cmd/cli/command/mix"
+	"crossjoin.com/gorxestra/cmd/cli/command/music"
+	"crossjoin.com/gorxestra/cmd/cli/command/panic"
+	"crossjoin.com/gorxestra/cmd/cli/command/pause"
+	"crossjoin.com/gorxestra/cmd/cli/command/performances"
 	"crossjoin.com/gorxestra/cmd/cli/command/play"
+	"crossjoin.com/gorxestra/cmd/cli/command/queue"
+	"crossjoin.com/gorxestra/cmd/cli/command/resume"
+	"crossjoin.com/gorxestra/cmd/cli/command/section"
+	"crossjoin.com/gorxestra/cmd/cli/command/seek"
+	"crossjoin.com/gorxestra/cmd/cli/command/status"
+	"crossjoin.com/gorxestra/cmd/cli/command/stop"
+	"crossjoin.com/gorxestra/cmd/cli/command/tempo"
+	"crossjoin.com/gorxestra/cmd/cli/command/transpose"
 	"github.com/urfave/cli/v2"
 )
 
 func GetCommands() cli.Commands {
 	return cli.Commands{
 		play.Commands(),
+		stop.Commands(),
+		pause.Commands(),
+		resume.Commands(),
+		seek.Commands(),
+		tempo.Commands(),
+		transpose.Commands(),
+		mix.Commands(),
+		panic.Commands(),
+		status.Commands(),
+		performances.Commands(),
+		section.Commands(),
+		music.Commands(),
+		queue.Commands(),
 		delete.Commands(),
 		add.Commands(),
 	}
@@ -0,0 +1,229 @@
+package mix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	conductor "crossjoin.com/gorxestra/daemon/conductord/api/client/v1"
+	"crossjoin.com/gorxestra/data"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	gainFlag  = "gain"
+	panFlag   = "pan"
+	muteFlag  = "mute"
+	soloFlag  = "solo"
+	resetFlag = "reset"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "mix",
+		Aliases:      nil,
+		Usage:        "<musician|track>",
+		UsageText:    "",
+		Description:  "Balance the musicians and tracks of the music being played, or show their mix",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       showAction,
+		OnUsageError: nil,
+		Subcommands: cli.Commands{
+			musicianCommand(),
+			trackCommand(),
+		},
+		//nolint
+		Flags:                  utils.PerformanceFlags(),
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func musicianCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "musician",
+		Usage:       "<id or id prefix>",
+		Description: "Change the mix of the notes sent to a musician",
+		Action:      musicianAction,
+		Flags:       mixFlags(),
+	}
+}
+
+func trackCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "track",
+		Usage:       "<track>",
+		Description: "Change the mix of the notes of a track",
+		Action:      trackAction,
+		Flags:       mixFlags(),
+	}
+}
+
+func mixFlags() []cli.Flag {
+	return append(utils.PerformanceFlags(),
+		&cli.Float64Flag{
+			Name:  gainFlag,
+			Usage: "factor applied to the velocity of the notes and the volume, from 0 to 2",
+		},
+		&cli.IntFlag{
+			Name:  panFlag,
+			Usage: "pan position, 0 left to 127 right",
+		},
+		&cli.BoolFlag{
+			Name:  muteFlag,
+			Usage: "silence the notes, --mute=false to hear them again",
+		},
+		&cli.BoolFlag{
+			Name:  soloFlag,
+			Usage: "silence the notes of those not soloed, --solo=false to end the solo",
+		},
+		&cli.BoolFlag{
+			Name:  resetFlag,
+			Usage: "bring the mix back to the default before the other changes",
+		},
+	)
+}
+
+func showAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := utils.Performance(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	status, err := cli.MusicStatus(id)
+	if err != nil {
+		return err
+	}
+	printMixer(status.Mixer)
+	return nil
+}
+
+func musicianAction(ctx *cli.Context) error {
+	prefix := ctx.Args().First()
+	if prefix == "" {
+		return errors.New("specify a musician")
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := utils.Performance(ctx, cli)
+	if err != nil {
+		return err
+	}
+	musician, err := findMusician(cli, id, prefix)
+	if err != nil {
+		return err
+	}
+
+	return mix(ctx, cli, id, data.MixTarget{Musician: &musician})
+}
+
+func trackAction(ctx *cli.Context) error {
+	raw := ctx.Args().First()
+	if raw == "" {
+		return errors.New("specify a track")
+	}
+	track, err := strconv.Atoi(raw)
+	if err != nil {
+		return errors.New("invalid track")
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := utils.Performance(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	return mix(ctx, cli, id, data.MixTarget{Track: &track})
+}
+
+// mix sends the change of the flags set and prints the mixer
+func mix(ctx *cli.Context, cli conductor.ClientDaemon, id data.ID, target data.MixTarget) error {
+	change := data.MixChange{Reset: ctx.Bool(resetFlag)}
+	if ctx.IsSet(gainFlag) {
+		gain := ctx.Float64(gainFlag)
+		change.Gain = &gain
+	}
+	if ctx.IsSet(panFlag) {
+		raw := ctx.Int(panFlag)
+		if raw < 0 || raw > 127 {
+			return errors.New("pan out of 0-127")
+		}
+		pan := uint8(raw)
+		change.Pan = &pan
+	}
+	if ctx.IsSet(muteFlag) {
+		mute := ctx.Bool(muteFlag)
+		change.Mute = &mute
+	}
+	if ctx.IsSet(soloFlag) {
+		solo := ctx.Bool(soloFlag)
+		change.Solo = &solo
+	}
+
+	mixer, err := cli.MixMusic(id, target, change)
+	if err != nil {
+		return err
+	}
+	printMixer(mixer)
+	return nil
+}
+
+// findMusician resolves a musician id or id prefix among the musicians
+// playing the performance
+func findMusician(cli conductor.ClientDaemon, id data.ID, prefix string) (data.ID, error) {
+	status, err := cli.MusicStatus(id)
+	if err != nil {
+		return data.ID{}, err
+	}
+
+	prefix = strings.ToLower(prefix)
+	var found []data.ID
+	for _, t := range status.Tracks {
+		if t.Musician != nil && strings.HasPrefix(t.Musician.Hex(), prefix) && !slices.Contains(found, *t.Musician) {
+			found = append(found, *t.Musician)
+		}
+	}
+	switch len(found) {
+	case 0:
+		if musician, err := data.IdFromHex(prefix); err == nil {
+			return musician, nil
+		}
+		return data.ID{}, data.ErrMusicianNotFound
+	case 1:
+		return found[0], nil
+	default:
+		return data.ID{}, fmt.Errorf("%q matches %d musicians", prefix, len(found))
+	}
+}
+
+func printMixer(m data.Mixer) {
+	if len(m.Musicians) == 0 && len(m.Tracks) == 0 {
+		fmt.Println("every musician and track sounds as written")
+		return
+	}
+	utils.PrintMixer(os.Stdout, m)
+}
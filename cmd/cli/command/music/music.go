@@ -0,0 +1,197 @@
+package music
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"crossjoin.com/gorxestra/data"
+	"github.com/urfave/cli/v2"
+)
+
+const nameFlag = "name"
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "music",
+		Aliases:      nil,
+		Usage:        "<upload|ls|info|rm>",
+		UsageText:    "",
+		Description:  "Manage the music library of the conductor",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       nil,
+		OnUsageError: nil,
+		Subcommands: cli.Commands{
+			uploadCommand(),
+			listCommand(),
+			infoCommand(),
+			removeCommand(),
+		},
+		//nolint
+		Flags:                  []cli.Flag{},
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func uploadCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "upload",
+		Usage:       "<file>",
+		Description: "Upload a MIDI file to the library",
+		Action:      uploadAction,
+		//nolint
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  nameFlag,
+				Usage: "name of the music in the library, the file name by default",
+			},
+		},
+	}
+}
+
+func listCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "ls",
+		Description: "List the musics of the library",
+		Action:      listAction,
+	}
+}
+
+func infoCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "info",
+		Usage:       "<music>",
+		Description: "Show the metadata of a music",
+		Action:      infoAction,
+	}
+}
+
+func removeCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "rm",
+		Usage:       "<music>",
+		Description: "Delete a music from the library",
+		Action:      removeAction,
+	}
+}
+
+func uploadAction(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if path == "" {
+		return errors.New("specify a file")
+	}
+
+	name := ctx.String(nameFlag)
+	if name == "" {
+		name = filepath.Base(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	info, err := cli.AddMusic(name, f)
+	if err != nil {
+		return err
+	}
+
+	printInfo(os.Stdout, info)
+	return nil
+}
+
+func listAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	musics, err := cli.ListMusic()
+	if err != nil {
+		return err
+	}
+
+	printList(os.Stdout, musics)
+	return nil
+}
+
+func infoAction(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		return errors.New("specify a music")
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	info, err := cli.MusicInfo(name)
+	if err != nil {
+		return err
+	}
+
+	printInfo(os.Stdout, info)
+	return nil
+}
+
+func removeAction(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		return errors.New("specify a music")
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cli.RemoveMusic(name)
+}
+
+func printList(w io.Writer, musics []data.MusicInfo) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDURATION\tTRACKS\tTEMPO\tFORMAT\tSIZE")
+	for _, m := range musics {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.1f\t%d\t%d\n",
+			m.Name, formatDuration(m.Duration), m.Tracks, m.Tempo, m.Format, m.Size)
+	}
+	tw.Flush()
+}
+
+func printInfo(w io.Writer, m data.MusicInfo) {
+	fmt.Fprintf(w, "name:     %s\n", m.Name)
+	fmt.Fprintf(w, "duration: %s\n", formatDuration(m.Duration))
+	fmt.Fprintf(w, "tracks:   %d\n", m.Tracks)
+	fmt.Fprintf(w, "tempo:    %.1f bpm\n", m.Tempo)
+	fmt.Fprintf(w, "format:   %d\n", m.Format)
+	fmt.Fprintf(w, "size:     %d bytes\n", m.Size)
+	fmt.Fprintf(w, "modified: %s\n", m.ModifiedAt.Local().Format(time.DateTime))
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,43 @@
+package panic
+
+import (
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"github.com/urfave/cli/v2"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "panic",
+		Aliases:      nil,
+		Usage:        "",
+		UsageText:    "",
+		Description:  "Turn all notes and sound off on every musician",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       panicAction,
+		OnUsageError: nil,
+		Subcommands:  cli.Commands{},
+		//nolint
+		Flags:                  []cli.Flag{},
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func panicAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cli.Panic()
+}
@@ -0,0 +1,47 @@
+package pause
+
+import (
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"github.com/urfave/cli/v2"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "pause",
+		Aliases:      nil,
+		Usage:        "",
+		UsageText:    "",
+		Description:  "Pause the music being played",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       pauseAction,
+		OnUsageError: nil,
+		Subcommands:  cli.Commands{},
+		//nolint
+		Flags:                  utils.PerformanceFlags(),
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func pauseAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := utils.Performance(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	return cli.PauseMusic(id)
+}
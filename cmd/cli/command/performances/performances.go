@@ -0,0 +1,76 @@
+package performances
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"crossjoin.com/gorxestra/data"
+	"github.com/urfave/cli/v2"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "performances",
+		Aliases:      []string{"ps"},
+		Usage:        "",
+		UsageText:    "",
+		Description:  "List the performances being played",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       performancesAction,
+		OnUsageError: nil,
+		Subcommands:  cli.Commands{},
+		//nolint
+		Flags:                  []cli.Flag{},
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func performancesAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	perfs, err := cli.Performances()
+	if err != nil {
+		return err
+	}
+
+	printPerformances(os.Stdout, perfs)
+	return nil
+}
+
+func printPerformances(w io.Writer, perfs []data.PlaybackStatus) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSECTION\tMUSIC\tSTATE\tPROGRESS")
+	for _, p := range perfs {
+		section := p.Section
+		if section == "" {
+			section = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s / %s\n",
+			p.Performance.Hex()[:12], section, p.Music, p.State,
+			formatDuration(p.Elapsed), formatDuration(p.Duration))
+	}
+	tw.Flush()
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
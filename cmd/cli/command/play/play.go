@@ -1,12 +1,45 @@
 package play
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"crossjoin.com/gorxestra/daemon/conductord/api"
+	"crossjoin.com/gorxestra/data"
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	assignFlag       = "assign"
+	tempoFlag        = "tempo"
+	transposeFlag    = "transpose"
+	startFlag        = "start"
+	endFlag          = "end"
+	tracksFlag       = "tracks"
+	excludeFlag      = "exclude"
+	loopsFlag        = "loops"
+	minMusiciansFlag = "min-musicians"
+	sectionFlag      = "section"
+	dryRunFlag       = "dry-run"
+	formatFlag       = "format"
+)
+
+// Formats of the schedule of a dry run
+const (
+	textFormat = "text"
+	jsonFormat = "json"
+	csvFormat  = "csv"
+)
+
 func Commands() *cli.Command {
 	return &cli.Command{
 		Name:         "play",
@@ -24,7 +57,17 @@ func Commands() *cli.Command {
 		OnUsageError: nil,
 		Subcommands:  cli.Commands{},
 		//nolint
-		Flags:                  []cli.Flag{},
+		Flags: append(OptionFlags(),
+			&cli.BoolFlag{
+				Name:  dryRunFlag,
+				Usage: "show how the music would be played and what each musician would be asked, without playing it",
+			},
+			&cli.StringFlag{
+				Name:  formatFlag,
+				Value: textFormat,
+				Usage: "format of the schedule of a dry run: text, json or csv",
+			},
+		),
 		SkipFlagParsing:        false,
 		HideHelp:               false,
 		HideHelpCommand:        false,
@@ -35,16 +78,258 @@ func Commands() *cli.Command {
 	}
 }
 
+// OptionFlags are the flags of the play options
+func OptionFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:    assignFlag,
+			Aliases: []string{"a"},
+			Usage: "play a track, or a MIDI channel of a track, with a musician: " +
+				"<track>[:<channel>]=<musician ID>. Tracks not assigned are not played",
+		},
+		&cli.Float64Flag{
+			Name:  tempoFlag,
+			Value: data.DefaultInterpretation.Tempo,
+			Usage: "factor applied to the tempo of the music, 0.5 plays it twice slower",
+		},
+		&cli.IntFlag{
+			Name:  transposeFlag,
+			Usage: "semitones the notes are shifted by, the percussion channel aside",
+		},
+		&cli.StringFlag{
+			Name:  startFlag,
+			Usage: "where the music starts: milliseconds, a duration such as 1m30s or <bar>:<beat>",
+		},
+		&cli.StringFlag{
+			Name:  endFlag,
+			Usage: "where the music ends: milliseconds, a duration such as 1m30s or <bar>:<beat>",
+		},
+		&cli.IntSliceFlag{
+			Name:  tracksFlag,
+			Usage: "the only tracks played",
+		},
+		&cli.IntSliceFlag{
+			Name:  excludeFlag,
+			Usage: "tracks not played",
+		},
+		&cli.IntFlag{
+			Name:  loopsFlag,
+			Value: data.DefaultPlayOptions.Loops,
+			Usage: "number of times the music is played from start to end",
+		},
+		&cli.IntFlag{
+			Name:  minMusiciansFlag,
+			Usage: "number of musicians registered the music waits for before starting",
+		},
+		&cli.StringFlag{
+			Name:  sectionFlag,
+			Usage: "section playing the music, the whole orchestra when missing",
+		},
+	}
+}
+
+// Options reads the play options from the flags of OptionFlags
+func Options(ctx *cli.Context) (data.PlayOptions, error) {
+	mapping := make([]data.TrackAssignment, 0, len(ctx.StringSlice(assignFlag)))
+	for _, raw := range ctx.StringSlice(assignFlag) {
+		a, err := parseAssignment(raw)
+		if err != nil {
+			return data.PlayOptions{}, err
+		}
+		mapping = append(mapping, a)
+	}
+
+	opts := data.PlayOptions{
+		Mapping: mapping,
+		Interpretation: data.Interpretation{
+			Tempo:     ctx.Float64(tempoFlag),
+			Transpose: ctx.Int(transposeFlag),
+		},
+		Tracks:       ctx.IntSlice(tracksFlag),
+		Exclude:      ctx.IntSlice(excludeFlag),
+		Loops:        ctx.Int(loopsFlag),
+		MinMusicians: ctx.Int(minMusiciansFlag),
+		Section:      ctx.String(sectionFlag),
+	}
+	if raw := ctx.String(startFlag); raw != "" {
+		start, err := parsePosition(raw)
+		if err != nil {
+			return data.PlayOptions{}, err
+		}
+		opts.Start = &start
+	}
+	if raw := ctx.String(endFlag); raw != "" {
+		end, err := parsePosition(raw)
+		if err != nil {
+			return data.PlayOptions{}, err
+		}
+		opts.End = &end
+	}
+	return opts, nil
+}
+
 func playAction(ctx *cli.Context) error {
 	music := ctx.Args().First()
 	if music == "" {
 		return errors.New("specify a music")
 	}
 
+	opts, err := Options(ctx)
+	if err != nil {
+		return err
+	}
+	opts.DryRun = ctx.Bool(dryRunFlag)
+	format := ctx.String(formatFlag)
+	switch {
+	case !slices.Contains([]string{textFormat, jsonFormat, csvFormat}, format):
+		return fmt.Errorf("invalid format %q, expected text, json or csv", format)
+	case format != textFormat && !opts.DryRun:
+		return errors.New("only the schedule of a dry run has a format")
+	}
+
 	cli, err := utils.GetConductorCli(ctx)
 	if err != nil {
 		return err
 	}
 
-	return cli.PlayMusic(music)
+	plan, err := cli.PlayMusic(music, opts)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case jsonFormat:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(api.ScheduleToDto(*plan.Schedule))
+	case csvFormat:
+		return api.WriteScheduleCsv(os.Stdout, *plan.Schedule)
+	}
+
+	printPlan(os.Stdout, plan)
+	if opts.DryRun {
+		if plan.Schedule != nil {
+			printSchedule(os.Stdout, *plan.Schedule)
+		}
+		fmt.Println("dry run, the music is not played")
+	}
+	return nil
+}
+
+// parsePosition parses a position given in milliseconds, as a duration or
+// as <bar>:<beat>
+func parsePosition(raw string) (data.Position, error) {
+	if barRaw, beatRaw, ok := strings.Cut(raw, ":"); ok {
+		bar, err := strconv.Atoi(barRaw)
+		if err != nil || bar < 1 {
+			return data.Position{}, fmt.Errorf("invalid bar in %q, bars count from 1", raw)
+		}
+		beat, err := strconv.Atoi(beatRaw)
+		if err != nil || beat < 1 {
+			return data.Position{}, fmt.Errorf("invalid beat in %q, beats count from 1", raw)
+		}
+		return data.Position{Bar: bar, Beat: beat}, nil
+	}
+
+	if ms, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return data.Position{Time: time.Duration(ms) * time.Millisecond}, nil
+	}
+
+	pos, err := time.ParseDuration(raw)
+	if err != nil {
+		return data.Position{}, fmt.Errorf("invalid position %q", raw)
+	}
+	return data.Position{Time: pos}, nil
+}
+
+// parseAssignment parses <track>[:<channel>]=<musician ID>
+func parseAssignment(raw string) (data.TrackAssignment, error) {
+	lane, musician, ok := strings.Cut(raw, "=")
+	if !ok {
+		return data.TrackAssignment{}, fmt.Errorf("invalid assignment %q, expected <track>[:<channel>]=<musician ID>", raw)
+	}
+
+	id, err := data.IdFromHex(musician)
+	if err != nil {
+		return data.TrackAssignment{}, fmt.Errorf("invalid musician id in %q", raw)
+	}
+
+	trackRaw, channelRaw, split := strings.Cut(lane, ":")
+	track, err := strconv.Atoi(trackRaw)
+	if err != nil || track < 0 {
+		return data.TrackAssignment{}, fmt.Errorf("invalid track in %q", raw)
+	}
+
+	a := data.TrackAssignment{Track: track, Musician: &id}
+	if split {
+		channel, err := strconv.ParseUint(channelRaw, 10, 8)
+		if err != nil || channel > 15 {
+			return data.TrackAssignment{}, fmt.Errorf("invalid channel in %q, channels go from 0 to 15", raw)
+		}
+		ch := uint8(channel)
+		a.Channel = &ch
+	}
+	return a, nil
+}
+
+func printPlan(w io.Writer, plan data.PlayPlan) {
+	if plan.Performance != (data.ID{}) {
+		fmt.Fprintf(w, "performance %s\n", plan.Performance.Hex())
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TRACK\tCHANNEL\tMUSICIAN")
+	for _, t := range plan.Tracks {
+		channel := "all"
+		if t.Channel != nil {
+			channel = strconv.Itoa(int(*t.Channel))
+		}
+		musician := "-"
+		if t.Musician != nil {
+			musician = t.Musician.Hex()
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", t.Track, channel, musician)
+	}
+	tw.Flush()
+
+	if len(plan.Skipped) > 0 {
+		skipped := make([]string, len(plan.Skipped))
+		for i, track := range plan.Skipped {
+			skipped[i] = strconv.Itoa(track)
+		}
+		fmt.Fprintf(w, "skipped tracks without notes: %s\n", strings.Join(skipped, ", "))
+	}
+
+	if len(plan.Excluded) > 0 {
+		excluded := make([]string, len(plan.Excluded))
+		for i, track := range plan.Excluded {
+			excluded[i] = strconv.Itoa(track)
+		}
+		fmt.Fprintf(w, "excluded tracks: %s\n", strings.Join(excluded, ", "))
+	}
+
+	fmt.Fprintf(w, "playing from %s to %s\n", plan.Start.Round(time.Millisecond), plan.End.Round(time.Millisecond))
+}
+
+func printSchedule(w io.Writer, s data.Schedule) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MUSICIAN\tTRACK\tEVENTS\tNOTES\tPEAK NOTES/S\tPOLYPHONY\tFIRST\tLAST")
+	for _, ms := range s.Musicians {
+		printLoad(tw, ms.Musician.Hex(), "-", ms.Load)
+	}
+	for _, ts := range s.Unassigned {
+		printLoad(tw, "-", strconv.Itoa(ts.Track), ts.Load)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "lasting %s\n", s.Duration.Round(time.Millisecond))
+}
+
+func printLoad(w io.Writer, musician, track string, l data.Load) {
+	first, last := "-", "-"
+	if l.Events > 0 {
+		first = l.First.Round(time.Millisecond).String()
+		last = l.Last.Round(time.Millisecond).String()
+	}
+	fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\n",
+		musician, track, l.Events, l.Notes, l.PeakRate, l.Polyphony, first, last)
 }
@@ -0,0 +1,337 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"crossjoin.com/gorxestra/cmd/cli/command/play"
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	conductor "crossjoin.com/gorxestra/daemon/conductord/api/client/v1"
+	"crossjoin.com/gorxestra/data"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	repeatFlag  = "repeat"
+	shuffleFlag = "shuffle"
+	gaplessFlag = "gapless"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "queue",
+		Aliases:      nil,
+		Usage:        "<add|ls|rm|mv|skip|clear|play|mode>",
+		UsageText:    "",
+		Description:  "Manage the musics the conductor plays one after the other",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       nil,
+		OnUsageError: nil,
+		Subcommands: cli.Commands{
+			addCommand(),
+			listCommand(),
+			removeCommand(),
+			moveCommand(),
+			skipCommand(),
+			clearCommand(),
+			playCommand(),
+			modeCommand(),
+		},
+		//nolint
+		Flags:                  []cli.Flag{},
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func addCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "add",
+		Usage:       "<music>",
+		Description: "Add a music of the library to the queue, with the options of play",
+		Action:      addAction,
+		Flags:       play.OptionFlags(),
+	}
+}
+
+func listCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "ls",
+		Description: "List the items of the queue",
+		Action:      listAction,
+	}
+}
+
+func removeCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "rm",
+		Usage:       "<item ID>",
+		Description: "Remove an item waiting in the queue, a prefix of its ID is enough",
+		Action:      removeAction,
+	}
+}
+
+func moveCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "mv",
+		Usage:       "<item ID> <index>",
+		Description: "Move an item waiting in the queue, index 0 plays it next",
+		Action:      moveAction,
+	}
+}
+
+func skipCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "skip",
+		Description: "Stop the item being played and play the next one",
+		Action:      skipAction,
+	}
+}
+
+func clearCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "clear",
+		Description: "Remove the items waiting in the queue",
+		Action:      clearAction,
+	}
+}
+
+func playCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "play",
+		Description: "Start the queue held after a stop or a restart of the conductor",
+		Action:      playAction,
+	}
+}
+
+func modeCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "mode",
+		Description: "Change how the queue moves from an item to the next",
+		Action:      modeAction,
+		//nolint
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  repeatFlag,
+				Usage: "off, one to play the current item until skipped or all to play the items again",
+			},
+			&cli.BoolFlag{
+				Name:  shuffleFlag,
+				Usage: "play the items in a random order",
+			},
+			&cli.BoolFlag{
+				Name:  gaplessFlag,
+				Usage: "start the next item at the end of the last note of the current one",
+			},
+		},
+	}
+}
+
+func addAction(ctx *cli.Context) error {
+	music := ctx.Args().First()
+	if music == "" {
+		return errors.New("specify a music")
+	}
+
+	opts, err := play.Options(ctx)
+	if err != nil {
+		return err
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	item, err := cli.QueueMusic(music, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("queued %s as %s\n", item.Music, item.Id.Hex())
+	return nil
+}
+
+func listAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	queue, err := cli.Queue()
+	if err != nil {
+		return err
+	}
+
+	printQueue(os.Stdout, queue)
+	return nil
+}
+
+func removeAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := findItem(cli, ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	return cli.RemoveQueueItem(id)
+}
+
+func moveAction(ctx *cli.Context) error {
+	if ctx.Args().Len() != 2 {
+		return errors.New("specify an item and its index")
+	}
+	index, err := strconv.Atoi(ctx.Args().Get(1))
+	if err != nil || index < 0 {
+		return fmt.Errorf("invalid index %q", ctx.Args().Get(1))
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := findItem(cli, ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	return cli.MoveQueueItem(id, index)
+}
+
+func skipAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cli.SkipQueue()
+}
+
+func clearAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cli.ClearQueue()
+}
+
+func playAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cli.PlayQueue()
+}
+
+func modeAction(ctx *cli.Context) error {
+	var repeat *data.RepeatMode
+	if ctx.IsSet(repeatFlag) {
+		mode := data.RepeatMode(ctx.String(repeatFlag))
+		if !mode.Valid() {
+			return fmt.Errorf("invalid repeat mode %q, expected off, one or all", mode)
+		}
+		repeat = &mode
+	}
+	var shuffle, gapless *bool
+	if ctx.IsSet(shuffleFlag) {
+		v := ctx.Bool(shuffleFlag)
+		shuffle = &v
+	}
+	if ctx.IsSet(gaplessFlag) {
+		v := ctx.Bool(gaplessFlag)
+		gapless = &v
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	queue, err := cli.SetQueueModes(repeat, shuffle, gapless)
+	if err != nil {
+		return err
+	}
+
+	printModes(os.Stdout, queue)
+	return nil
+}
+
+// findItem returns the item waiting in the queue whose ID starts with
+// prefix
+func findItem(cli conductor.ClientDaemon, prefix string) (data.ID, error) {
+	if prefix == "" {
+		return data.ID{}, errors.New("specify an item")
+	}
+
+	queue, err := cli.Queue()
+	if err != nil {
+		return data.ID{}, err
+	}
+
+	var found []data.ID
+	for _, item := range queue.Items {
+		if strings.HasPrefix(item.Id.Hex(), strings.ToLower(prefix)) {
+			found = append(found, item.Id)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return data.ID{}, data.ErrQueueItemNotFound
+	case 1:
+		return found[0], nil
+	default:
+		return data.ID{}, fmt.Errorf("%q matches %d items", prefix, len(found))
+	}
+}
+
+func printQueue(w io.Writer, q data.Queue) {
+	if q.Current != nil {
+		fmt.Fprintf(w, "playing: %s (%s)\n", q.Current.Music, q.Current.Id.Hex())
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tID\tMUSIC")
+	for i, item := range q.Items {
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", i, item.Id.Hex(), item.Music)
+	}
+	tw.Flush()
+
+	printModes(w, q)
+	if q.Held {
+		fmt.Fprintln(w, "held, run queue play to start it")
+	}
+}
+
+func printModes(w io.Writer, q data.Queue) {
+	fmt.Fprintf(w, "repeat: %s, shuffle: %s, gapless: %s\n",
+		q.Modes.Repeat, onOff(q.Modes.Shuffle), onOff(q.Modes.Gapless))
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
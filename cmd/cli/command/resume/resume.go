@@ -0,0 +1,47 @@
+package resume
+
+import (
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"github.com/urfave/cli/v2"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "resume",
+		Aliases:      nil,
+		Usage:        "",
+		UsageText:    "",
+		Description:  "Resume the paused music",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       resumeAction,
+		OnUsageError: nil,
+		Subcommands:  cli.Commands{},
+		//nolint
+		Flags:                  utils.PerformanceFlags(),
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func resumeAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := utils.Performance(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	return cli.ResumeMusic(id)
+}
@@ -0,0 +1,162 @@
+package section
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"crossjoin.com/gorxestra/data"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	labelFlag    = "label"
+	musicianFlag = "musician"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "section",
+		Aliases:      nil,
+		Usage:        "<set|ls|rm>",
+		UsageText:    "",
+		Description:  "Manage the sections of the orchestra, the groups of musicians playing a music together",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       nil,
+		OnUsageError: nil,
+		Subcommands: cli.Commands{
+			setCommand(),
+			listCommand(),
+			removeCommand(),
+		},
+		//nolint
+		Flags:                  []cli.Flag{},
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func setCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "set",
+		Usage:       "<name>",
+		Description: "Define a section, replacing the section of the same name",
+		Action:      setAction,
+		//nolint
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    labelFlag,
+				Aliases: []string{"l"},
+				Usage:   "label of the musicians of the section, repeatable",
+			},
+			&cli.StringSliceFlag{
+				Name:    musicianFlag,
+				Aliases: []string{"m"},
+				Usage:   "id of a musician of the section, repeatable",
+			},
+		},
+	}
+}
+
+func listCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "ls",
+		Description: "List the sections",
+		Action:      listAction,
+	}
+}
+
+func removeCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "rm",
+		Usage:       "<name>",
+		Description: "Remove a section",
+		Action:      removeAction,
+	}
+}
+
+func setAction(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		return errors.New("specify a section")
+	}
+
+	section := data.Section{Name: name, Labels: ctx.StringSlice(labelFlag)}
+	for _, raw := range ctx.StringSlice(musicianFlag) {
+		id, err := data.IdFromHex(raw)
+		if err != nil {
+			return fmt.Errorf("invalid musician %q", raw)
+		}
+		section.Musicians = append(section.Musicians, id)
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cli.SetSection(section)
+}
+
+func listAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	sections, err := cli.Sections()
+	if err != nil {
+		return err
+	}
+
+	printSections(os.Stdout, sections)
+	return nil
+}
+
+func removeAction(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		return errors.New("specify a section")
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cli.RemoveSection(name)
+}
+
+func printSections(w io.Writer, sections []data.Section) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tLABELS\tMUSICIANS")
+	for _, s := range sections {
+		musicians := make([]string, len(s.Musicians))
+		for i := range s.Musicians {
+			musicians[i] = s.Musicians[i].Hex()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Name, orDash(strings.Join(s.Labels, ",")), orDash(strings.Join(musicians, ",")))
+	}
+	tw.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
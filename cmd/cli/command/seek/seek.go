@@ -0,0 +1,74 @@
+package seek
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"github.com/urfave/cli/v2"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "seek",
+		Aliases:      nil,
+		Usage:        "<position>",
+		UsageText:    "",
+		Description:  "Move the music being played to a position, in milliseconds or as a duration such as 1m30s",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       seekAction,
+		OnUsageError: nil,
+		Subcommands:  cli.Commands{},
+		//nolint
+		Flags:                  utils.PerformanceFlags(),
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func seekAction(ctx *cli.Context) error {
+	raw := ctx.Args().First()
+	if raw == "" {
+		return errors.New("specify a position")
+	}
+
+	pos, err := parsePosition(raw)
+	if err != nil {
+		return err
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := utils.Performance(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	return cli.SeekMusic(id, pos)
+}
+
+// parsePosition reads a position given in milliseconds or as a duration
+func parsePosition(raw string) (time.Duration, error) {
+	if ms, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+
+	pos, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, errors.New("invalid position")
+	}
+	return pos, nil
+}
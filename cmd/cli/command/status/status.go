@@ -0,0 +1,165 @@
+package status
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"crossjoin.com/gorxestra/data"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	watchFlag    = "watch"
+	intervalFlag = "interval"
+
+	progressWidth = 40
+
+	// clearScreen moves the cursor home and clears the terminal
+	clearScreen = "\033[H\033[2J"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "status",
+		Aliases:      nil,
+		Usage:        "",
+		UsageText:    "",
+		Description:  "Show the music being played and its progress",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       statusAction,
+		OnUsageError: nil,
+		Subcommands:  cli.Commands{},
+		//nolint
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    watchFlag,
+				Aliases: []string{"w"},
+				Usage:   "keep refreshing the status",
+			},
+			&cli.DurationFlag{
+				Name:  intervalFlag,
+				Value: 500 * time.Millisecond,
+				Usage: "refresh interval of --watch",
+			},
+			utils.PerformanceFlags()[0],
+		},
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func statusAction(ctx *cli.Context) error {
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := utils.Performance(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Bool(watchFlag) {
+		status, err := cli.MusicStatus(id)
+		if err != nil {
+			return err
+		}
+		printStatus(os.Stdout, status)
+		return nil
+	}
+
+	ticker := time.NewTicker(ctx.Duration(intervalFlag))
+	defer ticker.Stop()
+	for {
+		status, err := cli.MusicStatus(id)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(clearScreen)
+		printStatus(os.Stdout, status)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func printStatus(w io.Writer, st data.PlaybackStatus) {
+	music := st.Music
+	if music == "" {
+		music = "-"
+	}
+
+	state := string(st.State)
+	if st.State == data.PlaybackStopped && st.Completed {
+		state += " (completed)"
+	}
+
+	if st.Performance != (data.ID{}) {
+		section := st.Section
+		if section == "" {
+			section = "whole orchestra"
+		}
+		fmt.Fprintf(w, "id:       %s\n", st.Performance.Hex())
+		fmt.Fprintf(w, "section:  %s\n", section)
+	}
+	fmt.Fprintf(w, "music:    %s\n", music)
+	fmt.Fprintf(w, "state:    %s\n", state)
+	fmt.Fprintf(w, "progress: %s %s / %s\n",
+		progressBar(st.Elapsed, st.Duration),
+		formatDuration(st.Elapsed),
+		formatDuration(st.Duration))
+	fmt.Fprintf(w, "tempo:    x%g, %+d semitones\n", st.Interpretation.Tempo, st.Interpretation.Transpose)
+	fmt.Fprintf(w, "notes:    %d dropped, %d failed\n", st.DroppedNotes, st.FailedNotes)
+	if len(st.Mixer.Musicians) > 0 || len(st.Mixer.Tracks) > 0 {
+		fmt.Fprintln(w, "mixer:")
+		utils.PrintMixer(w, st.Mixer)
+	}
+
+	if len(st.Tracks) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "tracks:")
+	for _, t := range st.Tracks {
+		musician := "-"
+		if t.Musician != nil {
+			musician = t.Musician.Hex()
+		}
+		channel := ""
+		if t.Channel != nil {
+			channel = fmt.Sprintf("ch %d", *t.Channel)
+		}
+		fmt.Fprintf(w, "  %3d %-5s  %s\n", t.Track, channel, musician)
+	}
+}
+
+func progressBar(elapsed, total time.Duration) string {
+	done := 0
+	if total > 0 {
+		done = int(int64(progressWidth) * int64(elapsed) / int64(total))
+		done = min(max(done, 0), progressWidth)
+	}
+	return "[" + strings.Repeat("#", done) + strings.Repeat("-", progressWidth-done) + "]"
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
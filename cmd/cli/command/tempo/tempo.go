@@ -0,0 +1,60 @@
+package tempo
+
+import (
+	"errors"
+	"strconv"
+
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"github.com/urfave/cli/v2"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "tempo",
+		Aliases:      nil,
+		Usage:        "<factor>",
+		UsageText:    "",
+		Description:  "Change the tempo of the music being played, 0.5 plays it twice slower and 1 as written",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       tempoAction,
+		OnUsageError: nil,
+		Subcommands:  cli.Commands{},
+		//nolint
+		Flags:                  utils.PerformanceFlags(),
+		SkipFlagParsing:        false,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func tempoAction(ctx *cli.Context) error {
+	raw := ctx.Args().First()
+	if raw == "" {
+		return errors.New("specify a tempo factor")
+	}
+
+	factor, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return errors.New("invalid tempo factor")
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := utils.Performance(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	return cli.InterpretMusic(id, &factor, nil)
+}
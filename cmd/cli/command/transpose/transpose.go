@@ -0,0 +1,62 @@
+package transpose
+
+import (
+	"errors"
+	"strconv"
+
+	"crossjoin.com/gorxestra/cmd/cli/utils"
+	"github.com/urfave/cli/v2"
+)
+
+func Commands() *cli.Command {
+	return &cli.Command{
+		Name:         "transpose",
+		Aliases:      nil,
+		Usage:        "<semitones> [performance]",
+		UsageText:    "",
+		Description:  "Shift the notes of the music being played by a number of semitones, 0 plays them as written",
+		Args:         false,
+		ArgsUsage:    "",
+		Category:     "Basic Commands (Beginner)",
+		BashComplete: nil,
+		Before:       nil,
+		After:        nil,
+		Action:       transposeAction,
+		OnUsageError: nil,
+		Subcommands:  cli.Commands{},
+		//nolint
+		Flags:                  []cli.Flag{},
+		SkipFlagParsing:        true,
+		HideHelp:               false,
+		HideHelpCommand:        false,
+		Hidden:                 false,
+		UseShortOptionHandling: false,
+		HelpName:               "",
+		CustomHelpTemplate:     "",
+	}
+}
+
+func transposeAction(ctx *cli.Context) error {
+	raw := ctx.Args().First()
+	if raw == "" {
+		return errors.New("specify a number of semitones")
+	}
+
+	semitones, err := strconv.Atoi(raw)
+	if err != nil {
+		return errors.New("invalid number of semitones")
+	}
+
+	cli, err := utils.GetConductorCli(ctx)
+	if err != nil {
+		return err
+	}
+	// The flags are not parsed so that the semitones may be negative, the
+	// performance follows them
+	id, err := utils.FindPerformance(cli, ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	return cli.InterpretMusic(id, nil, &semitones)
+}
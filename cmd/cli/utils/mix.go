@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"crossjoin.com/gorxestra/data"
+)
+
+// FormatMix describes a mix in a few words
+func FormatMix(m data.Mix) string {
+	parts := []string{fmt.Sprintf("gain x%g", m.Gain)}
+	if m.Pan != nil {
+		parts = append(parts, fmt.Sprintf("pan %d", *m.Pan))
+	}
+	if m.Mute {
+		parts = append(parts, "muted")
+	}
+	if m.Solo {
+		parts = append(parts, "solo")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// PrintMixer writes the mixes of the musicians and tracks, one per line
+func PrintMixer(w io.Writer, m data.Mixer) {
+	for _, mm := range m.Musicians {
+		fmt.Fprintf(w, "  musician %s  %s\n", mm.Musician.Hex(), FormatMix(mm.Mix))
+	}
+	for _, tm := range m.Tracks {
+		fmt.Fprintf(w, "  track %3d  %s\n", tm.Track, FormatMix(tm.Mix))
+	}
+}
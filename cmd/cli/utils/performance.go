@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	conductor "crossjoin.com/gorxestra/daemon/conductord/api/client/v1"
+	"crossjoin.com/gorxestra/data"
+	"github.com/urfave/cli/v2"
+)
+
+const PerformanceFlag = "performance"
+
+// PerformanceFlags returns the flag choosing the performance a command
+// acts on
+func PerformanceFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    PerformanceFlag,
+			Aliases: []string{"p"},
+			Usage:   "id or id prefix of the performance, the one started last when missing",
+		},
+	}
+}
+
+// Performance resolves the performance of the flag
+func Performance(ctx *cli.Context, cli conductor.ClientDaemon) (data.ID, error) {
+	return FindPerformance(cli, ctx.String(PerformanceFlag))
+}
+
+// FindPerformance resolves a performance id or id prefix, the zero id
+// standing for the performance started last. A prefix is matched against
+// the performances being played, a full id may name a performance ended.
+func FindPerformance(cli conductor.ClientDaemon, prefix string) (data.ID, error) {
+	prefix = strings.ToLower(prefix)
+	if prefix == "" {
+		return data.ID{}, nil
+	}
+
+	perfs, err := cli.Performances()
+	if err != nil {
+		return data.ID{}, err
+	}
+
+	var found []data.ID
+	for _, p := range perfs {
+		if strings.HasPrefix(p.Performance.Hex(), prefix) {
+			found = append(found, p.Performance)
+		}
+	}
+	switch len(found) {
+	case 0:
+		if id, err := data.IdFromHex(prefix); err == nil {
+			return id, nil
+		}
+		return data.ID{}, data.ErrPerformanceNotFound
+	case 1:
+		return found[0], nil
+	default:
+		return data.ID{}, fmt.Errorf("%q matches %d performances", prefix, len(found))
+	}
+}
@@ -1,12 +1,61 @@
 package config
 
+import "crossjoin.com/gorxestra/util/conf/typ"
+
 type ConductorConf struct {
 	// Root data path
 	DataDir string `conf:"default:./conductor,flag:d" json:"dataDir"`
 
 	InitAndExit bool `conf:"default:false,flag:x" json:"-"`
 
+	// MusicDir is the directory of the music library. Relative paths are
+	// resolved from DataDir.
+	MusicDir string `conf:"default:music" json:"musicDir"`
+
 	Rest Rest `json:"rest"`
 
+	Roster Roster `json:"roster"`
+
+	Playback Playback `json:"playback"`
+
 	Logger Logger `json:"logger"`
 }
+
+type Roster struct {
+	// Lease is how long a musician stays registered without renewing its
+	// registration with a heartbeat
+	Lease typ.Duration `conf:"default:15s" json:"lease"`
+
+	// ProbeTimeout is how long the musicians saved by a previous run have
+	// to answer their health check when the conductor starts
+	ProbeTimeout typ.Duration `conf:"default:2s" json:"probeTimeout"`
+}
+
+type Playback struct {
+	// LookAhead is how long before its play time a note is sent to the
+	// musician. It must cover the network delay between the conductor and
+	// the musicians.
+	LookAhead typ.Duration `conf:"default:200ms" json:"lookAhead"`
+
+	// MaxClockSkew is the largest clock offset tolerated between the
+	// conductor and a musician when a music starts.
+	MaxClockSkew typ.Duration `conf:"default:5ms" json:"maxClockSkew"`
+
+	// RefuseOnClockSkew refuses to play a music when a musician clock is
+	// skewed above MaxClockSkew. When false only a warning is logged.
+	RefuseOnClockSkew bool `conf:"default:false" json:"refuseOnClockSkew"`
+
+	// FailoverAfter is the number of consecutive failed deliveries after
+	// which the tracks of a musician are handed over to a standby
+	// musician. Zero disables the failover.
+	FailoverAfter int `conf:"default:3" json:"failoverAfter"`
+
+	// MusiciansWait is how long a music asking for a minimum number of
+	// musicians waits for them to register before giving up
+	MusiciansWait typ.Duration `conf:"default:1m" json:"musiciansWait"`
+
+	// IsolateChannels gives each channel of each track played its own
+	// MIDI channel, for musicians sharing a single synthesizer. The
+	// percussion channel is kept for the drums of every track.
+	IsolateChannels bool `conf:"default:false" json:"isolateChannels"`
+}
@@ -1,5 +1,7 @@
 package config
 
+import "crossjoin.com/gorxestra/util/conf/typ"
+
 type MusicianConf struct {
 	// Root data path
 	DataDir string `conf:"default:./conductor,flag:d" json:"dataDir"`
@@ -10,10 +12,73 @@ type MusicianConf struct {
 
 	Conductor Conductor `json:"conductor"`
 
+	ClockSync ClockSync `json:"clockSync"`
+
+	Output Output `json:"output"`
+
+	Capabilities Capabilities `json:"capabilities"`
+
 	Logger Logger `json:"logger"`
 }
 
 type Conductor struct {
 	AdvertiseAddr string `conf:"default:http://localhost:8090" json:"advertiseAddr"`
 	ConductorAddr string `conf:"default:http://localhost:8080" json:"conductorAddr"`
+
+	// Heartbeat is the time between two renewals of the registration. The
+	// registration is renewed more often when the conductor lease is
+	// shorter than three heartbeats.
+	Heartbeat typ.Duration `conf:"default:5s" json:"heartbeat"`
+}
+
+type ClockSync struct {
+	// Interval is the time between two clock exchanges with the conductor
+	Interval typ.Duration `conf:"default:10s" json:"interval"`
+
+	// Window is the number of recent exchanges the clock offset estimate
+	// is based on. The same number of exchanges is made on start.
+	Window int `conf:"default:8" json:"window"`
+}
+
+type Output struct {
+	// Backend selects where the notes are played: "port" for a MIDI out
+	// port, "smf" to record a MIDI file, "log" to only log them and
+	// "memory" to keep them in memory.
+	Backend string `conf:"default:port" json:"backend"`
+
+	// Port is matched against the names of the MIDI out ports by the port
+	// backend, the first port containing it is used.
+	Port string `conf:"default:qsynth" json:"port"`
+
+	// File is the MIDI file written by the smf backend. A relative path is
+	// resolved from the data directory.
+	File string `conf:"default:musician.mid" json:"file"`
+}
+
+// Capabilities are advertised to the conductor when registering, the
+// conductor only gives the musician the tracks it is able to play. Lists
+// are separated by ";".
+type Capabilities struct {
+	// Channels are the MIDI channels played, from 0 to 15. All channels
+	// are played when empty.
+	Channels []string `json:"channels"`
+
+	// Programs are the General MIDI programs played, from 0 to 127. A
+	// range such as "0-7" selects an instrument family. Any program is
+	// played when empty.
+	Programs []string `json:"programs"`
+
+	// Polyphony is the number of notes sounding at once, zero for no
+	// limit
+	Polyphony int `conf:"default:0" json:"polyphony"`
+
+	// Percussion tells the musician plays the drums of channel 9
+	Percussion bool `conf:"default:true" json:"percussion"`
+
+	// Latency is the time between a note being due and its sound coming
+	// out, the conductor sends the notes that much earlier
+	Latency typ.Duration `conf:"default:0s" json:"latency"`
+
+	// Labels are free-form tags describing the musician
+	Labels []string `json:"labels"`
 }
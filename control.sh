@@ -2,12 +2,16 @@
 
 
 
-if [ "$1" == "pause" ]; then
-    pkill -SIGUSR1 -f "./build/"
-    echo "Paused all services."
-elif [ "$1" == "resume" ]; then
-    pkill -SIGUSR2 -f "./build/"
-    echo "Resumed all services."
-else
-    echo "Usage: $0 {pause|resume}"
-fi
+CLI=./build/cli
+
+case "$1" in
+    pause|resume|stop)
+        $CLI "$1" && echo "Music $1 requested."
+        ;;
+    seek)
+        $CLI seek "$2" && echo "Music moved to $2."
+        ;;
+    *)
+        echo "Usage: $0 {pause|resume|stop|seek <position>}"
+        ;;
+esac
@@ -1,11 +1,55 @@
 package api
 
 import (
+	"io"
+	"time"
+
 	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/util/broadcast"
 )
 
 type NodeInterface interface {
 	RegisterMusician(m data.Musician) error
 	UnregisterMusician(id data.ID) error
-	PlayMusic(name string) error
+	Heartbeat(id data.ID) (time.Duration, error)
+	PlayMusic(name string, opts data.PlayOptions) (data.PlayPlan, error)
+	AddMusic(name string, r io.Reader) (data.MusicInfo, error)
+	ListMusic() ([]data.MusicInfo, error)
+	MusicInfo(name string) (data.MusicInfo, error)
+	RemoveMusic(name string) error
+	// The transport methods address a performance by its id, the zero id
+	// addressing the performance started last
+	StopMusic(id data.ID) error
+	PauseMusic(id data.ID) error
+	ResumeMusic(id data.ID) error
+	SeekMusic(id data.ID, pos time.Duration) error
+	// InterpretMusic changes the tempo factor and the transposition of
+	// the performance, nil leaves them as they are
+	InterpretMusic(id data.ID, tempo *float64, transpose *int) error
+	// MixMusic changes the mix of a musician or a track of the
+	// performance and returns its mixer
+	MixMusic(id data.ID, target data.MixTarget, change data.MixChange) (data.Mixer, error)
+	MusicStatus(id data.ID) (data.PlaybackStatus, error)
+	Performances() ([]data.PlaybackStatus, error)
+	Sections() ([]data.Section, error)
+	SetSection(s data.Section) error
+	RemoveSection(name string) error
+	Queue() (data.Queue, error)
+	QueueMusic(name string, opts data.PlayOptions) (data.QueueItem, error)
+	RemoveQueueItem(id data.ID) error
+	MoveQueueItem(id data.ID, to int) error
+	ClearQueue() error
+	SkipQueue() error
+	PlayQueue() error
+	// SetQueueModes changes how the queue moves from an item to the next,
+	// nil leaves a mode as it is
+	SetQueueModes(repeat *data.RepeatMode, shuffle, gapless *bool) (data.Queue, error)
+	// Panic turns all notes and sound off on every musician registered
+	Panic() error
+	Clock(originate time.Time) (data.ClockSample, error)
+}
+
+// EventSource is implemented by the node to stream its events
+type EventSource interface {
+	Subscribe(buffer int) *broadcast.Subscription[data.Event]
 }
@@ -5,6 +5,7 @@ import (
 	"net/url"
 
 	"crossjoin.com/gorxestra/daemon/conductord/api"
+	"crossjoin.com/gorxestra/data"
 	utilClient "crossjoin.com/gorxestra/util/http/client"
 )
 
@@ -24,7 +25,7 @@ func New(addr string) (client, error) {
 
 	c := client{
 		httpClient: &httpClient{
-			restClient: utilClient.MakeRestClient(*u),
+			restClient: utilClient.MakeRestClientWithMapper(*u, data.StrErrorMapper),
 		},
 	}
 	return c, nil
@@ -2,11 +2,16 @@ package client
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"time"
 
+	"crossjoin.com/gorxestra/daemon/conductord/api"
 	"crossjoin.com/gorxestra/daemon/conductord/api/server/v1/openapi/generated/model"
 	"crossjoin.com/gorxestra/data"
 	utilClient "crossjoin.com/gorxestra/util/http/client"
+	"crossjoin.com/gorxestra/util/http/client/protocol"
 )
 
 const (
@@ -16,7 +21,36 @@ const (
 
 	registerMusicianPath   = "/v1/musician"
 	unregisterMusicianPath = "/v1/musician/%s"
+	heartbeatPath          = "/v1/musician/%s/heartbeat"
 	playMusicPath          = "/v1/music/play/%s"
+	musicPath              = "/v1/music"
+	musicInfoPath          = "/v1/music/%s"
+	musicStatusPath        = "/v1/music/status"
+	stopMusicPath          = "/v1/music/stop"
+	pauseMusicPath         = "/v1/music/pause"
+	resumeMusicPath        = "/v1/music/resume"
+	seekMusicPath          = "/v1/music/seek"
+	interpretMusicPath     = "/v1/music/interpretation"
+	performancesPath       = "/v1/performances"
+	performancePath        = "/v1/performances/%s"
+	stopPerformancePath    = "/v1/performances/%s/stop"
+	pausePerformancePath   = "/v1/performances/%s/pause"
+	resumePerformancePath  = "/v1/performances/%s/resume"
+	seekPerformancePath    = "/v1/performances/%s/seek"
+	interpretPerfPath      = "/v1/performances/%s/interpretation"
+	mixMusicPath           = "/v1/music/mix"
+	mixPerformancePath     = "/v1/performances/%s/mix"
+	sectionsPath           = "/v1/sections"
+	sectionPath            = "/v1/sections/%s"
+	queuePath              = "/v1/queue"
+	queueMusicPath         = "/v1/queue/music/%s"
+	queueItemPath          = "/v1/queue/item/%s"
+	moveQueueItemPath      = "/v1/queue/item/%s/move"
+	skipQueuePath          = "/v1/queue/skip"
+	playQueuePath          = "/v1/queue/play"
+	queueModesPath         = "/v1/queue/modes"
+	panicPath              = "/v1/panic"
+	clockPath              = "/v1/clock"
 )
 
 type httpClient struct {
@@ -58,11 +92,8 @@ func (h *httpClient) RegisterMusician(m data.Musician) error {
 	request := utilClient.Request{
 		Path:        registerMusicianPath,
 		QueryParams: nil,
-		Body: model.Musician{
-			Address: m.Address,
-			Id:      m.Id.Hex(),
-		},
-		Method: http.MethodPost,
+		Body:        api.MusicianToDto(m),
+		Method:      http.MethodPost,
 	}
 
 	return h.restClient.JsonSubmitForm(nil, request)
@@ -79,13 +110,377 @@ func (h *httpClient) UnregisterMusician(id data.ID) error {
 	return h.restClient.JsonSubmitForm(nil, request)
 }
 
-func (h *httpClient) PlayMusic(name string) error {
+func (h *httpClient) Heartbeat(id data.ID) (time.Duration, error) {
+	request := utilClient.Request{
+		Path:        fmt.Sprintf(heartbeatPath, id.Hex()),
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodPost,
+	}
+
+	var resp model.Lease
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(resp.LeaseMs) * time.Millisecond, nil
+}
+
+func (h *httpClient) PlayMusic(name string, opts data.PlayOptions) (data.PlayPlan, error) {
+	body := api.PlayOptionsToDto(opts)
+
+	request := utilClient.Request{
+		Path:        fmt.Sprintf(playMusicPath, url.PathEscape(name)),
+		QueryParams: nil,
+		Body:        body,
+		Method:      http.MethodPost,
+	}
+
+	var resp model.PlayPlan
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return data.PlayPlan{}, err
+	}
+
+	return api.PlayPlanDtoToPlayPlan(resp)
+}
+
+func (h *httpClient) AddMusic(name string, r io.Reader) (data.MusicInfo, error) {
+	request := utilClient.Request{
+		Path:        musicPath,
+		QueryParams: nil,
+		Body: protocol.MultipartBody{
+			Fields: map[string]string{"name": name},
+			Files: []protocol.FormFile{{
+				Field:    "file",
+				FileName: name,
+				Content:  r,
+			}},
+		},
+		Method: http.MethodPost,
+	}
+
+	var resp model.MusicInfo
+	err := h.restClient.MultipartSubmitForm(&resp, request)
+	if err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	return api.MusicInfoDtoToMusicInfo(resp), nil
+}
+
+func (h *httpClient) ListMusic() ([]data.MusicInfo, error) {
+	request := utilClient.Request{
+		Path:        musicPath,
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodGet,
+	}
+
+	var resp []model.MusicInfo
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return nil, err
+	}
+
+	musics := make([]data.MusicInfo, len(resp))
+	for i := range resp {
+		musics[i] = api.MusicInfoDtoToMusicInfo(resp[i])
+	}
+	return musics, nil
+}
+
+func (h *httpClient) MusicInfo(name string) (data.MusicInfo, error) {
+	request := utilClient.Request{
+		Path:        fmt.Sprintf(musicInfoPath, url.PathEscape(name)),
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodGet,
+	}
+
+	var resp model.MusicInfo
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	return api.MusicInfoDtoToMusicInfo(resp), nil
+}
+
+func (h *httpClient) RemoveMusic(name string) error {
+	request := utilClient.Request{
+		Path:        fmt.Sprintf(musicInfoPath, url.PathEscape(name)),
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodDelete,
+	}
+
+	return h.restClient.JsonSubmitForm(nil, request)
+}
+
+// performanceOr returns the path of the performance, or the path acting
+// on the performance started last for the zero id
+func performanceOr(id data.ID, last, path string) string {
+	if id == (data.ID{}) {
+		return last
+	}
+	return fmt.Sprintf(path, id.Hex())
+}
+
+func (h *httpClient) MusicStatus(id data.ID) (data.PlaybackStatus, error) {
+	request := utilClient.Request{
+		Path:        performanceOr(id, musicStatusPath, performancePath),
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodGet,
+	}
+
+	var resp model.PlaybackStatus
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return data.PlaybackStatus{}, err
+	}
+
+	return api.PlaybackStatusDtoToPlaybackStatus(resp)
+}
+
+func (h *httpClient) Performances() ([]data.PlaybackStatus, error) {
+	request := utilClient.Request{
+		Path:        performancesPath,
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodGet,
+	}
+
+	var resp []model.PlaybackStatus
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return nil, err
+	}
+
+	perfs := make([]data.PlaybackStatus, len(resp))
+	for i := range resp {
+		perfs[i], err = api.PlaybackStatusDtoToPlaybackStatus(resp[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return perfs, nil
+}
+
+func (h *httpClient) StopMusic(id data.ID) error {
+	return h.transport(performanceOr(id, stopMusicPath, stopPerformancePath), nil)
+}
+
+func (h *httpClient) PauseMusic(id data.ID) error {
+	return h.transport(performanceOr(id, pauseMusicPath, pausePerformancePath), nil)
+}
+
+func (h *httpClient) ResumeMusic(id data.ID) error {
+	return h.transport(performanceOr(id, resumeMusicPath, resumePerformancePath), nil)
+}
+
+func (h *httpClient) SeekMusic(id data.ID, pos time.Duration) error {
+	return h.transport(performanceOr(id, seekMusicPath, seekPerformancePath), model.SeekMusicParams{
+		Ms: pos.Milliseconds(),
+	})
+}
+
+func (h *httpClient) InterpretMusic(id data.ID, tempo *float64, transpose *int) error {
+	return h.transport(performanceOr(id, interpretMusicPath, interpretPerfPath), model.InterpretMusicParams{
+		Tempo:     tempo,
+		Transpose: transpose,
+	})
+}
+
+func (h *httpClient) MixMusic(id data.ID, target data.MixTarget, change data.MixChange) (data.Mixer, error) {
+	request := utilClient.Request{
+		Path:        performanceOr(id, mixMusicPath, mixPerformancePath),
+		QueryParams: api.MixChangeToParams(target, change),
+		Body:        nil,
+		Method:      http.MethodPost,
+	}
+
+	var resp model.Mixer
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return data.Mixer{}, err
+	}
+
+	return api.MixerDtoToMixer(resp)
+}
+
+func (h *httpClient) Sections() ([]data.Section, error) {
+	request := utilClient.Request{
+		Path:        sectionsPath,
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodGet,
+	}
+
+	var resp []model.Section
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make([]data.Section, len(resp))
+	for i := range resp {
+		sections[i], err = api.SectionDtoToSection(resp[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sections, nil
+}
+
+func (h *httpClient) SetSection(s data.Section) error {
+	request := utilClient.Request{
+		Path:        fmt.Sprintf(sectionPath, url.PathEscape(s.Name)),
+		QueryParams: nil,
+		Body:        api.SectionToDto(s),
+		Method:      http.MethodPut,
+	}
+
+	return h.restClient.JsonSubmitForm(nil, request)
+}
+
+func (h *httpClient) RemoveSection(name string) error {
+	request := utilClient.Request{
+		Path:        fmt.Sprintf(sectionPath, url.PathEscape(name)),
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodDelete,
+	}
+
+	return h.restClient.JsonSubmitForm(nil, request)
+}
+
+func (h *httpClient) Queue() (data.Queue, error) {
 	request := utilClient.Request{
-		Path:        fmt.Sprintf(playMusicPath, name),
+		Path:        queuePath,
 		QueryParams: nil,
 		Body:        nil,
+		Method:      http.MethodGet,
+	}
+
+	var resp model.Queue
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return data.Queue{}, err
+	}
+
+	return api.QueueDtoToQueue(resp)
+}
+
+func (h *httpClient) QueueMusic(name string, opts data.PlayOptions) (data.QueueItem, error) {
+	request := utilClient.Request{
+		Path:        fmt.Sprintf(queueMusicPath, url.PathEscape(name)),
+		QueryParams: nil,
+		Body:        api.PlayOptionsToDto(opts),
 		Method:      http.MethodPost,
 	}
 
+	var resp model.QueueItem
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return data.QueueItem{}, err
+	}
+
+	return api.QueueItemDtoToQueueItem(resp)
+}
+
+func (h *httpClient) RemoveQueueItem(id data.ID) error {
+	request := utilClient.Request{
+		Path:        fmt.Sprintf(queueItemPath, id.Hex()),
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodDelete,
+	}
+
 	return h.restClient.JsonSubmitForm(nil, request)
 }
+
+func (h *httpClient) MoveQueueItem(id data.ID, to int) error {
+	return h.transport(fmt.Sprintf(moveQueueItemPath, id.Hex()), model.MoveQueueItemParams{
+		Index: to,
+	})
+}
+
+func (h *httpClient) ClearQueue() error {
+	request := utilClient.Request{
+		Path:        queuePath,
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodDelete,
+	}
+
+	return h.restClient.JsonSubmitForm(nil, request)
+}
+
+func (h *httpClient) SkipQueue() error {
+	return h.transport(skipQueuePath, nil)
+}
+
+func (h *httpClient) PlayQueue() error {
+	return h.transport(playQueuePath, nil)
+}
+
+func (h *httpClient) SetQueueModes(repeat *data.RepeatMode, shuffle, gapless *bool) (data.Queue, error) {
+	params := model.SetQueueModesParams{
+		Shuffle: shuffle,
+		Gapless: gapless,
+	}
+	if repeat != nil {
+		mode := model.SetQueueModesParamsRepeat(*repeat)
+		params.Repeat = &mode
+	}
+
+	request := utilClient.Request{
+		Path:        queueModesPath,
+		QueryParams: params,
+		Body:        nil,
+		Method:      http.MethodPost,
+	}
+
+	var resp model.Queue
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return data.Queue{}, err
+	}
+
+	return api.QueueDtoToQueue(resp)
+}
+
+func (h *httpClient) Panic() error {
+	return h.transport(panicPath, nil)
+}
+
+func (h *httpClient) transport(path string, params interface{}) error {
+	request := utilClient.Request{
+		Path:        path,
+		QueryParams: params,
+		Body:        nil,
+		Method:      http.MethodPost,
+	}
+
+	return h.restClient.JsonSubmitForm(nil, request)
+}
+
+func (h *httpClient) Clock(originate time.Time) (data.ClockSample, error) {
+	request := utilClient.Request{
+		Path:        clockPath,
+		QueryParams: nil,
+		Body:        model.ClockRequest{Originate: originate},
+		Method:      http.MethodPost,
+	}
+
+	var resp model.ClockSample
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return data.ClockSample{}, err
+	}
+
+	return api.ClockSampleDtoToClockSample(resp), nil
+}
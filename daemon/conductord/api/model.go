@@ -1,5 +1,12 @@
 package api
 
+import (
+	"time"
+
+	"crossjoin.com/gorxestra/daemon/conductord/api/server/v1/openapi/generated/model"
+	"crossjoin.com/gorxestra/data"
+)
+
 // func MarkNodeDownParamsDtoToMarkNodeDownParams(params model.MarkNodeDownParams) (data.MarkNodeDownParams, error) {
 // 	nodeId, err := data.IdFromHex(params.NodeId)
 // 	if err != nil {
@@ -11,3 +18,727 @@ package api
 // 		NodeAddress: params.NodeAddress,
 // 	}, nil
 // }
+
+func ClockSampleToDto(s data.ClockSample) model.ClockSample {
+	return model.ClockSample{
+		Originate: s.Originate,
+		Receive:   s.Receive,
+		Transmit:  s.Transmit,
+	}
+}
+
+func ClockSampleDtoToClockSample(dto model.ClockSample) data.ClockSample {
+	return data.ClockSample{
+		Originate: dto.Originate,
+		Receive:   dto.Receive,
+		Transmit:  dto.Transmit,
+	}
+}
+
+func PlaybackStatusToDto(st data.PlaybackStatus) model.PlaybackStatus {
+	dto := model.PlaybackStatus{
+		Completed:    st.Completed,
+		DroppedNotes: st.DroppedNotes,
+		DurationMs:   st.Duration.Milliseconds(),
+		ElapsedMs:    st.Elapsed.Milliseconds(),
+		FailedNotes:  st.FailedNotes,
+		State:        model.PlaybackStatusState(st.State),
+		Tracks:       make([]model.TrackAssignment, len(st.Tracks)),
+		Tempo:        st.Interpretation.Tempo,
+		Transpose:    st.Interpretation.Transpose,
+		Mixer:        MixerToDto(st.Mixer),
+	}
+
+	if st.Performance != (data.ID{}) {
+		id := st.Performance.Hex()
+		dto.Performance = &id
+	}
+	if st.Section != "" {
+		dto.Section = &st.Section
+	}
+	if st.Music != "" {
+		dto.Music = &st.Music
+	}
+
+	for i, t := range st.Tracks {
+		dto.Tracks[i] = TrackAssignmentToDto(t)
+	}
+
+	return dto
+}
+
+func PlaybackStatusDtoToPlaybackStatus(dto model.PlaybackStatus) (data.PlaybackStatus, error) {
+	st := data.PlaybackStatus{
+		State:        data.PlaybackState(dto.State),
+		Completed:    dto.Completed,
+		Elapsed:      time.Duration(dto.ElapsedMs) * time.Millisecond,
+		Duration:     time.Duration(dto.DurationMs) * time.Millisecond,
+		Tracks:       make([]data.TrackAssignment, len(dto.Tracks)),
+		DroppedNotes: dto.DroppedNotes,
+		FailedNotes:  dto.FailedNotes,
+		Interpretation: data.Interpretation{
+			Tempo:     dto.Tempo,
+			Transpose: dto.Transpose,
+		},
+	}
+
+	if dto.Performance != nil {
+		id, err := data.IdFromHex(*dto.Performance)
+		if err != nil {
+			return data.PlaybackStatus{}, err
+		}
+		st.Performance = id
+	}
+	if dto.Section != nil {
+		st.Section = *dto.Section
+	}
+	if dto.Music != nil {
+		st.Music = *dto.Music
+	}
+
+	for i, t := range dto.Tracks {
+		a, err := TrackAssignmentDtoToTrackAssignment(t)
+		if err != nil {
+			return data.PlaybackStatus{}, err
+		}
+		st.Tracks[i] = a
+	}
+
+	mixer, err := MixerDtoToMixer(dto.Mixer)
+	if err != nil {
+		return data.PlaybackStatus{}, err
+	}
+	st.Mixer = mixer
+
+	return st, nil
+}
+
+func TrackAssignmentToDto(a data.TrackAssignment) model.TrackAssignment {
+	dto := model.TrackAssignment{Track: a.Track}
+	if a.Channel != nil {
+		channel := int(*a.Channel)
+		dto.Channel = &channel
+	}
+	if a.Musician != nil {
+		id := a.Musician.Hex()
+		dto.Musician = &id
+	}
+	return dto
+}
+
+func TrackAssignmentDtoToTrackAssignment(dto model.TrackAssignment) (data.TrackAssignment, error) {
+	a := data.TrackAssignment{Track: dto.Track}
+	if dto.Channel != nil {
+		if *dto.Channel < 0 || *dto.Channel > 15 {
+			return data.TrackAssignment{}, data.ErrInvalidAssignment
+		}
+		channel := uint8(*dto.Channel)
+		a.Channel = &channel
+	}
+	if dto.Musician != nil {
+		id, err := data.IdFromHex(*dto.Musician)
+		if err != nil {
+			return data.TrackAssignment{}, err
+		}
+		a.Musician = &id
+	}
+	return a, nil
+}
+
+func PlayPlanToDto(p data.PlayPlan) model.PlayPlan {
+	dto := model.PlayPlan{
+		Tracks:   make([]model.TrackAssignment, len(p.Tracks)),
+		Skipped:  make([]int, 0, len(p.Skipped)),
+		Excluded: make([]int, 0, len(p.Excluded)),
+		StartMs:  p.Start.Milliseconds(),
+		EndMs:    p.End.Milliseconds(),
+	}
+	if p.Performance != (data.ID{}) {
+		id := p.Performance.Hex()
+		dto.Performance = &id
+	}
+	for i, t := range p.Tracks {
+		dto.Tracks[i] = TrackAssignmentToDto(t)
+	}
+	dto.Skipped = append(dto.Skipped, p.Skipped...)
+	dto.Excluded = append(dto.Excluded, p.Excluded...)
+	if p.Schedule != nil {
+		schedule := ScheduleToDto(*p.Schedule)
+		dto.Schedule = &schedule
+	}
+	return dto
+}
+
+func PlayPlanDtoToPlayPlan(dto model.PlayPlan) (data.PlayPlan, error) {
+	p := data.PlayPlan{
+		Tracks:   make([]data.TrackAssignment, len(dto.Tracks)),
+		Skipped:  dto.Skipped,
+		Excluded: dto.Excluded,
+		Start:    time.Duration(dto.StartMs) * time.Millisecond,
+		End:      time.Duration(dto.EndMs) * time.Millisecond,
+	}
+	if dto.Performance != nil {
+		id, err := data.IdFromHex(*dto.Performance)
+		if err != nil {
+			return data.PlayPlan{}, err
+		}
+		p.Performance = id
+	}
+	for i, t := range dto.Tracks {
+		a, err := TrackAssignmentDtoToTrackAssignment(t)
+		if err != nil {
+			return data.PlayPlan{}, err
+		}
+		p.Tracks[i] = a
+	}
+	if dto.Schedule != nil {
+		schedule, err := ScheduleDtoToSchedule(*dto.Schedule)
+		if err != nil {
+			return data.PlayPlan{}, err
+		}
+		p.Schedule = &schedule
+	}
+	return p, nil
+}
+
+func LoadToDto(l data.Load) model.Load {
+	return model.Load{
+		Events:    l.Events,
+		Notes:     l.Notes,
+		PeakRate:  l.PeakRate,
+		Polyphony: l.Polyphony,
+		FirstMs:   l.First.Milliseconds(),
+		LastMs:    l.Last.Milliseconds(),
+	}
+}
+
+func LoadDtoToLoad(dto model.Load) data.Load {
+	return data.Load{
+		Events:    dto.Events,
+		Notes:     dto.Notes,
+		PeakRate:  dto.PeakRate,
+		Polyphony: dto.Polyphony,
+		First:     time.Duration(dto.FirstMs) * time.Millisecond,
+		Last:      time.Duration(dto.LastMs) * time.Millisecond,
+	}
+}
+
+func ScheduleToDto(s data.Schedule) model.Schedule {
+	dto := model.Schedule{
+		Musicians:  make([]model.MusicianSchedule, len(s.Musicians)),
+		Unassigned: make([]model.TrackSchedule, len(s.Unassigned)),
+		DurationMs: s.Duration.Milliseconds(),
+	}
+	for i, ms := range s.Musicians {
+		dto.Musicians[i] = model.MusicianSchedule{Musician: ms.Musician.Hex(), Load: LoadToDto(ms.Load)}
+	}
+	for i, ts := range s.Unassigned {
+		dto.Unassigned[i] = model.TrackSchedule{Track: ts.Track, Load: LoadToDto(ts.Load)}
+	}
+	return dto
+}
+
+func ScheduleDtoToSchedule(dto model.Schedule) (data.Schedule, error) {
+	s := data.Schedule{Duration: time.Duration(dto.DurationMs) * time.Millisecond}
+	for _, ms := range dto.Musicians {
+		id, err := data.IdFromHex(ms.Musician)
+		if err != nil {
+			return data.Schedule{}, err
+		}
+		s.Musicians = append(s.Musicians, data.MusicianSchedule{Musician: id, Load: LoadDtoToLoad(ms.Load)})
+	}
+	for _, ts := range dto.Unassigned {
+		s.Unassigned = append(s.Unassigned, data.TrackSchedule{Track: ts.Track, Load: LoadDtoToLoad(ts.Load)})
+	}
+	return s, nil
+}
+
+func PositionToDto(p data.Position) model.Position {
+	if p.Bar == 0 {
+		ms := p.Time.Milliseconds()
+		return model.Position{Ms: &ms}
+	}
+	bar, beat := p.Bar, p.Beat
+	return model.Position{Bar: &bar, Beat: &beat}
+}
+
+func PositionDtoToPosition(dto model.Position) (data.Position, error) {
+	switch {
+	case dto.Ms != nil && dto.Bar == nil && dto.Beat == nil:
+		if *dto.Ms < 0 {
+			return data.Position{}, data.ErrInvalidPosition
+		}
+		return data.Position{Time: time.Duration(*dto.Ms) * time.Millisecond}, nil
+	case dto.Ms == nil && dto.Bar != nil:
+		p := data.Position{Bar: *dto.Bar, Beat: 1}
+		if dto.Beat != nil {
+			p.Beat = *dto.Beat
+		}
+		if p.Bar < 1 || p.Beat < 1 {
+			return data.Position{}, data.ErrInvalidPosition
+		}
+		return p, nil
+	default:
+		return data.Position{}, data.ErrInvalidPosition
+	}
+}
+
+func PlayOptionsToDto(opts data.PlayOptions) model.PlayOptions {
+	dto := model.PlayOptions{
+		Tempo:        &opts.Interpretation.Tempo,
+		Transpose:    &opts.Interpretation.Transpose,
+		Loops:        &opts.Loops,
+		MinMusicians: &opts.MinMusicians,
+		DryRun:       &opts.DryRun,
+	}
+	if len(opts.Mapping) > 0 {
+		assignments := make([]model.TrackAssignment, len(opts.Mapping))
+		for i := range opts.Mapping {
+			assignments[i] = TrackAssignmentToDto(opts.Mapping[i])
+		}
+		dto.Assignments = &assignments
+	}
+	if opts.Start != nil {
+		start := PositionToDto(*opts.Start)
+		dto.Start = &start
+	}
+	if opts.End != nil {
+		end := PositionToDto(*opts.End)
+		dto.End = &end
+	}
+	if len(opts.Tracks) > 0 {
+		dto.Tracks = &opts.Tracks
+	}
+	if len(opts.Exclude) > 0 {
+		dto.Exclude = &opts.Exclude
+	}
+	if opts.Section != "" {
+		dto.Section = &opts.Section
+	}
+	return dto
+}
+
+// PlayOptionsDtoToPlayOptions reads the play options, the options missing
+// take their default value
+func PlayOptionsDtoToPlayOptions(dto model.PlayOptions) (data.PlayOptions, error) {
+	opts := data.DefaultPlayOptions
+	if dto.Assignments != nil {
+		opts.Mapping = make([]data.TrackAssignment, len(*dto.Assignments))
+		for i, a := range *dto.Assignments {
+			mapped, err := TrackAssignmentDtoToTrackAssignment(a)
+			if err != nil {
+				return data.PlayOptions{}, data.ErrInvalidAssignment
+			}
+			opts.Mapping[i] = mapped
+		}
+	}
+	if dto.Tempo != nil {
+		opts.Interpretation.Tempo = *dto.Tempo
+	}
+	if dto.Transpose != nil {
+		opts.Interpretation.Transpose = *dto.Transpose
+	}
+	if dto.Start != nil {
+		start, err := PositionDtoToPosition(*dto.Start)
+		if err != nil {
+			return data.PlayOptions{}, err
+		}
+		opts.Start = &start
+	}
+	if dto.End != nil {
+		end, err := PositionDtoToPosition(*dto.End)
+		if err != nil {
+			return data.PlayOptions{}, err
+		}
+		opts.End = &end
+	}
+	if dto.Tracks != nil {
+		opts.Tracks = *dto.Tracks
+	}
+	if dto.Exclude != nil {
+		opts.Exclude = *dto.Exclude
+	}
+	if dto.Loops != nil {
+		opts.Loops = *dto.Loops
+	}
+	if dto.MinMusicians != nil {
+		opts.MinMusicians = *dto.MinMusicians
+	}
+	if dto.Section != nil {
+		opts.Section = *dto.Section
+	}
+	if dto.DryRun != nil {
+		opts.DryRun = *dto.DryRun
+	}
+	return opts, nil
+}
+
+func MixToDto(m data.Mix) model.Mix {
+	dto := model.Mix{Gain: m.Gain, Mute: m.Mute, Solo: m.Solo}
+	if m.Pan != nil {
+		pan := int(*m.Pan)
+		dto.Pan = &pan
+	}
+	return dto
+}
+
+func MixDtoToMix(dto model.Mix) (data.Mix, error) {
+	m := data.Mix{Gain: dto.Gain, Mute: dto.Mute, Solo: dto.Solo}
+	if dto.Pan != nil {
+		if *dto.Pan < 0 || *dto.Pan > 127 {
+			return data.Mix{}, data.ErrInvalidMix
+		}
+		pan := uint8(*dto.Pan)
+		m.Pan = &pan
+	}
+	return m, nil
+}
+
+func MixerToDto(m data.Mixer) model.Mixer {
+	dto := model.Mixer{
+		Musicians: make([]model.MusicianMix, len(m.Musicians)),
+		Tracks:    make([]model.TrackMix, len(m.Tracks)),
+	}
+	for i, mm := range m.Musicians {
+		dto.Musicians[i] = model.MusicianMix{Musician: mm.Musician.Hex(), Mix: MixToDto(mm.Mix)}
+	}
+	for i, tm := range m.Tracks {
+		dto.Tracks[i] = model.TrackMix{Track: tm.Track, Mix: MixToDto(tm.Mix)}
+	}
+	return dto
+}
+
+func MixerDtoToMixer(dto model.Mixer) (data.Mixer, error) {
+	var m data.Mixer
+	for _, mm := range dto.Musicians {
+		id, err := data.IdFromHex(mm.Musician)
+		if err != nil {
+			return data.Mixer{}, err
+		}
+		mix, err := MixDtoToMix(mm.Mix)
+		if err != nil {
+			return data.Mixer{}, err
+		}
+		m.Musicians = append(m.Musicians, data.MusicianMix{Musician: id, Mix: mix})
+	}
+	for _, tm := range dto.Tracks {
+		mix, err := MixDtoToMix(tm.Mix)
+		if err != nil {
+			return data.Mixer{}, err
+		}
+		m.Tracks = append(m.Tracks, data.TrackMix{Track: tm.Track, Mix: mix})
+	}
+	return m, nil
+}
+
+// MixParamsToMixChange returns the target and the change of a mix request
+func MixParamsToMixChange(params model.MixMusicParams) (data.MixTarget, data.MixChange, error) {
+	var target data.MixTarget
+	if params.Musician != nil {
+		id, err := data.IdFromHex(*params.Musician)
+		if err != nil {
+			return data.MixTarget{}, data.MixChange{}, data.ErrInvalidMix
+		}
+		target.Musician = &id
+	}
+	target.Track = params.Track
+
+	change := data.MixChange{
+		Reset: params.Reset != nil && *params.Reset,
+		Gain:  params.Gain,
+		Mute:  params.Mute,
+		Solo:  params.Solo,
+	}
+	if params.Pan != nil {
+		if *params.Pan < 0 || *params.Pan > 127 {
+			return data.MixTarget{}, data.MixChange{}, data.ErrInvalidMix
+		}
+		pan := uint8(*params.Pan)
+		change.Pan = &pan
+	}
+	return target, change, nil
+}
+
+// MixChangeToParams returns the request of a mix change
+func MixChangeToParams(target data.MixTarget, change data.MixChange) model.MixMusicParams {
+	params := model.MixMusicParams{
+		Track: target.Track,
+		Gain:  change.Gain,
+		Mute:  change.Mute,
+		Solo:  change.Solo,
+	}
+	if target.Musician != nil {
+		id := target.Musician.Hex()
+		params.Musician = &id
+	}
+	if change.Reset {
+		params.Reset = &change.Reset
+	}
+	if change.Pan != nil {
+		pan := int(*change.Pan)
+		params.Pan = &pan
+	}
+	return params
+}
+
+func SectionToDto(sec data.Section) model.Section {
+	dto := model.Section{Name: sec.Name}
+	if len(sec.Labels) > 0 {
+		dto.Labels = &sec.Labels
+	}
+	if len(sec.Musicians) > 0 {
+		musicians := make([]string, len(sec.Musicians))
+		for i := range sec.Musicians {
+			musicians[i] = sec.Musicians[i].Hex()
+		}
+		dto.Musicians = &musicians
+	}
+	return dto
+}
+
+func SectionDtoToSection(dto model.Section) (data.Section, error) {
+	sec := data.Section{Name: dto.Name}
+	if dto.Labels != nil {
+		sec.Labels = *dto.Labels
+	}
+	if dto.Musicians != nil {
+		for _, raw := range *dto.Musicians {
+			id, err := data.IdFromHex(raw)
+			if err != nil {
+				return data.Section{}, data.ErrInvalidSection
+			}
+			sec.Musicians = append(sec.Musicians, id)
+		}
+	}
+	return sec, nil
+}
+
+func QueueItemToDto(item data.QueueItem) model.QueueItem {
+	return model.QueueItem{
+		Id:      item.Id.Hex(),
+		Music:   item.Music,
+		Options: PlayOptionsToDto(item.Options),
+	}
+}
+
+func QueueItemDtoToQueueItem(dto model.QueueItem) (data.QueueItem, error) {
+	id, err := data.IdFromHex(dto.Id)
+	if err != nil {
+		return data.QueueItem{}, err
+	}
+	opts, err := PlayOptionsDtoToPlayOptions(dto.Options)
+	if err != nil {
+		return data.QueueItem{}, err
+	}
+
+	return data.QueueItem{
+		Id:      id,
+		Music:   dto.Music,
+		Options: opts,
+	}, nil
+}
+
+func QueueToDto(q data.Queue) model.Queue {
+	dto := model.Queue{
+		Items:   make([]model.QueueItem, len(q.Items)),
+		Repeat:  model.QueueRepeat(q.Modes.Repeat),
+		Shuffle: q.Modes.Shuffle,
+		Gapless: q.Modes.Gapless,
+		Held:    q.Held,
+	}
+	if q.Current != nil {
+		current := QueueItemToDto(*q.Current)
+		dto.Current = &current
+	}
+	for i := range q.Items {
+		dto.Items[i] = QueueItemToDto(q.Items[i])
+	}
+	return dto
+}
+
+func QueueDtoToQueue(dto model.Queue) (data.Queue, error) {
+	q := data.Queue{
+		Items: make([]data.QueueItem, len(dto.Items)),
+		Modes: data.QueueModes{
+			Repeat:  data.RepeatMode(dto.Repeat),
+			Shuffle: dto.Shuffle,
+			Gapless: dto.Gapless,
+		},
+		Held: dto.Held,
+	}
+	if dto.Current != nil {
+		current, err := QueueItemDtoToQueueItem(*dto.Current)
+		if err != nil {
+			return data.Queue{}, err
+		}
+		q.Current = &current
+	}
+	for i := range dto.Items {
+		item, err := QueueItemDtoToQueueItem(dto.Items[i])
+		if err != nil {
+			return data.Queue{}, err
+		}
+		q.Items[i] = item
+	}
+	return q, nil
+}
+
+func MusicInfoToDto(info data.MusicInfo) model.MusicInfo {
+	return model.MusicInfo{
+		Name:       info.Name,
+		Size:       info.Size,
+		Format:     int(info.Format),
+		Tracks:     info.Tracks,
+		DurationMs: info.Duration.Milliseconds(),
+		Tempo:      info.Tempo,
+		ModifiedAt: info.ModifiedAt,
+	}
+}
+
+func MusicInfoDtoToMusicInfo(dto model.MusicInfo) data.MusicInfo {
+	return data.MusicInfo{
+		Name:       dto.Name,
+		Size:       dto.Size,
+		Format:     uint16(dto.Format),
+		Tracks:     dto.Tracks,
+		Duration:   time.Duration(dto.DurationMs) * time.Millisecond,
+		Tempo:      dto.Tempo,
+		ModifiedAt: dto.ModifiedAt,
+	}
+}
+
+func CapabilitiesToDto(c data.Capabilities) model.Capabilities {
+	dto := model.Capabilities{
+		Channels:   make([]int, len(c.Channels)),
+		Programs:   make([]int, len(c.Programs)),
+		Polyphony:  c.Polyphony,
+		Percussion: c.Percussion,
+		LatencyMs:  c.Latency.Milliseconds(),
+		Labels:     append([]string{}, c.Labels...),
+	}
+	for i, channel := range c.Channels {
+		dto.Channels[i] = int(channel)
+	}
+	for i, program := range c.Programs {
+		dto.Programs[i] = int(program)
+	}
+	return dto
+}
+
+func CapabilitiesDtoToCapabilities(dto model.Capabilities) (data.Capabilities, error) {
+	if dto.Polyphony < 0 || dto.LatencyMs < 0 {
+		return data.Capabilities{}, data.ErrInvalidCapabilities
+	}
+
+	c := data.Capabilities{
+		Polyphony:  dto.Polyphony,
+		Percussion: dto.Percussion,
+		Latency:    time.Duration(dto.LatencyMs) * time.Millisecond,
+		Labels:     dto.Labels,
+	}
+	for _, channel := range dto.Channels {
+		if channel < 0 || channel > 15 {
+			return data.Capabilities{}, data.ErrInvalidCapabilities
+		}
+		c.Channels = append(c.Channels, uint8(channel))
+	}
+	for _, program := range dto.Programs {
+		if program < 0 || program > 127 {
+			return data.Capabilities{}, data.ErrInvalidCapabilities
+		}
+		c.Programs = append(c.Programs, uint8(program))
+	}
+	return c, nil
+}
+
+func MusicianToDto(m data.Musician) model.Musician {
+	dto := model.Musician{
+		Id:      m.Id.Hex(),
+		Address: m.Address,
+	}
+	if m.Capabilities != nil {
+		c := CapabilitiesToDto(*m.Capabilities)
+		dto.Capabilities = &c
+	}
+	return dto
+}
+
+func MusicianDtoToMusician(dto model.Musician) (data.Musician, error) {
+	id, err := data.IdFromHex(dto.Id)
+	if err != nil {
+		return data.Musician{}, err
+	}
+
+	m := data.Musician{Id: id, Address: dto.Address}
+	if dto.Capabilities != nil {
+		c, err := CapabilitiesDtoToCapabilities(*dto.Capabilities)
+		if err != nil {
+			return data.Musician{}, err
+		}
+		m.Capabilities = &c
+	}
+	return m, nil
+}
+
+// EventDataToDto converts the payload of an event to the model sent to
+// the event stream clients
+func EventDataToDto(ev data.Event) any {
+	switch d := ev.Data.(type) {
+	case data.MusicianEvent:
+		return model.MusicianEventData{
+			Id:      d.Musician.Id.Hex(),
+			Address: d.Musician.Address,
+		}
+	case data.PlaybackEvent:
+		dto := model.PlaybackEventData{
+			Performance: d.Performance.Hex(),
+			Music:       d.Music,
+			DurationMs:  d.Duration.Milliseconds(),
+		}
+		if ev.Type == data.EventPlaybackFinished {
+			dto.Completed = &d.Completed
+		}
+		if d.Next != "" {
+			dto.Next = &d.Next
+		}
+		return dto
+	case data.PositionEvent:
+		return model.PositionEventData{
+			Performance: d.Performance.Hex(),
+			Music:       d.Music,
+			Bar:         d.Bar,
+			ElapsedMs:   d.Elapsed.Milliseconds(),
+		}
+	case data.NoteErrorEvent:
+		return model.NoteErrorEventData{
+			Performance: d.Performance.Hex(),
+			Musician:    d.Musician.Hex(),
+			Notes:       d.Notes,
+			Error:       d.Error,
+		}
+	case data.HandoverEvent:
+		dto := model.HandoverEventData{
+			Performance: d.Performance.Hex(),
+			Music:       d.Music,
+			Tracks:      make([]model.TrackAssignment, len(d.Tracks)),
+		}
+		if d.From != nil {
+			from := d.From.Hex()
+			dto.From = &from
+		}
+		if d.To != nil {
+			to := d.To.Hex()
+			dto.To = &to
+		}
+		for i := range d.Tracks {
+			dto.Tracks[i] = TrackAssignmentToDto(d.Tracks[i])
+		}
+		return dto
+	case data.DroppedEvent:
+		return model.DroppedEventData{
+			Count: d.Count,
+		}
+	default:
+		return d
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"crossjoin.com/gorxestra/data"
+)
+
+// scheduleHeader are the columns of a schedule written as CSV
+var scheduleHeader = []string{
+	"musician", "track", "events", "notes", "peakRate", "polyphony", "firstMs", "lastMs",
+}
+
+// WriteScheduleCsv writes the schedule as CSV, a row per musician then a
+// row per track nobody plays
+func WriteScheduleCsv(w io.Writer, s data.Schedule) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(scheduleHeader); err != nil {
+		return err
+	}
+	for _, ms := range s.Musicians {
+		if err := cw.Write(loadRecord(ms.Musician.Hex(), "", ms.Load)); err != nil {
+			return err
+		}
+	}
+	for _, ts := range s.Unassigned {
+		if err := cw.Write(loadRecord("", strconv.Itoa(ts.Track), ts.Load)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func loadRecord(musician, track string, l data.Load) []string {
+	return []string{
+		musician,
+		track,
+		strconv.Itoa(l.Events),
+		strconv.Itoa(l.Notes),
+		strconv.Itoa(l.PeakRate),
+		strconv.Itoa(l.Polyphony),
+		strconv.FormatInt(l.First.Milliseconds(), 10),
+		strconv.FormatInt(l.Last.Milliseconds(), 10),
+	}
+}
@@ -26,6 +26,7 @@ import (
 type APINodeInterface interface {
 	httpUtils.NodeInterface
 	api.NodeInterface
+	api.EventSource
 	Config() config.ConductorConf
 }
 
@@ -34,6 +35,8 @@ const (
 	TokenHeader = "X-API-Token" //nolint: all
 	// MaxRequestBodyBytes is the maximum request body size that we allow in our APIs.
 	MaxRequestBodyBytes = "10MB"
+	// EventsPath is the path of the event stream
+	EventsPath = "/v1/events"
 )
 
 // NewHttpRouter builds and returns a new router with our REST handlers registered.
@@ -78,11 +81,17 @@ func NewHttpRouter(
 
 	// register v1 handlers
 	v1 := v1.Handlers{
-		Node: node,
-		Log:  logger,
+		Node:     node,
+		Source:   node,
+		Log:      logger,
+		Shutdown: shutdown,
 	}
 
 	server.RegisterHandlers(e, &v1, publicMiddleware...)
 
+	// The event stream is long lived and registered apart from the
+	// generated routes
+	e.GET(EventsPath, v1.Events)
+
 	return e
 }
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"crossjoin.com/gorxestra/daemon/conductord/api"
+	"crossjoin.com/gorxestra/data"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// eventsBufferSize is the number of events waiting to be written to a
+	// client before the next ones are dropped for it
+	eventsBufferSize = 256
+	// eventsKeepAlive is the time between two comments written to keep an
+	// idle event stream open
+	eventsKeepAlive = 15 * time.Second
+)
+
+// Events streams the conductor events as Server-Sent Events. Every client
+// has its own buffer, a client that does not keep up misses events and is
+// told how many with an events.dropped event.
+// It is registered outside of the generated routes so that the response
+// is not bound by the server timeouts.
+func (h *Handlers) Events(ctx echo.Context) error {
+	rc := http.NewResponseController(ctx.Response())
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.Log.With("error", err).Warn("clearing events write deadline")
+	}
+
+	sub := h.Source.Subscribe(eventsBufferSize)
+	defer sub.Close()
+
+	w := ctx.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return err
+	}
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-h.Shutdown:
+			return nil
+		case <-ctx.Request().Context().Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		case ev, ok := <-sub.C():
+			if !ok {
+				return nil
+			}
+
+			if dropped := sub.TakeDropped(); dropped > 0 {
+				err := writeEvent(w, data.Event{
+					Type: data.EventDropped,
+					Time: time.Now(),
+					Data: data.DroppedEvent{Count: dropped},
+				})
+				if err != nil {
+					return nil
+				}
+			}
+
+			if err := writeEvent(w, ev); err != nil {
+				return nil
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return nil
+		}
+	}
+}
+
+// writeEvent writes an event in the Server-Sent Events format
+func writeEvent(w http.ResponseWriter, ev data.Event) error {
+	payload, err := json.Marshal(api.EventDataToDto(ev))
+	if err != nil {
+		return err
+	}
+
+	if ev.Id != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", ev.Id); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, payload)
+	return err
+}
@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteEvent(t *testing.T) {
+	w := httptest.NewRecorder()
+	perf, err := data.IdFromHex("00112233445566778899aabbccddeeff00112233")
+	assert.NoError(t, err)
+
+	err = writeEvent(w, data.Event{
+		Id:   7,
+		Type: data.EventPlaybackPosition,
+		Time: time.Now(),
+		Data: data.PositionEvent{Performance: perf, Music: "queen.mid", Bar: 3, Elapsed: 4500 * time.Millisecond},
+	})
+	assert.NoError(t, err)
+
+	err = writeEvent(w, data.Event{
+		Type: data.EventDropped,
+		Data: data.DroppedEvent{Count: 2},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "id: 7\n"+
+		"event: playback.position\n"+
+		`data: {"bar":3,"elapsedMs":4500,"music":"queen.mid","performance":"00112233445566778899aabbccddeeff00112233"}`+"\n\n"+
+		"event: events.dropped\n"+
+		`data: {"count":2}`+"\n\n", w.Body.String())
+}
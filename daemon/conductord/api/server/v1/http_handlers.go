@@ -1,7 +1,10 @@
 package v1
 
 import (
+	"bytes"
+	"errors"
 	"net/http"
+	"time"
 
 	"crossjoin.com/gorxestra/daemon/conductord/api"
 	"crossjoin.com/gorxestra/daemon/conductord/api/server/v1/openapi/generated/model"
@@ -10,10 +13,18 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// Fields of the multipart form of the music upload
+const (
+	uploadFileField = "file"
+	uploadNameField = "name"
+)
+
 // Handlers is an implementation to the V1 route handler interface
 type Handlers struct {
-	Node api.NodeInterface
-	Log  logging.Logger
+	Node     api.NodeInterface
+	Source   api.EventSource
+	Log      logging.Logger
+	Shutdown <-chan struct{}
 }
 
 // AddMusician implements server.ServerInterface.
@@ -24,15 +35,15 @@ func (h *Handlers) RegisterMusician(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, err)
 	}
 
-	id, err := data.IdFromHex(musicianDto.Id)
+	musician, err := api.MusicianDtoToMusician(musicianDto)
+	if errors.Is(err, data.ErrInvalidCapabilities) {
+		return err
+	}
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, err)
 	}
 
-	err = h.Node.RegisterMusician(data.Musician{
-		Id:      id,
-		Address: musicianDto.Address,
-	})
+	err = h.Node.RegisterMusician(musician)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, err)
 	}
@@ -55,12 +66,491 @@ func (h *Handlers) UnregisterMusician(ctx echo.Context, idRaw string) error {
 	return ctx.JSON(http.StatusOK, nil)
 }
 
+// Heartbeat implements server.ServerInterface.
+func (h *Handlers) Heartbeat(ctx echo.Context, idRaw string) error {
+	id, err := data.IdFromHex(idRaw)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	lease, err := h.Node.Heartbeat(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, model.Lease{LeaseMs: lease.Milliseconds()})
+}
+
 // PlayMusic implements server.ServerInterface.
-func (h *Handlers) PlayMusic(ctx echo.Context, name string) error {
-	err := h.Node.PlayMusic(name)
+func (h *Handlers) PlayMusic(ctx echo.Context, name string, params model.PlayMusicParams) error {
+	var req model.PlayOptions
+	err := ctx.Bind(&req)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	opts, err := api.PlayOptionsDtoToPlayOptions(req)
+	if err != nil {
+		return err
+	}
+
+	// Only a dry run has a schedule to write as CSV
+	csv := params.Format != nil && *params.Format == model.Csv
+	if csv && !opts.DryRun {
+		return data.ErrInvalidPlayOptions
+	}
+
+	plan, err := h.Node.PlayMusic(name, opts)
+	if err != nil {
+		return err
+	}
+
+	if csv {
+		var buf bytes.Buffer
+		if err := api.WriteScheduleCsv(&buf, *plan.Schedule); err != nil {
+			return err
+		}
+		return ctx.Blob(http.StatusOK, "text/csv", buf.Bytes())
+	}
+	return ctx.JSON(http.StatusOK, api.PlayPlanToDto(plan))
+}
+
+// ListMusic implements server.ServerInterface.
+func (h *Handlers) ListMusic(ctx echo.Context) error {
+	musics, err := h.Node.ListMusic()
+	if err != nil {
+		return err
+	}
+
+	dto := make([]model.MusicInfo, len(musics))
+	for i := range musics {
+		dto[i] = api.MusicInfoToDto(musics[i])
+	}
+
+	return ctx.JSON(http.StatusOK, dto)
+}
+
+// UploadMusic implements server.ServerInterface.
+func (h *Handlers) UploadMusic(ctx echo.Context) error {
+	file, err := ctx.FormFile(uploadFileField)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	name := ctx.FormValue(uploadNameField)
+	if name == "" {
+		name = file.Filename
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := h.Node.AddMusic(name, src)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.MusicInfoToDto(info))
+}
+
+// MusicInfo implements server.ServerInterface.
+func (h *Handlers) MusicInfo(ctx echo.Context, name string) error {
+	info, err := h.Node.MusicInfo(name)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.MusicInfoToDto(info))
+}
+
+// RemoveMusic implements server.ServerInterface.
+func (h *Handlers) RemoveMusic(ctx echo.Context, name string) error {
+	err := h.Node.RemoveMusic(name)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// MusicStatus implements server.ServerInterface.
+func (h *Handlers) MusicStatus(ctx echo.Context) error {
+	status, err := h.Node.MusicStatus(data.ID{})
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.PlaybackStatusToDto(status))
+}
+
+// StopMusic implements server.ServerInterface.
+func (h *Handlers) StopMusic(ctx echo.Context) error {
+	err := h.Node.StopMusic(data.ID{})
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// PauseMusic implements server.ServerInterface.
+func (h *Handlers) PauseMusic(ctx echo.Context) error {
+	err := h.Node.PauseMusic(data.ID{})
 	if err != nil {
 		return err
 	}
 
 	return ctx.JSON(http.StatusOK, nil)
 }
+
+// ResumeMusic implements server.ServerInterface.
+func (h *Handlers) ResumeMusic(ctx echo.Context) error {
+	err := h.Node.ResumeMusic(data.ID{})
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// SeekMusic implements server.ServerInterface.
+func (h *Handlers) SeekMusic(ctx echo.Context, params model.SeekMusicParams) error {
+	err := h.Node.SeekMusic(data.ID{}, time.Duration(params.Ms)*time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// InterpretMusic implements server.ServerInterface.
+func (h *Handlers) InterpretMusic(ctx echo.Context, params model.InterpretMusicParams) error {
+	err := h.Node.InterpretMusic(data.ID{}, params.Tempo, params.Transpose)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// MixMusic implements server.ServerInterface.
+func (h *Handlers) MixMusic(ctx echo.Context, params model.MixMusicParams) error {
+	target, change, err := api.MixParamsToMixChange(params)
+	if err != nil {
+		return err
+	}
+
+	mixer, err := h.Node.MixMusic(data.ID{}, target, change)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.MixerToDto(mixer))
+}
+
+// ListPerformances implements server.ServerInterface.
+func (h *Handlers) ListPerformances(ctx echo.Context) error {
+	perfs, err := h.Node.Performances()
+	if err != nil {
+		return err
+	}
+
+	dto := make([]model.PlaybackStatus, len(perfs))
+	for i := range perfs {
+		dto[i] = api.PlaybackStatusToDto(perfs[i])
+	}
+	return ctx.JSON(http.StatusOK, dto)
+}
+
+// PerformanceStatus implements server.ServerInterface.
+func (h *Handlers) PerformanceStatus(ctx echo.Context, idRaw string) error {
+	id, err := data.IdFromHex(idRaw)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	status, err := h.Node.MusicStatus(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.PlaybackStatusToDto(status))
+}
+
+// StopPerformance implements server.ServerInterface.
+func (h *Handlers) StopPerformance(ctx echo.Context, idRaw string) error {
+	id, err := data.IdFromHex(idRaw)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	err = h.Node.StopMusic(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// PausePerformance implements server.ServerInterface.
+func (h *Handlers) PausePerformance(ctx echo.Context, idRaw string) error {
+	id, err := data.IdFromHex(idRaw)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	err = h.Node.PauseMusic(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// ResumePerformance implements server.ServerInterface.
+func (h *Handlers) ResumePerformance(ctx echo.Context, idRaw string) error {
+	id, err := data.IdFromHex(idRaw)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	err = h.Node.ResumeMusic(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// SeekPerformance implements server.ServerInterface.
+func (h *Handlers) SeekPerformance(ctx echo.Context, idRaw string, params model.SeekPerformanceParams) error {
+	id, err := data.IdFromHex(idRaw)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	err = h.Node.SeekMusic(id, time.Duration(params.Ms)*time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// InterpretPerformance implements server.ServerInterface.
+func (h *Handlers) InterpretPerformance(ctx echo.Context, idRaw string, params model.InterpretPerformanceParams) error {
+	id, err := data.IdFromHex(idRaw)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	err = h.Node.InterpretMusic(id, params.Tempo, params.Transpose)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// MixPerformance implements server.ServerInterface.
+func (h *Handlers) MixPerformance(ctx echo.Context, idRaw string, params model.MixPerformanceParams) error {
+	id, err := data.IdFromHex(idRaw)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	target, change, err := api.MixParamsToMixChange(model.MixMusicParams(params))
+	if err != nil {
+		return err
+	}
+
+	mixer, err := h.Node.MixMusic(id, target, change)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.MixerToDto(mixer))
+}
+
+// ListSections implements server.ServerInterface.
+func (h *Handlers) ListSections(ctx echo.Context) error {
+	sections, err := h.Node.Sections()
+	if err != nil {
+		return err
+	}
+
+	dto := make([]model.Section, len(sections))
+	for i := range sections {
+		dto[i] = api.SectionToDto(sections[i])
+	}
+	return ctx.JSON(http.StatusOK, dto)
+}
+
+// SetSection implements server.ServerInterface.
+func (h *Handlers) SetSection(ctx echo.Context, name string) error {
+	var req model.Section
+	err := ctx.Bind(&req)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	// The section is named by the path
+	req.Name = name
+	section, err := api.SectionDtoToSection(req)
+	if err != nil {
+		return err
+	}
+
+	err = h.Node.SetSection(section)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// RemoveSection implements server.ServerInterface.
+func (h *Handlers) RemoveSection(ctx echo.Context, name string) error {
+	err := h.Node.RemoveSection(name)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// GetQueue implements server.ServerInterface.
+func (h *Handlers) GetQueue(ctx echo.Context) error {
+	queue, err := h.Node.Queue()
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.QueueToDto(queue))
+}
+
+// QueueMusic implements server.ServerInterface.
+func (h *Handlers) QueueMusic(ctx echo.Context, name string) error {
+	var req model.PlayOptions
+	err := ctx.Bind(&req)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	opts, err := api.PlayOptionsDtoToPlayOptions(req)
+	if err != nil {
+		return err
+	}
+
+	item, err := h.Node.QueueMusic(name, opts)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.QueueItemToDto(item))
+}
+
+// RemoveQueueItem implements server.ServerInterface.
+func (h *Handlers) RemoveQueueItem(ctx echo.Context, idRaw string) error {
+	id, err := data.IdFromHex(idRaw)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	err = h.Node.RemoveQueueItem(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// MoveQueueItem implements server.ServerInterface.
+func (h *Handlers) MoveQueueItem(ctx echo.Context, idRaw string, params model.MoveQueueItemParams) error {
+	id, err := data.IdFromHex(idRaw)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	err = h.Node.MoveQueueItem(id, params.Index)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// ClearQueue implements server.ServerInterface.
+func (h *Handlers) ClearQueue(ctx echo.Context) error {
+	err := h.Node.ClearQueue()
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// SkipQueue implements server.ServerInterface.
+func (h *Handlers) SkipQueue(ctx echo.Context) error {
+	err := h.Node.SkipQueue()
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// PlayQueue implements server.ServerInterface.
+func (h *Handlers) PlayQueue(ctx echo.Context) error {
+	err := h.Node.PlayQueue()
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// SetQueueModes implements server.ServerInterface.
+func (h *Handlers) SetQueueModes(ctx echo.Context, params model.SetQueueModesParams) error {
+	var repeat *data.RepeatMode
+	if params.Repeat != nil {
+		mode := data.RepeatMode(*params.Repeat)
+		repeat = &mode
+	}
+
+	queue, err := h.Node.SetQueueModes(repeat, params.Shuffle, params.Gapless)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.QueueToDto(queue))
+}
+
+// Panic implements server.ServerInterface.
+func (h *Handlers) Panic(ctx echo.Context) error {
+	err := h.Node.Panic()
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// Clock implements server.ServerInterface.
+func (h *Handlers) Clock(ctx echo.Context) error {
+	var req model.ClockRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	sample, err := h.Node.Clock(req.Originate)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.ClockSampleToDto(sample))
+}
@@ -3,6 +3,40 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package model
 
+import (
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for PlaybackStatusState.
+const (
+	Idle    PlaybackStatusState = "idle"
+	Paused  PlaybackStatusState = "paused"
+	Playing PlaybackStatusState = "playing"
+	Stopped PlaybackStatusState = "stopped"
+)
+
+// Defines values for QueueRepeat.
+const (
+	QueueRepeatAll QueueRepeat = "all"
+	QueueRepeatOff QueueRepeat = "off"
+	QueueRepeatOne QueueRepeat = "one"
+)
+
+// Defines values for PlayMusicParamsFormat.
+const (
+	Csv  PlayMusicParamsFormat = "csv"
+	Json PlayMusicParamsFormat = "json"
+)
+
+// Defines values for SetQueueModesParamsRepeat.
+const (
+	SetQueueModesParamsRepeatAll SetQueueModesParamsRepeat = "all"
+	SetQueueModesParamsRepeatOff SetQueueModesParamsRepeat = "off"
+	SetQueueModesParamsRepeatOne SetQueueModesParamsRepeat = "one"
+)
+
 // BuildVersion defines model for BuildVersion.
 type BuildVersion struct {
 	// Branch Branch the build is based on
@@ -24,12 +58,75 @@ type BuildVersion struct {
 	Minor int `json:"minor"`
 }
 
+// Capabilities what the musician is able to play, a musician without capabilities plays anything
+type Capabilities struct {
+	// Channels MIDI channels played, from 0 to 15, all when empty
+	Channels []int `json:"channels"`
+
+	// Labels free-form tags describing the musician
+	Labels []string `json:"labels"`
+
+	// LatencyMs time in milliseconds between a note being due and its sound coming out, the notes are sent that much earlier
+	LatencyMs int64 `json:"latencyMs"`
+
+	// Percussion the musician plays the drums of channel 9, the tracks using it only go to such musicians
+	Percussion bool `json:"percussion"`
+
+	// Polyphony notes sounding at once, unlimited when 0
+	Polyphony int `json:"polyphony"`
+
+	// Programs General MIDI programs played, from 0 to 127, any when empty
+	Programs []int `json:"programs"`
+}
+
+// ClockRequest defines model for ClockRequest.
+type ClockRequest struct {
+	// Originate requester time when the request was sent
+	Originate time.Time `json:"originate"`
+}
+
+// ClockSample defines model for ClockSample.
+type ClockSample struct {
+	// Originate requester time when the request was sent
+	Originate time.Time `json:"originate"`
+
+	// Receive node time when the request arrived
+	Receive time.Time `json:"receive"`
+
+	// Transmit node time when the answer was sent
+	Transmit time.Time `json:"transmit"`
+}
+
+// DroppedEventData defines model for DroppedEventData.
+type DroppedEventData struct {
+	// Count number of events the client missed
+	Count uint64 `json:"count"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Error Error message
 	Error string `json:"error"`
 }
 
+// HandoverEventData defines model for HandoverEventData.
+type HandoverEventData struct {
+	// From id of the musician that played the tracks, absent when they had no musician
+	From *string `json:"from,omitempty"`
+
+	// Music name of the music
+	Music string `json:"music"`
+
+	// Performance id of the performance
+	Performance string `json:"performance"`
+
+	// To id of the musician now playing the tracks, absent when no musician could take them
+	To *string `json:"to,omitempty"`
+
+	// Tracks tracks moved to the new musician
+	Tracks []TrackAssignment `json:"tracks"`
+}
+
 // Info defines model for Info.
 type Info struct {
 	Build BuildVersion `json:"build"`
@@ -43,17 +140,495 @@ type InfoResponse struct {
 	Body Info `json:"body"`
 }
 
+// Lease defines model for Lease.
+type Lease struct {
+	// LeaseMs time in milliseconds the registration lasts without a new heartbeat
+	LeaseMs int64 `json:"leaseMs"`
+}
+
+// Load what a part of a performance asks of the one playing it
+type Load struct {
+	// Events messages sent, including the ends of the notes
+	Events int `json:"events"`
+
+	// FirstMs time of the first message from the start of the performance in milliseconds
+	FirstMs int64 `json:"firstMs"`
+
+	// LastMs time of the last message from the start of the performance in milliseconds
+	LastMs int64 `json:"lastMs"`
+
+	// Notes notes started
+	Notes int `json:"notes"`
+
+	// PeakRate largest number of notes started within a second
+	PeakRate int `json:"peakRate"`
+
+	// Polyphony largest number of notes sounding at once
+	Polyphony int `json:"polyphony"`
+}
+
+// Mix defines model for Mix.
+type Mix struct {
+	// Gain factor applied to the velocity of the notes and the volume of the channels
+	Gain float64 `json:"gain"`
+
+	// Mute the notes are silenced
+	Mute bool `json:"mute"`
+
+	// Pan pan position of the channels, absent leaving the pan of the music
+	Pan *int `json:"pan,omitempty"`
+
+	// Solo the notes of those not soloed are silenced
+	Solo bool `json:"solo"`
+}
+
+// Mixer defines model for Mixer.
+type Mixer struct {
+	// Musicians mixes of the musicians, those absent sounding as written
+	Musicians []MusicianMix `json:"musicians"`
+
+	// Tracks mixes of the tracks, those absent sounding as written
+	Tracks []TrackMix `json:"tracks"`
+}
+
+// MusicInfo defines model for MusicInfo.
+type MusicInfo struct {
+	// DurationMs duration of the music in milliseconds
+	DurationMs int64 `json:"durationMs"`
+
+	// Format SMF format, 0 single track, 1 synchronous tracks, 2 independent tracks
+	Format int `json:"format"`
+
+	// ModifiedAt time the music was added
+	ModifiedAt time.Time `json:"modifiedAt"`
+
+	// Name name of the music
+	Name string `json:"name"`
+
+	// Size size of the file in bytes
+	Size int64 `json:"size"`
+
+	// Tempo tempo at the start of the music in BPM
+	Tempo float64 `json:"tempo"`
+
+	// Tracks number of tracks
+	Tracks int `json:"tracks"`
+}
+
 // Musician defines model for Musician.
 type Musician struct {
 	// Address musician address
 	Address string `json:"address"`
 
+	// Capabilities what the musician is able to play, a musician without capabilities plays anything
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+
+	// Id Id of the musician
+	Id string `json:"id"`
+}
+
+// MusicianEventData defines model for MusicianEventData.
+type MusicianEventData struct {
+	// Address musician address
+	Address string `json:"address"`
+
 	// Id Id of the musician
 	Id string `json:"id"`
 }
 
+// MusicianMix defines model for MusicianMix.
+type MusicianMix struct {
+	Mix Mix `json:"mix"`
+
+	// Musician id of the musician
+	Musician string `json:"musician"`
+}
+
+// MusicianSchedule defines model for MusicianSchedule.
+type MusicianSchedule struct {
+	// Load what a part of a performance asks of the one playing it
+	Load Load `json:"load"`
+
+	// Musician id of the musician
+	Musician string `json:"musician"`
+}
+
+// NoteErrorEventData defines model for NoteErrorEventData.
+type NoteErrorEventData struct {
+	// Error delivery error
+	Error string `json:"error"`
+
+	// Musician id of the musician
+	Musician string `json:"musician"`
+
+	// Notes number of notes that could not be delivered
+	Notes int `json:"notes"`
+
+	// Performance id of the performance
+	Performance string `json:"performance"`
+}
+
+// PlayOptions defines model for PlayOptions.
+type PlayOptions struct {
+	// Assignments explicit distribution of the tracks, every assignment needs a musician
+	Assignments *[]TrackAssignment `json:"assignments,omitempty"`
+
+	// DryRun plan the music without playing it
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// End position in a music, either ms or a bar with its beat
+	End *Position `json:"end,omitempty"`
+
+	// Exclude tracks not played
+	Exclude *[]int `json:"exclude,omitempty"`
+
+	// Loops number of times the music is played from start to end, 1 when missing
+	Loops *int `json:"loops,omitempty"`
+
+	// MinMusicians number of musicians registered the music waits for before starting
+	MinMusicians *int `json:"minMusicians,omitempty"`
+
+	// Section section playing the music, the whole orchestra when missing
+	Section *string `json:"section,omitempty"`
+
+	// Start position in a music, either ms or a bar with its beat
+	Start *Position `json:"start,omitempty"`
+
+	// Tempo factor applied to the tempo of the music, 1 when missing
+	Tempo *float64 `json:"tempo,omitempty"`
+
+	// Tracks the only tracks played, all of them when missing
+	Tracks *[]int `json:"tracks,omitempty"`
+
+	// Transpose semitones the notes are shifted by, the percussion channel aside, 0 when missing
+	Transpose *int `json:"transpose,omitempty"`
+}
+
+// PlayPlan defines model for PlayPlan.
+type PlayPlan struct {
+	// EndMs end of the part of the music played in milliseconds
+	EndMs int64 `json:"endMs"`
+
+	// Excluded tracks with notes left out by the play options
+	Excluded []int `json:"excluded"`
+
+	// Performance id of the performance, absent for a dry run
+	Performance *string `json:"performance,omitempty"`
+
+	// Schedule what a performance asks of each musician, only returned by a dry run
+	Schedule *Schedule `json:"schedule,omitempty"`
+
+	// Skipped tracks without notes, such as the tempo track
+	Skipped []int `json:"skipped"`
+
+	// StartMs start of the part of the music played in milliseconds
+	StartMs int64 `json:"startMs"`
+
+	// Tracks assignments of the tracks with notes, a track split by MIDI channel has an assignment per channel
+	Tracks []TrackAssignment `json:"tracks"`
+}
+
+// PlaybackEventData defines model for PlaybackEventData.
+type PlaybackEventData struct {
+	// Completed set on playback.finished when the music ended after its last note
+	Completed *bool `json:"completed,omitempty"`
+
+	// DurationMs duration of the music in milliseconds
+	DurationMs int64 `json:"durationMs"`
+
+	// Music name of the music
+	Music string `json:"music"`
+
+	// Next set on playback.finished when the music cued started right after, without gap
+	Next *string `json:"next,omitempty"`
+
+	// Performance id of the performance
+	Performance string `json:"performance"`
+}
+
+// PlaybackStatus defines model for PlaybackStatus.
+type PlaybackStatus struct {
+	// Completed the music ended after its last note rather than being stopped
+	Completed bool `json:"completed"`
+
+	// DroppedNotes notes of tracks without musician
+	DroppedNotes uint64 `json:"droppedNotes"`
+
+	// DurationMs duration of the music in milliseconds
+	DurationMs int64 `json:"durationMs"`
+
+	// ElapsedMs position in the music in milliseconds
+	ElapsedMs int64 `json:"elapsedMs"`
+
+	// FailedNotes notes that could not be sent to a musician
+	FailedNotes uint64 `json:"failedNotes"`
+	Mixer       Mixer  `json:"mixer"`
+
+	// Music name of the music
+	Music *string `json:"music,omitempty"`
+
+	// Performance id of the performance, absent when nothing was played
+	Performance *string `json:"performance,omitempty"`
+
+	// Section section playing the music, absent for the whole orchestra
+	Section *string `json:"section,omitempty"`
+
+	// State state of the playback
+	State PlaybackStatusState `json:"state"`
+
+	// Tempo factor applied to the tempo of the music
+	Tempo  float64           `json:"tempo"`
+	Tracks []TrackAssignment `json:"tracks"`
+
+	// Transpose semitones the notes are shifted by
+	Transpose int `json:"transpose"`
+}
+
+// PlaybackStatusState state of the playback
+type PlaybackStatusState string
+
+// Position position in a music, either ms or a bar with its beat
+type Position struct {
+	// Bar bar of the position, counting from 1
+	Bar *int `json:"bar,omitempty"`
+
+	// Beat beat of the position in its bar, counting from 1, 1 when missing
+	Beat *int `json:"beat,omitempty"`
+
+	// Ms time from the start of the music in milliseconds
+	Ms *int64 `json:"ms,omitempty"`
+}
+
+// PositionEventData defines model for PositionEventData.
+type PositionEventData struct {
+	// Bar number of the bar being played, starting at 1
+	Bar int `json:"bar"`
+
+	// ElapsedMs position in the music in milliseconds
+	ElapsedMs int64 `json:"elapsedMs"`
+
+	// Music name of the music
+	Music string `json:"music"`
+
+	// Performance id of the performance
+	Performance string `json:"performance"`
+}
+
+// Queue defines model for Queue.
+type Queue struct {
+	Current *QueueItem `json:"current,omitempty"`
+
+	// Gapless the next item starts at the end of the last note of the current one
+	Gapless bool `json:"gapless"`
+
+	// Held the queue waits to be played again, after the music was stopped or the conductor restarted
+	Held bool `json:"held"`
+
+	// Items items waiting, in the order they are played
+	Items []QueueItem `json:"items"`
+
+	// Repeat what the queue does with the items played
+	Repeat QueueRepeat `json:"repeat"`
+
+	// Shuffle items are played in a random order
+	Shuffle bool `json:"shuffle"`
+}
+
+// QueueRepeat what the queue does with the items played
+type QueueRepeat string
+
+// QueueItem defines model for QueueItem.
+type QueueItem struct {
+	// Id id of the item
+	Id string `json:"id"`
+
+	// Music name of the music
+	Music   string      `json:"music"`
+	Options PlayOptions `json:"options"`
+}
+
+// Schedule what a performance asks of each musician, only returned by a dry run
+type Schedule struct {
+	// DurationMs time the performance lasts with its loops in milliseconds
+	DurationMs int64 `json:"durationMs"`
+
+	// Musicians musicians available, those without part having an empty load
+	Musicians []MusicianSchedule `json:"musicians"`
+
+	// Unassigned tracks with notes played by nobody
+	Unassigned []TrackSchedule `json:"unassigned"`
+}
+
+// Section defines model for Section.
+type Section struct {
+	// Labels the musicians with one of these labels belong to the section
+	Labels *[]string `json:"labels,omitempty"`
+
+	// Musicians ids of musicians belonging to the section
+	Musicians *[]string `json:"musicians,omitempty"`
+
+	// Name name of the section
+	Name string `json:"name"`
+}
+
+// TrackAssignment defines model for TrackAssignment.
+type TrackAssignment struct {
+	// Channel MIDI channel of the track played by the musician, missing when the musician plays the whole track
+	Channel *int `json:"channel,omitempty"`
+
+	// Musician id of the musician playing the track, missing when nobody plays it
+	Musician *string `json:"musician,omitempty"`
+
+	// Track index of the track in the music
+	Track int `json:"track"`
+}
+
+// TrackMix defines model for TrackMix.
+type TrackMix struct {
+	Mix Mix `json:"mix"`
+
+	// Track index of the track in the music
+	Track int `json:"track"`
+}
+
+// TrackSchedule defines model for TrackSchedule.
+type TrackSchedule struct {
+	// Load what a part of a performance asks of the one playing it
+	Load  Load `json:"load"`
+	Track int  `json:"track"`
+}
+
+// UploadMusicMultipartBody defines parameters for UploadMusic.
+type UploadMusicMultipartBody struct {
+	// File Standard MIDI File
+	File openapi_types.File `json:"file"`
+
+	// Name name of the music in the library
+	Name *string `json:"name,omitempty"`
+}
+
+// InterpretMusicParams defines parameters for InterpretMusic.
+type InterpretMusicParams struct {
+	// Tempo factor applied to the tempo of the music, 0.5 plays it twice slower
+	Tempo *float64 `form:"tempo,omitempty" json:"tempo,omitempty"`
+
+	// Transpose semitones the notes are shifted by, the percussion channel aside
+	Transpose *int `form:"transpose,omitempty" json:"transpose,omitempty"`
+}
+
+// MixMusicParams defines parameters for MixMusic.
+type MixMusicParams struct {
+	// Musician id of the musician mixed, or track
+	Musician *string `form:"musician,omitempty" json:"musician,omitempty"`
+
+	// Track index of the track mixed, or musician
+	Track *int `form:"track,omitempty" json:"track,omitempty"`
+
+	// Reset bring the mix back to the default before the other changes
+	Reset *bool `form:"reset,omitempty" json:"reset,omitempty"`
+
+	// Gain factor applied to the velocity of the notes and the volume of the channels
+	Gain *float64 `form:"gain,omitempty" json:"gain,omitempty"`
+
+	// Pan pan position of the channels, 0 left to 127 right
+	Pan *int `form:"pan,omitempty" json:"pan,omitempty"`
+
+	// Mute silence the notes
+	Mute *bool `form:"mute,omitempty" json:"mute,omitempty"`
+
+	// Solo silence the notes of those not soloed
+	Solo *bool `form:"solo,omitempty" json:"solo,omitempty"`
+}
+
+// PlayMusicParams defines parameters for PlayMusic.
+type PlayMusicParams struct {
+	// Format format of the response, csv returns the schedule of a dry run
+	Format *PlayMusicParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// PlayMusicParamsFormat defines parameters for PlayMusic.
+type PlayMusicParamsFormat string
+
+// SeekMusicParams defines parameters for SeekMusic.
+type SeekMusicParams struct {
+	// Ms position in milliseconds from the start of the music
+	Ms int64 `form:"ms" json:"ms"`
+}
+
+// InterpretPerformanceParams defines parameters for InterpretPerformance.
+type InterpretPerformanceParams struct {
+	// Tempo factor applied to the tempo of the music, 0.5 plays it twice slower
+	Tempo *float64 `form:"tempo,omitempty" json:"tempo,omitempty"`
+
+	// Transpose semitones the notes are shifted by, the percussion channel aside
+	Transpose *int `form:"transpose,omitempty" json:"transpose,omitempty"`
+}
+
+// MixPerformanceParams defines parameters for MixPerformance.
+type MixPerformanceParams struct {
+	// Musician id of the musician mixed, or track
+	Musician *string `form:"musician,omitempty" json:"musician,omitempty"`
+
+	// Track index of the track mixed, or musician
+	Track *int `form:"track,omitempty" json:"track,omitempty"`
+
+	// Reset bring the mix back to the default before the other changes
+	Reset *bool `form:"reset,omitempty" json:"reset,omitempty"`
+
+	// Gain factor applied to the velocity of the notes and the volume of the channels
+	Gain *float64 `form:"gain,omitempty" json:"gain,omitempty"`
+
+	// Pan pan position of the channels, 0 left to 127 right
+	Pan *int `form:"pan,omitempty" json:"pan,omitempty"`
+
+	// Mute silence the notes
+	Mute *bool `form:"mute,omitempty" json:"mute,omitempty"`
+
+	// Solo silence the notes of those not soloed
+	Solo *bool `form:"solo,omitempty" json:"solo,omitempty"`
+}
+
+// SeekPerformanceParams defines parameters for SeekPerformance.
+type SeekPerformanceParams struct {
+	// Ms position in milliseconds from the start of the music
+	Ms int64 `form:"ms" json:"ms"`
+}
+
+// MoveQueueItemParams defines parameters for MoveQueueItem.
+type MoveQueueItemParams struct {
+	// Index index of the item in the queue, 0 plays it next
+	Index int `form:"index" json:"index"`
+}
+
+// SetQueueModesParams defines parameters for SetQueueModes.
+type SetQueueModesParams struct {
+	// Repeat off drops the items played, one plays the current item again until skipped, all puts the items played back at the end
+	Repeat *SetQueueModesParamsRepeat `form:"repeat,omitempty" json:"repeat,omitempty"`
+
+	// Shuffle play the items in a random order
+	Shuffle *bool `form:"shuffle,omitempty" json:"shuffle,omitempty"`
+
+	// Gapless start the next item at the end of the last note of the current one
+	Gapless *bool `form:"gapless,omitempty" json:"gapless,omitempty"`
+}
+
+// SetQueueModesParamsRepeat defines parameters for SetQueueModes.
+type SetQueueModesParamsRepeat string
+
+// ClockJSONRequestBody defines body for Clock for application/json ContentType.
+type ClockJSONRequestBody = ClockRequest
+
+// UploadMusicMultipartRequestBody defines body for UploadMusic for multipart/form-data ContentType.
+type UploadMusicMultipartRequestBody UploadMusicMultipartBody
+
 // PlayMusicJSONRequestBody defines body for PlayMusic for application/json ContentType.
-type PlayMusicJSONRequestBody = Musician
+type PlayMusicJSONRequestBody = PlayOptions
 
 // RegisterMusicianJSONRequestBody defines body for RegisterMusician for application/json ContentType.
 type RegisterMusicianJSONRequestBody = Musician
+
+// QueueMusicJSONRequestBody defines body for QueueMusic for application/json ContentType.
+type QueueMusicJSONRequestBody = PlayOptions
+
+// SetSectionJSONRequestBody defines body for SetSection for application/json ContentType.
+type SetSectionJSONRequestBody = Section
@@ -13,6 +13,7 @@ import (
 	"path"
 	"strings"
 
+	. "crossjoin.com/gorxestra/daemon/conductord/api/server/v1/openapi/generated/model"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
@@ -20,15 +21,114 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
-	// Play a musician
+	// Clock synchronization
+	// (POST /v1/clock)
+	Clock(ctx echo.Context) error
+	// List the musics
+	// (GET /v1/music)
+	ListMusic(ctx echo.Context) error
+	// Upload a music
+	// (POST /v1/music)
+	UploadMusic(ctx echo.Context) error
+	// Change the interpretation of the music
+	// (POST /v1/music/interpretation)
+	InterpretMusic(ctx echo.Context, params InterpretMusicParams) error
+	// Mix the music
+	// (POST /v1/music/mix)
+	MixMusic(ctx echo.Context, params MixMusicParams) error
+	// Pause the music
+	// (POST /v1/music/pause)
+	PauseMusic(ctx echo.Context) error
+	// Play a music
 	// (POST /v1/music/play/{name})
-	PlayMusic(ctx echo.Context, name string) error
+	PlayMusic(ctx echo.Context, name string, params PlayMusicParams) error
+	// Resume the music
+	// (POST /v1/music/resume)
+	ResumeMusic(ctx echo.Context) error
+	// Seek the music
+	// (POST /v1/music/seek)
+	SeekMusic(ctx echo.Context, params SeekMusicParams) error
+	// Playback status
+	// (GET /v1/music/status)
+	MusicStatus(ctx echo.Context) error
+	// Stop the music
+	// (POST /v1/music/stop)
+	StopMusic(ctx echo.Context) error
+	// Delete a music
+	// (DELETE /v1/music/{name})
+	RemoveMusic(ctx echo.Context, name string) error
+	// Describe a music
+	// (GET /v1/music/{name})
+	MusicInfo(ctx echo.Context, name string) error
 	// Register a musician
 	// (POST /v1/musician)
 	RegisterMusician(ctx echo.Context) error
 	// Unregister a Musician
 	// (DELETE /v1/musician/{id})
 	UnregisterMusician(ctx echo.Context, id string) error
+	// Renew the registration of a musician
+	// (POST /v1/musician/{id}/heartbeat)
+	Heartbeat(ctx echo.Context, id string) error
+	// Silence every musician
+	// (POST /v1/panic)
+	Panic(ctx echo.Context) error
+	// List the performances
+	// (GET /v1/performances)
+	ListPerformances(ctx echo.Context) error
+	// Performance status
+	// (GET /v1/performances/{id})
+	PerformanceStatus(ctx echo.Context, id string) error
+	// Change the interpretation of a performance
+	// (POST /v1/performances/{id}/interpretation)
+	InterpretPerformance(ctx echo.Context, id string, params InterpretPerformanceParams) error
+	// Mix a performance
+	// (POST /v1/performances/{id}/mix)
+	MixPerformance(ctx echo.Context, id string, params MixPerformanceParams) error
+	// Pause a performance
+	// (POST /v1/performances/{id}/pause)
+	PausePerformance(ctx echo.Context, id string) error
+	// Resume a performance
+	// (POST /v1/performances/{id}/resume)
+	ResumePerformance(ctx echo.Context, id string) error
+	// Seek a performance
+	// (POST /v1/performances/{id}/seek)
+	SeekPerformance(ctx echo.Context, id string, params SeekPerformanceParams) error
+	// Stop a performance
+	// (POST /v1/performances/{id}/stop)
+	StopPerformance(ctx echo.Context, id string) error
+	// Clear the queue
+	// (DELETE /v1/queue)
+	ClearQueue(ctx echo.Context) error
+	// Describe the queue
+	// (GET /v1/queue)
+	GetQueue(ctx echo.Context) error
+	// Remove an item of the queue
+	// (DELETE /v1/queue/item/{id})
+	RemoveQueueItem(ctx echo.Context, id string) error
+	// Move an item of the queue
+	// (POST /v1/queue/item/{id}/move)
+	MoveQueueItem(ctx echo.Context, id string, params MoveQueueItemParams) error
+	// Change the modes of the queue
+	// (POST /v1/queue/modes)
+	SetQueueModes(ctx echo.Context, params SetQueueModesParams) error
+	// Queue a music
+	// (POST /v1/queue/music/{name})
+	QueueMusic(ctx echo.Context, name string) error
+	// Play the queue
+	// (POST /v1/queue/play)
+	PlayQueue(ctx echo.Context) error
+	// Skip an item of the queue
+	// (POST /v1/queue/skip)
+	SkipQueue(ctx echo.Context) error
+	// List the sections
+	// (GET /v1/sections)
+	ListSections(ctx echo.Context) error
+	// Remove a section
+	// (DELETE /v1/sections/{name})
+	RemoveSection(ctx echo.Context, name string) error
+	// Define a section
+	// (PUT /v1/sections/{name})
+	SetSection(ctx echo.Context, name string) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -36,6 +136,127 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// Clock converts echo context to params.
+func (w *ServerInterfaceWrapper) Clock(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Clock(ctx)
+	return err
+}
+
+// ListMusic converts echo context to params.
+func (w *ServerInterfaceWrapper) ListMusic(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListMusic(ctx)
+	return err
+}
+
+// UploadMusic converts echo context to params.
+func (w *ServerInterfaceWrapper) UploadMusic(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UploadMusic(ctx)
+	return err
+}
+
+// InterpretMusic converts echo context to params.
+func (w *ServerInterfaceWrapper) InterpretMusic(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params InterpretMusicParams
+	// ------------- Optional query parameter "tempo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tempo", ctx.QueryParams(), &params.Tempo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tempo: %s", err))
+	}
+
+	// ------------- Optional query parameter "transpose" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "transpose", ctx.QueryParams(), &params.Transpose)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter transpose: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.InterpretMusic(ctx, params)
+	return err
+}
+
+// MixMusic converts echo context to params.
+func (w *ServerInterfaceWrapper) MixMusic(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MixMusicParams
+	// ------------- Optional query parameter "musician" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "musician", ctx.QueryParams(), &params.Musician)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter musician: %s", err))
+	}
+
+	// ------------- Optional query parameter "track" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "track", ctx.QueryParams(), &params.Track)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter track: %s", err))
+	}
+
+	// ------------- Optional query parameter "reset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reset", ctx.QueryParams(), &params.Reset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reset: %s", err))
+	}
+
+	// ------------- Optional query parameter "gain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "gain", ctx.QueryParams(), &params.Gain)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter gain: %s", err))
+	}
+
+	// ------------- Optional query parameter "pan" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pan", ctx.QueryParams(), &params.Pan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter pan: %s", err))
+	}
+
+	// ------------- Optional query parameter "mute" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mute", ctx.QueryParams(), &params.Mute)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter mute: %s", err))
+	}
+
+	// ------------- Optional query parameter "solo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "solo", ctx.QueryParams(), &params.Solo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter solo: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.MixMusic(ctx, params)
+	return err
+}
+
+// PauseMusic converts echo context to params.
+func (w *ServerInterfaceWrapper) PauseMusic(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PauseMusic(ctx)
+	return err
+}
+
 // PlayMusic converts echo context to params.
 func (w *ServerInterfaceWrapper) PlayMusic(ctx echo.Context) error {
 	var err error
@@ -47,8 +268,94 @@ func (w *ServerInterfaceWrapper) PlayMusic(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PlayMusicParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PlayMusic(ctx, name, params)
+	return err
+}
+
+// ResumeMusic converts echo context to params.
+func (w *ServerInterfaceWrapper) ResumeMusic(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ResumeMusic(ctx)
+	return err
+}
+
+// SeekMusic converts echo context to params.
+func (w *ServerInterfaceWrapper) SeekMusic(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SeekMusicParams
+	// ------------- Required query parameter "ms" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "ms", ctx.QueryParams(), &params.Ms)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ms: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SeekMusic(ctx, params)
+	return err
+}
+
+// MusicStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) MusicStatus(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.MusicStatus(ctx)
+	return err
+}
+
+// StopMusic converts echo context to params.
+func (w *ServerInterfaceWrapper) StopMusic(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.StopMusic(ctx)
+	return err
+}
+
+// RemoveMusic converts echo context to params.
+func (w *ServerInterfaceWrapper) RemoveMusic(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PlayMusic(ctx, name)
+	err = w.Handler.RemoveMusic(ctx, name)
+	return err
+}
+
+// MusicInfo converts echo context to params.
+func (w *ServerInterfaceWrapper) MusicInfo(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.MusicInfo(ctx, name)
 	return err
 }
 
@@ -77,6 +384,394 @@ func (w *ServerInterfaceWrapper) UnregisterMusician(ctx echo.Context) error {
 	return err
 }
 
+// Heartbeat converts echo context to params.
+func (w *ServerInterfaceWrapper) Heartbeat(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Heartbeat(ctx, id)
+	return err
+}
+
+// Panic converts echo context to params.
+func (w *ServerInterfaceWrapper) Panic(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Panic(ctx)
+	return err
+}
+
+// ListPerformances converts echo context to params.
+func (w *ServerInterfaceWrapper) ListPerformances(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListPerformances(ctx)
+	return err
+}
+
+// PerformanceStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) PerformanceStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PerformanceStatus(ctx, id)
+	return err
+}
+
+// InterpretPerformance converts echo context to params.
+func (w *ServerInterfaceWrapper) InterpretPerformance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params InterpretPerformanceParams
+	// ------------- Optional query parameter "tempo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tempo", ctx.QueryParams(), &params.Tempo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tempo: %s", err))
+	}
+
+	// ------------- Optional query parameter "transpose" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "transpose", ctx.QueryParams(), &params.Transpose)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter transpose: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.InterpretPerformance(ctx, id, params)
+	return err
+}
+
+// MixPerformance converts echo context to params.
+func (w *ServerInterfaceWrapper) MixPerformance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MixPerformanceParams
+	// ------------- Optional query parameter "musician" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "musician", ctx.QueryParams(), &params.Musician)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter musician: %s", err))
+	}
+
+	// ------------- Optional query parameter "track" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "track", ctx.QueryParams(), &params.Track)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter track: %s", err))
+	}
+
+	// ------------- Optional query parameter "reset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reset", ctx.QueryParams(), &params.Reset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reset: %s", err))
+	}
+
+	// ------------- Optional query parameter "gain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "gain", ctx.QueryParams(), &params.Gain)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter gain: %s", err))
+	}
+
+	// ------------- Optional query parameter "pan" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pan", ctx.QueryParams(), &params.Pan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter pan: %s", err))
+	}
+
+	// ------------- Optional query parameter "mute" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mute", ctx.QueryParams(), &params.Mute)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter mute: %s", err))
+	}
+
+	// ------------- Optional query parameter "solo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "solo", ctx.QueryParams(), &params.Solo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter solo: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.MixPerformance(ctx, id, params)
+	return err
+}
+
+// PausePerformance converts echo context to params.
+func (w *ServerInterfaceWrapper) PausePerformance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PausePerformance(ctx, id)
+	return err
+}
+
+// ResumePerformance converts echo context to params.
+func (w *ServerInterfaceWrapper) ResumePerformance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ResumePerformance(ctx, id)
+	return err
+}
+
+// SeekPerformance converts echo context to params.
+func (w *ServerInterfaceWrapper) SeekPerformance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SeekPerformanceParams
+	// ------------- Required query parameter "ms" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "ms", ctx.QueryParams(), &params.Ms)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ms: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SeekPerformance(ctx, id, params)
+	return err
+}
+
+// StopPerformance converts echo context to params.
+func (w *ServerInterfaceWrapper) StopPerformance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.StopPerformance(ctx, id)
+	return err
+}
+
+// ClearQueue converts echo context to params.
+func (w *ServerInterfaceWrapper) ClearQueue(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ClearQueue(ctx)
+	return err
+}
+
+// GetQueue converts echo context to params.
+func (w *ServerInterfaceWrapper) GetQueue(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetQueue(ctx)
+	return err
+}
+
+// RemoveQueueItem converts echo context to params.
+func (w *ServerInterfaceWrapper) RemoveQueueItem(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RemoveQueueItem(ctx, id)
+	return err
+}
+
+// MoveQueueItem converts echo context to params.
+func (w *ServerInterfaceWrapper) MoveQueueItem(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MoveQueueItemParams
+	// ------------- Required query parameter "index" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "index", ctx.QueryParams(), &params.Index)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter index: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.MoveQueueItem(ctx, id, params)
+	return err
+}
+
+// SetQueueModes converts echo context to params.
+func (w *ServerInterfaceWrapper) SetQueueModes(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetQueueModesParams
+	// ------------- Optional query parameter "repeat" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "repeat", ctx.QueryParams(), &params.Repeat)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter repeat: %s", err))
+	}
+
+	// ------------- Optional query parameter "shuffle" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "shuffle", ctx.QueryParams(), &params.Shuffle)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter shuffle: %s", err))
+	}
+
+	// ------------- Optional query parameter "gapless" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "gapless", ctx.QueryParams(), &params.Gapless)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter gapless: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SetQueueModes(ctx, params)
+	return err
+}
+
+// QueueMusic converts echo context to params.
+func (w *ServerInterfaceWrapper) QueueMusic(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.QueueMusic(ctx, name)
+	return err
+}
+
+// PlayQueue converts echo context to params.
+func (w *ServerInterfaceWrapper) PlayQueue(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PlayQueue(ctx)
+	return err
+}
+
+// SkipQueue converts echo context to params.
+func (w *ServerInterfaceWrapper) SkipQueue(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SkipQueue(ctx)
+	return err
+}
+
+// ListSections converts echo context to params.
+func (w *ServerInterfaceWrapper) ListSections(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListSections(ctx)
+	return err
+}
+
+// RemoveSection converts echo context to params.
+func (w *ServerInterfaceWrapper) RemoveSection(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RemoveSection(ctx, name)
+	return err
+}
+
+// SetSection converts echo context to params.
+func (w *ServerInterfaceWrapper) SetSection(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SetSection(ctx, name)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -105,28 +800,150 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.POST(baseURL+"/v1/clock", wrapper.Clock, m...)
+	router.GET(baseURL+"/v1/music", wrapper.ListMusic, m...)
+	router.POST(baseURL+"/v1/music", wrapper.UploadMusic, m...)
+	router.POST(baseURL+"/v1/music/interpretation", wrapper.InterpretMusic, m...)
+	router.POST(baseURL+"/v1/music/mix", wrapper.MixMusic, m...)
+	router.POST(baseURL+"/v1/music/pause", wrapper.PauseMusic, m...)
 	router.POST(baseURL+"/v1/music/play/:name", wrapper.PlayMusic, m...)
+	router.POST(baseURL+"/v1/music/resume", wrapper.ResumeMusic, m...)
+	router.POST(baseURL+"/v1/music/seek", wrapper.SeekMusic, m...)
+	router.GET(baseURL+"/v1/music/status", wrapper.MusicStatus, m...)
+	router.POST(baseURL+"/v1/music/stop", wrapper.StopMusic, m...)
+	router.DELETE(baseURL+"/v1/music/:name", wrapper.RemoveMusic, m...)
+	router.GET(baseURL+"/v1/music/:name", wrapper.MusicInfo, m...)
 	router.POST(baseURL+"/v1/musician", wrapper.RegisterMusician, m...)
 	router.DELETE(baseURL+"/v1/musician/:id", wrapper.UnregisterMusician, m...)
+	router.POST(baseURL+"/v1/musician/:id/heartbeat", wrapper.Heartbeat, m...)
+	router.POST(baseURL+"/v1/panic", wrapper.Panic, m...)
+	router.GET(baseURL+"/v1/performances", wrapper.ListPerformances, m...)
+	router.GET(baseURL+"/v1/performances/:id", wrapper.PerformanceStatus, m...)
+	router.POST(baseURL+"/v1/performances/:id/interpretation", wrapper.InterpretPerformance, m...)
+	router.POST(baseURL+"/v1/performances/:id/mix", wrapper.MixPerformance, m...)
+	router.POST(baseURL+"/v1/performances/:id/pause", wrapper.PausePerformance, m...)
+	router.POST(baseURL+"/v1/performances/:id/resume", wrapper.ResumePerformance, m...)
+	router.POST(baseURL+"/v1/performances/:id/seek", wrapper.SeekPerformance, m...)
+	router.POST(baseURL+"/v1/performances/:id/stop", wrapper.StopPerformance, m...)
+	router.DELETE(baseURL+"/v1/queue", wrapper.ClearQueue, m...)
+	router.GET(baseURL+"/v1/queue", wrapper.GetQueue, m...)
+	router.DELETE(baseURL+"/v1/queue/item/:id", wrapper.RemoveQueueItem, m...)
+	router.POST(baseURL+"/v1/queue/item/:id/move", wrapper.MoveQueueItem, m...)
+	router.POST(baseURL+"/v1/queue/modes", wrapper.SetQueueModes, m...)
+	router.POST(baseURL+"/v1/queue/music/:name", wrapper.QueueMusic, m...)
+	router.POST(baseURL+"/v1/queue/play", wrapper.PlayQueue, m...)
+	router.POST(baseURL+"/v1/queue/skip", wrapper.SkipQueue, m...)
+	router.GET(baseURL+"/v1/sections", wrapper.ListSections, m...)
+	router.DELETE(baseURL+"/v1/sections/:name", wrapper.RemoveSection, m...)
+	router.PUT(baseURL+"/v1/sections/:name", wrapper.SetSection, m...)
 
 } // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RWTW/cNhD9KwRboC0grNaJT3uL04/4YDRw0V5SI+CKsxITiWSHI8cLQ/+9GIraXX3U",
-	"m6IJYKCnJanRzHtvHkf7KAvXeGfBUpCbRxmKChoVl1etqfUfgME4y3uPzgOSgfh0i8oWFa80hAKNpxgm",
-	"r+K5oArElhMIE8RWBdDCWZlJ2nuQGxkIjS1ll8kY9N62zRZwnu0Xhw8QCNV3QTTGOhT3PSCR3jhkNJag",
-	"BOSURaWshfq/Yytc0xh6X6mwQPSNCpVwO9EHfX7SRn1w55hyyOcwjZJ8EdW6TCL81RoELTfvEsihwKRL",
-	"Y2GywQpH4e+6TP6E2EMb2waG4zHiGC0aCEGVMFdtgq5PwlWu7c4teJPR8uJbhJ3cyG/yo8nz5PB8ZO8u",
-	"k0miMAeHQC3aIJSoTSDueWi9d0ighUdHrnD1oHAQ3wuzgpW4v8jE/QsBVKzEDzKThqCJuWeGSAcKUe1n",
-	"VA+oUg8OrG8heGcDLLB3en+OfNRtWiu+yPlv2mAKoxZuvdIaISxo1KRXxBCx4Hyj5+9da9aTL8+Q4Wz3",
-	"jZbZAchdx49NssE4928eCrMzheL9UOa1s7otyKF4Q+TFq7fXXNBQzRUnD0cJ5MEiciMvVuvVmjk5D1Z5",
-	"IzfyZTzKpFdURYHyClRNPDm6TOYJIi8bIDRFSDsEFfvF60AKqfXD7pMqyzgVeXd/kUeJcl+rff5oVQNd",
-	"bJALNOf+tlZ7oXpR/2Ts3MXI41qnx7HLETCqBggwyM27aR5/koddHM8U8W1nBHLT/5x2iLCFLH1IFgzf",
-	"3fXBEOgqObVwlsBGFsr7Ogmefwj9h+eY6ilLH0wbHTFmcdvXE7HgFGu0V3+ZYt9erNcTUAQPxLKbCZwp",
-	"s1ndiElswdhSsJKg2TOX68t5w/pQ60jsXGtjnIadamv6Ygr1I3kBZmvhwUPB8wxSTCZD2zQK9xMvpQuq",
-	"yhCn0wVfwBNzDkNj0ZO3UJpAgCe5Fqw5RN0cy/2v7MJD9JXWT1glzQ/xCRD474c3Nf/baOk5mWah2WeM",
-	"kz8a3fVsayCY8/7d4jHpzT876Bh34qEnp5xZ+gotzLr46fmXk25ulXHtXz+uxI+RsT6QWvW9f/n1u/iz",
-	"w63RGoaSl1+/5OuqtR+Pk271nFy7aLGZb7vu7wAAAP//lgGu6jcNAAA=",
+	"H4sIAAAAAAAC/+x963PjuJH4v4LS71d1d1Vc2zOZvavMt30ku1MVb+bGk9yHeCsFkS0JaxLgAqBtZcv/",
+	"+1U3ABIkQYnyQ6NL/MkWH0Cju9HvBn9b5KqqlQRpzeL9bwuTb6Di9O+3jSiLv4I2Qkn8XWtVg7YC6O5S",
+	"c5lv8L8CTK5Fbemxxbd0ndkNsCUOwIRhS26gYEousoXd1rB4vzBWC7lePGQLeujvsqmWoMej/aD0PRir",
+	"+b8ZVgmpNLt1ADH/RjuikBbWoHHIfMOlhPLpsOWqqoT9+4abxEJ/5GbD1Iq5h+YPWvFf1L6V8l/mrZRQ",
+	"8ixYe8gWGn5thIZi8f5vHsgwwYBKfcRkgRU6xP/8kC2+4zVfilIEfulDeLfhDmVVY0QuuESs8WUJzCpW",
+	"l3ybMd7duxN2oxrL8mhMesowLrd2g5jNBvzpYUnMffnh+w8s3KZhoMjYSquKXeD0b77OGC9LdrcByaCq",
+	"7XaRLYSFisYaE8Ff4VrzLf4u+TI570oDfLVSumKWrw1zN5dCrnuISMzV8c54Kgsy314mZrOiAiYkq0RZ",
+	"CgO5koVhS7B3AJJxJpUFtgScvWiAcVkwYQ0zqpEFsjTeUI3NCDZ82DCugRmQSDduWdXkGwZcl4I4AtfF",
+	"rUPMf75LMmsNOm9MkCYDaGNWcJTFS4VuKkObzJGL/d4BZDXPbwxrDIIpLFOy3LK1QuoZhCsMZTpAlkqV",
+	"wCUBosptvVFyO4bDrZSwgENzHDqHjDWyFJWwUDiuuEgvUKu15lWCGD+ABM1LRqwXHkux3tv/ypClH8l7",
+	"g03cboEItHj1PZLEzNTyMG3kUuU3n+DXBowdqwGlxVpIbmG8aO3eAc2IF2lJSDx/nd1xQ/wUc0/BLXyF",
+	"j48l52Bx3cQtkFe8qkv4kjAiiDmIW0hxVgETk3CtxS0Us+ewmktTCTtrEi7NHehnQ3a3wggOpMD3WtU1",
+	"FH+4BWm/55aPyZCrRqZgJqWCmxzwXbfx81KAtKwSxvQR00zJlyHv02QI2B+0VnoMDYTLfWjoaVaBMXy9",
+	"Hy9uEJzlRy4LdQt6x/pxm48nFAUuvSf/SL464RBJu4zxJYnfQNot2/CCSRWrjrG1gfcSSOcV9OZNvVuD",
+	"JrzLHHbBHT+W4lY1a9FS3dGagz5MLTpaK8tVUxbM8hvAx6uJfZLfpFQjXWeVukUEK5pOwl1SBf9/DavF",
+	"+8X/O+/M5HNvI59/xnG+MUasZQXS7pXHfUS1aHdQIhN9kCuVsLTR9toHS89Yf8gW3uAzKZFnGy0N46wU",
+	"xiIdTFPXSqNyq7WyKldlsBcN+3cmzuCM3b7J2O1bBjY/Y/9xgIkywEALlbco21V/AlMraRLCe6mK7b7F",
+	"E96Gc9GLOP6fgKcGLvHybMvJyeu1MFZzfI6V3FjTWqacGGgDXNslcDvHIBqAG8AhiBUvJmxmzmquiWo8",
+	"3niMmxsT9pSS0G4lYUeWsROz4/G90HN6ImNC5mVThO0IsmjHJyMpaQGthDZ2Eqf+dXooiFhn/OBlY/3C",
+	"BjJlSIp5xmbJ9wNS8peHw+Fqys7EqaBIvlgDv/mUtFlKrtdgLOsUZ2804kkhGWcO0PTo0ybw5PADo3g/",
+	"S3tGC0iI1tS3QgPXtFTDPXAp7sd7ds1FwnlY8dwqzXhdl6KT6LdQqlzYbY9rydGhu6psOk6ILOXOOlLN",
+	"soxW6fDhdGqKLANHSZQgcyjSDghPrKJGz0cZgT+HcLVqsAR+G7Zkzdvngi6p+L2ommrxHh2JRSWk+3WR",
+	"YgGjSrVrFTS0MvST4cNQ7FvZgP5ELY8uP5+nLCQMss5nG0smcQ9maDGYzMPnUdPxp2F3WlgLsxX5pR8S",
+	"eS7hZU/ZET2wgr3yXDCRcZEEaBiqiX3dzpagNaUNiqJxOiwlH8O9HrYfJ/rCI8Mpri7/yNy9jF0wdOFL",
+	"j76MvWFmK/ONVlI1psXpWyZkATXIApHqrianrFQhVgKKb+yE5O+WhA4RL4oD3C60mR9pSRvxj8SreLVT",
+	"iiVpmOXWicoZ+LVQ1akdjJeZD6/1lFlLy28/Xs6TdFOc3+mGSWIMuJSQ5xHRTt1OkMU8GVbWI2fL0oIn",
+	"gtG8KDSY1Bb1r7DwRCrKO4hU7tqVvajmQ7YQCTvtw8i52etHCuTCAGK81B3u5JPW/NJwJ7V3Je734dcL",
+	"vCqi9D7PcS+Q0YMIQQzmVb6BoknFjEpvgu8Clsz0F4SWYEBwf1IWKDCxgx0m4hkFlOIW9Ja5+1MRgkdD",
+	"P23hDsxHCmo4p10qy5bAPGiT9u/Tgw/7/W+3pmCfduGcjyXf/plmM4md17r8iXXDfV2KXFhWCARj2cTa",
+	"NCg0IJJ04zAJUJgo5fFsIYhsUejtpyZlb5ZcxvrQe7I9x3FsuoLcuy0+eiOWHr9HJxImYzBSuRmhiFc8",
+	"I8eiVL1bL4kKTKz1QrjdOXpOL1qFfi1aHRReqoQxLpXUWs5vJrJul9PGagdDa5n5yAHyes8CEdagJcSW",
+	"sFLaK+sBAGnTHXKbTKP4G71AGk3m0iZ3G1UCUzrfUHZwuOqx2YIAHULuCZMk7aDRwz0Jk6DE2EhpfZx3",
+	"MZrO3n59gP3ioiTlNiSSQiYG034OoGoIyAHMSXHxWpmUyQeVsEp61ozcxY1Yoe++3GZBrPnETJv64kYU",
+	"gBbzkFcDPt7GCPnq7bsWsM4m85LtY5kyokAWKY8AZCdsR8ak31OP8g+8bCgmhQOKJI+jElaWoXhabh0k",
+	"Jd8y5QX0QcQ5XK+0zjduVc4KvWW6SapCE5kTu7ZMa3bgOzcC8yY7kYALJzxkLr3JTbSB6LnDcED7OkXr",
+	"fuzrWak9tRUjZdrXkhH5M8bdRWbqUhATxEl8tkFfTsb6tAYd7r5UNL91XQIFI47uUJz5bRWMiiXPb3bm",
+	"yDCFaVP8YMAyL9pxkLOVkMJsQka6IxJ6yQXjKwuaUvoU5UQ0JvX5kWIBT0lDSbi3j0dH3kDRhka1WG+s",
+	"w03W7qw1r18q+zUrARSRIOaSK8ttYw5ikRlMwDS3G9Bojktf/2Gs8vybYA+X1f1pVxS7jQK0GI2s2P2J",
+	"26PxIJS8NpDUcW3cVcinzrLiotyDsLEr5MpqVN8BmIO6KsRS9zjVT9+FT9CbPndLdVoUd2st/tEsj7Fs",
+	"I+2cMHIn7NpUBJ8utwvx25AkeFO5aAcZoB4E/I83xkt7t4V+Tkz2RJv40EDdc7mNTzJi98cDHQmySJrF",
+	"G3QYD2yjhLE46u+1LmrYQR72B4nV4Kbs3Pw8MBUIEpOVYWT0Lbl29oiwWEXHx1nVJU9EX/C1wE9+loxR",
+	"aQryMHmib/a6mzTdeGjgdjg2roAA5Ho0zeFe7lT2NJ0pnS0yd7m2DxGhdlhJSVxHvj+W4nLt1Vvw7YJ3",
+	"zbglpH8pBfGlK3Jm2SSI4RghuIP+u4EmESnNG61B7g0T0NsfLFS4jjWvy2Ts2pXi3FuGYsyRzIRcRuSI",
+	"duaMv+CBYEqmzdwNlBP20q8Il4/FWIXK2Hs5HNOXmbeh+nkjL+6Z1zhI/IakuYZRSj8CohXNA7rhZYJA",
+	"yHUWOEzpws27Jdk6DpLNRvZQsmuokxKlrcl2KCkUeB8MrzkgWyiCSlSr1SJbOKzzskwqQLNpVqsSphbe",
+	"rc4JYM1loSq3/v05ZoeOdlHdbB2Teeq3LEx4GbGxKHbtKJzmucvrVBdf3hlfi0LRyfxLmCCMhwuNExvp",
+	"KqJE7RDwqHQ6cwEyVy1GSr0X+5ifUW7zrvGUXfWUc0+Uqs0TxOlE2UC4xfgtFyW2F4T8fBvtRg22cTUV",
+	"3FdcM8q7HFg6EId0hhuukS4yMS/U5ffCcsukohq2Q+IX02DsqBqI4Bt5o1edQT5IkE10OfRKNNy6lAyb",
+	"wQBz77EllEqug9kbzP5Deh92EF64WrUODDebeNqE+/P/3ai7dS6NhNgd2uFj5TrVytSLf8Vxs4h/YlJk",
+	"we4bxEf6/RbOdwoRxa6a6Ot9GYlDkojjMt8BcI7vPVjCjtHpPYLEZLKA+z46YoNtv1vixm1J88Q09stC",
+	"2aWz+1v/SbnsFuSZIPgcNd4WvthoUO1TQy5WIu9Fcb5rLaYfra3ZNx8/4KKFRegXg5u9ARZtZfPi/eLN",
+	"2cXZhdOkIHktFu8Xv6NL6JnbDa39fAO8tNi+95Atzj2I+G8FVovc+F8aeLH1/5MJ19Th1x1fr0H7X7dv",
+	"znNsNSEkK5Owon76/JEZuy2B0YMM7nGXruGMfSZnuQgtGZFxFVTktRy0hLTliuEJ/y7lQzJ2txH5hpVg",
+	"Te9t0AyMFRW37h21WhmwbfPXUtmNA64rh9RYqHYtrRZ1oFEL+LUk4wKcXvhQIInw7UUW2me+9TXauZLW",
+	"izGKazianf9inAJxvLa3xCbuNyLG6iPY32LfOt3YMaXVDRCXulJyIv/bi4vnBcz3GCXgotvM+Pt4d8Wb",
+	"0j7b9K6PJTFxI+G+htxC4Ys88BHTVBXX2w4uX1Yn/hH2ETYhUk3+G9y/jrfb0nD/uzVv15Bg9T8JEzVx",
+	"tumbUiw111tmXE8BGjK8ggQX4fuXXuI9iWrzLTXXKTCyjUY4vUyt6JSoOsD9kJ7ZhHT6pigYZ1eWy4Lr",
+	"wmXR/ihc2220Uies2soJpJ/PJlxLfKypUe5jMQW+20j0sxin5/D5tbgFecZ+4hUYctuFZGcVWgL6WuI/",
+	"ggRPTm0/liEiuZAMhTYzUHPNrdImJXj+QhN3TDMlfqqmtAKN+3N0Ib4qfBipo8mgNUukXKUxmmKXZCkk",
+	"kuLxhaJB6Qfu2mc1EpCdg62Wv0B+WhIy2mITW8qX3D5ki3cXFy+/iT7IW16KwjGm0kz43wnKEki/f3mQ",
+	"PBpKsjgY3AtjzSkJFrfDQiB8Qk/QvXMhLehag+Wti5gUOt+RHRHlN3zuw4fPQrxejPN8FMG9ls6lcUIJ",
+	"pUMFFnRUFMI1CiFnrhRn7BtqxnJTEQLBXMs2au3ajyh4SJ0NPvXW91u3ELe+X8u2kL/1nvpQu7mdsHPV",
+	"GduQdE6JsQ8Bc0GSdatavP/b48unLs6+bt0mZu9EDsyU6o4CaQKH+rUBkjRORLUpk46lnlJv9ZA9d7nT",
+	"FNhRhqcD/ZBCqJ/TUrAP/Z9vzo4mqT4PN0Z/UzSUZ1kiF5qjiaqfVLwNQ/D3lEzbTrD0ZdEo9jopw7wz",
+	"v1dwVeLeNV22UQzKCzrvfSyzPLLOruV8meXTGWHQtibgWrZzYruzcQ6c3YDQjFqQUhLmUtzPki2J8AyO",
+	"WWSeCV1pWWIPRoUKHZFHBsyM0Ec3XTTkxK7Pb3rT7UwljuZe6rZwQNwzzO0HUer5OdTh4iVF6V8v1icA",
+	"0mDAptYfJStetFcxBZRvupsl0d8mw3rT4nx3i+KFY253jIkrtZqAsR6wzfy2xTFMvh2x15ic5lcLB9Jq",
+	"NHSqJ3JiOry5e7qfX9IQdxU/CesTb6TSxMe2yCsXIX138e5IJrfwzuYKdeirCg0q9FLcz9SUVOw0rSt/",
+	"VGUxoQVZI60ombBMGKbBNBUUCZX1ESfYFQ+asM5eyehxN5eQJd+e/4Zy6mGanJh1DtaOc7zGmUquoety",
+	"wgBRpZyC7SyWuPXlfRgC36tAr6NiXQ10dQV3oOO3qUw1vCaLifrv/ijXslIaztj/4NW4vBxnoNBUV1ax",
+	"UiX6RwUT0ljgFHB3IS4/KT1ZKBIdOIpQkl7sGpfOrqWz8nz+3VnpOyvoM2aghNwyYc219DMFbT/sYXLb",
+	"JnaB3VA5HozHhb2Wri8h1XdkVYv/YZMRmpw+nX8tXZbfhMJHOeoGi3zMNmvRTnMtwyTIBOHItLCe0BQx",
+	"qjBwopgtt9dyNI2rvjGWcXYrtG146TIWDgHtkDkVMQf4gXzv767+mjHONJ4nBDo+VQkkXXFmZ5xkTBnQ",
+	"uAFmWdB1tFOCMYCRzM4WoD/DONxBZrOz4QIrBdmYsdzcsph2Ma7jao2UhdL2YHdwhKoeklnZIje3iYIe",
+	"Z7g8f96nX+eyO6j5kkHMtkHqgYp37+054qH3+hAjE0G+fv1hu2+GjaFUeUYy4OhWmNsKnYxkSvdbqzoT",
+	"LbXAvj315u0RwiS9KB03N5iDUJoVwtXSt9JOSBKfJ6Wj+4JiWkE7E2laN3+i+17DYAW6GzMhxNyTrzbV",
+	"o+gVoXk/zQzAjqKAS3ULU8YxtX101eGds2msKMvoCJno7J0Eqa8Abubpq6iUuXfE2Y667imf2uxUa4eV",
+	"fv/8yqAHMiiSfC57ts1kyUz+J6iVthMcmoVEDVVdh8PlWpVGNiydL0tJYFkMUindgQJBzaXClfi473h7",
+	"Yd0e9dYlUB6eYB5jJ6Y/YtB201vV0+Loyqp6ShyRlzUKfPVlUUr8WFW/6pnHbeMeNXaStfPaCygh1cP2",
+	"CfBY02BmdDLdFxkkbQR8Y5bqSJW1P4e/M1f0s+9p1QUjaM+OG0DsW7unwjwOI1NmZZYW9z+Al/VgOZbG",
+	"RJmtQcnTlKimIo/T4pZjVbAEpL1yH3IfPryEOW5NOLhtwqXxfluXYE2KKvfUZZcofIlIRDv889dWUTyh",
+	"Lv0xpodGFASX7JuugOpd6gxfqmpmd6CB4dKwQZapxp6WYzUi9h7GOf9NFDvV3l/aECTj7HKag7rnIh46",
+	"MEGeFmOiGLHD86s8waXXer97eSr+UemlKAqQx1O0320aedOJurOTKoxLsdgcvj3vjgXfIf+oZG14yHiv",
+	"4gQj9v1vBFCLplQWA+D4vrCm/76P+eN1Ol+cUUOvcXk4d/59sBCvZdfGij5A1ZgonEbh+FSY/Efojjw/",
+	"oX30LJziDo5PKoEIx4T6nkg+Yja7S7ycloDfz80TO6fmUuTT++RzoyWd0tZVyLhPFqnViinpD1aM+uLc",
+	"hc6m6BCWDWsgDLsBqEO8Ipmglof5tyfjYXp/vo+MKQpEGJkTMOphsB83Grey+5rYiWaQj/HUx+gJGcaD",
+	"9jeGuCcTFTTmJLtD6j5G99G7tbN2Eb3fPB5TnCntT54quYUy5T1GJG7DfTP1Rv+kitNWHY8KNB419RfT",
+	"UBxPe0X0P02XNgZwZ6B1tG9etB8imm12UfGuxoOPvc30hXZg9trp8K/X6ZAWQNl4K8Tb4FU+zep54IMt",
+	"Ok9uPWcPRCynsFbrWj5KTl2K+9OUUK/9Eq/9Eq/9Eq/9El9WW9LuP2IHRawVI8FzmhoSWxoepwjntjjE",
+	"xDigt+EkNNr/EXvw1eDrt3Y8jqEPrySNxpisJ33l41c+fnQ57eMYeWZ5bYz7Z6yrPU1X5J+jkPeL2lCv",
+	"4YVkTfEj9+i8mtOYCk8oNn1VQq9K6JG1tnPY+9dw2PmeQtvubGx/gHdIetIAWXu/nycTJhxqxKyi8gyQ",
+	"RfKcReDanbs+k9MYPc1yfBFOq4CGFtPh5sBaVUKiWnXvD3LNY0L4cBC2Axdgeu8mUP0D2F2IfhbMuQkm",
+	"2tocUk6xuHOSZPFeOUfk763TC+Xp0tEztWcmS9S7w9tny3t/bPsRy/UQvlBbdby6OZr1RK3+HsHV6lBu",
+	"Osf395j+u9iJ/ABJHxC/z9r/WM1dhcS1BFkwnIMyiSU3NpWO+MLstzsvQGvvq52LLjlKn09Lm/00yk5A",
+	"nsXQd5vidUuE+OSjNgQpsb1puo2668bzbE3uYJjPJ1CQKWbn5xhW3eG+8t/WYEqGf/3B0z3Fe5b0op12",
+	"vaRV7NlAWMtXaFWb0ZdHsrb90PQ++0JLoxpVH4/132R0X3StGzseyiW0uk/LTOaswndFRqck7P/2ScJn",
+	"xx7wDpTUB09SUHQfNTkoH+M+ctz7pM7B39JJ58zCl1W+UL5mniF1FI/rL/JGqjvJHKMw3KYnWi4wNoN3",
+	"C5xBx+GuA6VTrWNB1ARHKP62UjgL41qGQ3NUCAG4jdEWrrtOlvj0GxrPywBOR7MqybhpPzAohlWo/nhq",
+	"l6LBTwGlBJSTTl+8CfKf91iV6JNUE8qajobucc3xwybps0/+Zfv7iGh7mvuctEDM7Qr+BU1ET9MuPOjL",
+	"ali4P107jjz+mChJOKH5aKTu5Jcw7mNTJ3dAzTzlgPbVjFjvOPKFQZnWCCKzRMlwVlsUAhCmPWEtnB3h",
+	"zu2mEYVh/kugbc1Pi9Udp25f3Yj6MDYhueRtyVcu8SHUG1Ef4r74j2KZ/Z8RCU+GUdtv+GZ7PiJyFaY4",
+	"Rs+In2xOs8jnaFEn2R5iOsTtIt0h5074dxLdVcJ6q22tmJKTYb6r9iNqs82w6GtuxzyNwkN6/HBfmPik",
+	"I34RVYafpmkSUuB7WAkZvZYQAhrqkuehKLX/3LU0yBBIbH9GpL+9pu/dGzb9XUI6wMh/lnB8fJGwNKiZ",
+	"iGqcBrc+v9vQSrnnOQJhevMURPfi+ClS08nx08m49PfASCw/PPzvABrQoIqwpwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
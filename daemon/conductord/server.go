@@ -69,6 +69,11 @@ func (s *Server) Initialize(cfg config.ConductorConf) error {
 		return fmt.Errorf("couldn't initialize the node: %s", err)
 	}
 
+	// Bring back the musicians registered before the restart
+	if err := node.RestoreRoster(); err != nil {
+		return fmt.Errorf("couldn't restore the roster: %s", err)
+	}
+
 	s.node = ServerNode(node)
 
 	// When a caller to logging uses Fatal, we want to stop the node before os.Exit is called.
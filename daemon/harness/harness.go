@@ -0,0 +1,206 @@
+// Package harness runs a conductor and its musicians in one process, on
+// loopback ports, for end-to-end tests. The musicians play on memory
+// outputs, so that the tests can check what each of them played and when.
+package harness
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	conductorClient "crossjoin.com/gorxestra/daemon/conductord/api/client/v1"
+	conductorApi "crossjoin.com/gorxestra/daemon/conductord/api/server"
+	musicianApi "crossjoin.com/gorxestra/daemon/musiciand/api/server"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	broker "crossjoin.com/gorxestra/service/conductor"
+	"crossjoin.com/gorxestra/service/musician"
+	"crossjoin.com/gorxestra/service/musician/output"
+	"crossjoin.com/gorxestra/util"
+	"crossjoin.com/gorxestra/util/conf"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// loopbackAddr listens on a free loopback port. 127.0.0.1:0 would prefer
+// port 8080.
+const loopbackAddr = "localhost:0"
+
+// shutdownTimeout bounds the time the HTTP servers take to shut down
+const shutdownTimeout = 5 * time.Second
+
+// Orchestra is a conductor and the musicians registered with it
+type Orchestra struct {
+	Conductor *Conductor
+	Musicians []*Musician
+}
+
+// Conductor is a conductor daemon of the orchestra
+type Conductor struct {
+	Node *broker.ConductorNode
+	// Addr is the URL of its REST API
+	Addr string
+	// Client calls its REST API
+	Client conductorClient.ClientDaemon
+}
+
+// Musician is a musician daemon of the orchestra
+type Musician struct {
+	Node *musician.MusicianNode
+	// Addr is the URL of its REST API
+	Addr string
+	// Output keeps the messages the musician played
+	Output *output.Memory
+}
+
+// Id returns the id the musician registered with
+func (m *Musician) Id() data.ID {
+	return m.Node.Id()
+}
+
+// Played returns the messages the musician played so far, in order
+func (m *Musician) Played() []output.Played {
+	return m.Output.Played()
+}
+
+// Option changes the configuration of the daemons of the orchestra
+type Option func(*options)
+
+type options struct {
+	conductor func(cfg *config.ConductorConf)
+	musician  func(i int, cfg *config.MusicianConf)
+}
+
+// WithConductor changes the configuration of the conductor
+func WithConductor(change func(cfg *config.ConductorConf)) Option {
+	return func(o *options) {
+		o.conductor = change
+	}
+}
+
+// WithMusician changes the configuration of each musician, by their index
+func WithMusician(change func(i int, cfg *config.MusicianConf)) Option {
+	return func(o *options) {
+		o.musician = change
+	}
+}
+
+// Start runs a conductor and a number of musicians registered with it.
+// The daemons use their default configuration, the options aside, and a
+// temporary data directory each. They are stopped when the test ends.
+func Start(t testing.TB, musicians int, opts ...Option) *Orchestra {
+	t.Helper()
+
+	o := options{
+		conductor: func(*config.ConductorConf) {},
+		musician:  func(int, *config.MusicianConf) {},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	orchestra := &Orchestra{Conductor: startConductor(t, o.conductor)}
+	for i := range musicians {
+		orchestra.Musicians = append(orchestra.Musicians, startMusician(t, orchestra.Conductor.Addr, func(cfg *config.MusicianConf) {
+			o.musician(i, cfg)
+		}))
+	}
+	return orchestra
+}
+
+func startConductor(t testing.TB, change func(cfg *config.ConductorConf)) *Conductor {
+	t.Helper()
+
+	var cfg config.ConductorConf
+	_, err := conf.ParseConfig(&cfg, conf.WithSources())
+	require.NoError(t, err)
+	change(&cfg)
+
+	log := logging.Base()
+	node, err := broker.New(log, t.TempDir(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, node.Start())
+
+	listener, err := util.MakeListener(loopbackAddr)
+	require.NoError(t, err)
+	addr := "http://" + listener.Addr().String()
+
+	stopping := make(chan struct{})
+	e := conductorApi.NewHttpRouter(log, node, stopping, listener, cfg.Rest.ConnectionsSoftLimit)
+	stop := serve(t, e, listener)
+	t.Cleanup(func() {
+		close(stopping)
+		if err := node.Stop(); err != nil {
+			t.Errorf("stopping conductor: %v", err)
+		}
+		stop()
+	})
+
+	cli, err := conductorClient.New(addr)
+	require.NoError(t, err)
+	return &Conductor{Node: node, Addr: addr, Client: cli}
+}
+
+// startMusician runs a musician and waits for it to register with the
+// conductor
+func startMusician(t testing.TB, conductorAddr string, change func(cfg *config.MusicianConf)) *Musician {
+	t.Helper()
+
+	listener, err := util.MakeListener(loopbackAddr)
+	require.NoError(t, err)
+	addr := "http://" + listener.Addr().String()
+
+	var cfg config.MusicianConf
+	_, err = conf.ParseConfig(&cfg, conf.WithSources())
+	require.NoError(t, err)
+	cfg.Conductor.AdvertiseAddr = addr
+	cfg.Conductor.ConductorAddr = conductorAddr
+	cfg.Output.Backend = output.BackendMemory
+	change(&cfg)
+
+	log := logging.Base()
+	node, err := musician.New(log, t.TempDir(), cfg)
+	require.NoError(t, err)
+
+	stopping := make(chan struct{})
+	e := musicianApi.NewHttpRouter(log, node, stopping, listener, cfg.Rest.ConnectionsSoftLimit)
+	stop := serve(t, e, listener)
+	require.NoError(t, node.Start())
+	t.Cleanup(func() {
+		close(stopping)
+		if err := node.Stop(); err != nil {
+			t.Errorf("stopping musician: %v", err)
+		}
+		stop()
+	})
+
+	out, ok := node.Output().(*output.Memory)
+	require.True(t, ok, "musician output is not a memory output")
+	return &Musician{Node: node, Addr: addr, Output: out}
+}
+
+// serve runs the router on the listener and returns the function
+// shutting it down
+func serve(t testing.TB, e *echo.Echo, listener net.Listener) func() {
+	//nolint: exhaustruct
+	server := &http.Server{Addr: listener.Addr().String()}
+	done := make(chan error, 1)
+	go func() {
+		done <- e.StartServer(server)
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			t.Errorf("shutting down %s: %v", listener.Addr(), err)
+		}
+		if err := <-done; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			t.Errorf("serving %s: %v", listener.Addr(), err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package harness
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+// scales returns a SMF of two tracks playing four quarter notes each at
+// 240 bpm, on MIDI channels 0 and 1
+func scales(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var tempo smf.Track
+	tempo.Add(0, smf.MetaTempo(240))
+	tempo.Close(0)
+
+	file := smf.New()
+	file.TimeFormat = smf.MetricTicks(960)
+	require.NoError(t, file.Add(tempo))
+	for channel, keys := range [][]uint8{{60, 62, 64, 65}, {48, 50, 52, 53}} {
+		var tr smf.Track
+		for _, key := range keys {
+			tr.Add(0, midi.NoteOn(uint8(channel), key, 100))
+			tr.Add(960, midi.NoteOff(uint8(channel), key))
+		}
+		tr.Close(0)
+		require.NoError(t, file.Add(tr))
+	}
+
+	var buf bytes.Buffer
+	_, err := file.WriteTo(&buf)
+	require.NoError(t, err)
+	return &buf
+}
+
+func TestOrchestra(t *testing.T) {
+	o := Start(t, 2)
+	cli := o.Conductor.Client
+
+	_, err := cli.AddMusic("scales.mid", scales(t))
+	require.NoError(t, err)
+	plan, err := cli.PlayMusic("scales.mid", data.DefaultPlayOptions)
+	require.NoError(t, err)
+	require.Len(t, plan.Tracks, 2)
+
+	assert.Eventually(t, func() bool {
+		st, err := cli.MusicStatus(plan.Performance)
+		return err == nil && st.State == data.PlaybackStopped
+	}, 5*time.Second, 10*time.Millisecond)
+	st, err := cli.MusicStatus(plan.Performance)
+	require.NoError(t, err)
+	assert.True(t, st.Completed)
+
+	// Each musician plays the scale of its track, a note every quarter
+	scales := map[int][]uint8{1: {60, 62, 64, 65}, 2: {48, 50, 52, 53}}
+	for _, a := range plan.Tracks {
+		require.NotNil(t, a.Musician, "track %d played by nobody", a.Track)
+		var m *Musician
+		for _, candidate := range o.Musicians {
+			if candidate.Id() == *a.Musician {
+				m = candidate
+			}
+		}
+		require.NotNil(t, m, "track %d played by an unknown musician", a.Track)
+
+		var keys []uint8
+		var starts []time.Time
+		for _, p := range m.Played() {
+			var channel, key, velocity uint8
+			if midi.Message(p.Message).GetNoteStart(&channel, &key, &velocity) {
+				keys = append(keys, key)
+				starts = append(starts, p.At)
+			}
+		}
+		assert.Equal(t, scales[a.Track], keys)
+		for i := 1; i < len(starts); i++ {
+			assert.InDelta(t, 250*time.Millisecond, starts[i].Sub(starts[i-1]), float64(50*time.Millisecond))
+		}
+	}
+}
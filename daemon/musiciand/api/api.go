@@ -1,5 +1,14 @@
 package api
 
+import (
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+)
+
 type NodeInterface interface {
-	Play(bs []byte) error
+	Play(note data.Note) error
+	Clock(originate time.Time) (data.ClockSample, error)
+	// Capabilities returns what the musician is able to play
+	Capabilities() (data.Capabilities, error)
 }
@@ -3,13 +3,19 @@ package client
 import (
 	"fmt"
 	"net/url"
+	"time"
 
-	"crossjoin.com/gorxestra/daemon/conductord/api"
+	"crossjoin.com/gorxestra/daemon/musiciand/api"
 	utilClient "crossjoin.com/gorxestra/util/http/client"
 )
 
+// requestTimeout bounds the requests to the musician, a musician
+// accepting connections and not answering would block its caller forever
+const requestTimeout = 5 * time.Second
+
 type ClientDaemon interface {
 	api.NodeInterface
+	OpenStream() (NoteStream, error)
 }
 
 type client struct {
@@ -24,7 +30,8 @@ func New(domain string) (client, error) {
 
 	c := client{
 		httpClient: &httpClient{
-			restClient: utilClient.MakeRestClient(*u),
+			serverURL:  *u,
+			restClient: utilClient.MakeRestClient(*u).WithTimeout(requestTimeout),
 		},
 	}
 	return c, nil
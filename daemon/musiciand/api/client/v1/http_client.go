@@ -1,21 +1,28 @@
 package client
 
 import (
-	"encoding/base64"
 	"net/http"
+	"net/url"
+	"time"
 
+	"crossjoin.com/gorxestra/daemon/musiciand/api"
 	"crossjoin.com/gorxestra/daemon/musiciand/api/server/v1/openapi/generated/model"
+	"crossjoin.com/gorxestra/data"
 	utilClient "crossjoin.com/gorxestra/util/http/client"
 )
 
 const (
-	healthCheckPath = "health"
-	readyCheckPath  = "ready"
-	infoCheckPath   = "info"
-	playPath        = "/v1/play"
+	healthCheckPath  = "health"
+	readyCheckPath   = "ready"
+	infoCheckPath    = "info"
+	playPath         = "/v1/play"
+	streamPath       = "/v1/stream"
+	clockPath        = "/v1/clock"
+	capabilitiesPath = "/v1/capabilities"
 )
 
 type httpClient struct {
+	serverURL  url.URL
 	restClient utilClient.RestClient
 }
 
@@ -50,17 +57,47 @@ func (h *httpClient) Info() (resp model.InfoResponse, err error) {
 	return resp, err
 }
 
-func (h *httpClient) Play(bs []byte) error {
-	noteBase64 := base64.RawStdEncoding.EncodeToString(bs)
-
+func (h *httpClient) Play(note data.Note) error {
 	request := utilClient.Request{
 		Path:        playPath,
 		QueryParams: nil,
-		Body: model.MusicNote{
-			Note: noteBase64,
-		},
-		Method: http.MethodPost,
+		Body:        api.NoteToMusicNoteDto(note),
+		Method:      http.MethodPost,
 	}
 
 	return h.restClient.JsonSubmitForm(nil, request)
 }
+
+func (h *httpClient) Clock(originate time.Time) (data.ClockSample, error) {
+	request := utilClient.Request{
+		Path:        clockPath,
+		QueryParams: nil,
+		Body:        model.ClockRequest{Originate: originate},
+		Method:      http.MethodPost,
+	}
+
+	var resp model.ClockSample
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return data.ClockSample{}, err
+	}
+
+	return api.ClockSampleDtoToClockSample(resp), nil
+}
+
+func (h *httpClient) Capabilities() (data.Capabilities, error) {
+	request := utilClient.Request{
+		Path:        capabilitiesPath,
+		QueryParams: nil,
+		Body:        nil,
+		Method:      http.MethodGet,
+	}
+
+	var resp model.Capabilities
+	err := h.restClient.JsonSubmitForm(&resp, request)
+	if err != nil {
+		return data.Capabilities{}, err
+	}
+
+	return api.CapabilitiesDtoToCapabilities(resp)
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"crossjoin.com/gorxestra/daemon/musiciand/api/stream"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/util/http/client/protocol"
+)
+
+const (
+	// streamOpenTimeout is how long to wait for the musician to accept a
+	// stream
+	streamOpenTimeout = 5 * time.Second
+	// streamWriteTimeout is how long a batch of notes may take to be
+	// written, a musician not reading the stream would block it forever
+	streamWriteTimeout = 2 * time.Second
+)
+
+var (
+	ErrStreamClosed = errors.New("note stream closed")
+	// ErrStreamTimeout is returned when the musician stopped reading the
+	// stream, which is closed
+	ErrStreamTimeout = errors.New("note stream write timed out")
+)
+
+// NoteStream is a long lived request used to send notes to a musician
+type NoteStream interface {
+	// Send writes a batch of notes to the stream
+	Send(notes []data.Note) error
+	// Close ends the stream
+	Close() error
+}
+
+type noteStream struct {
+	mu  sync.Mutex
+	pr  *io.PipeReader
+	pw  *io.PipeWriter
+	enc *stream.Encoder
+	// cancel aborts the request of the stream
+	cancel context.CancelCauseFunc
+
+	done chan struct{}
+}
+
+// OpenStream starts a note stream with the musician. It returns once the
+// musician accepted the stream.
+func (h *httpClient) OpenStream() (NoteStream, error) {
+	u := h.serverURL
+
+	var err error
+	u.Path, err = url.JoinPath(u.Path, streamPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), pr)
+	if err != nil {
+		cancel(err)
+		return nil, err
+	}
+	req.Header.Set(protocol.HeaderContentType, string(stream.ContentType))
+
+	s := &noteStream{
+		mu:     sync.Mutex{},
+		pr:     pr,
+		pw:     pw,
+		enc:    stream.NewEncoder(pw),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	accepted := make(chan error, 1)
+	go s.run(req, pr, accepted)
+
+	select {
+	case err = <-accepted:
+	case <-time.After(streamOpenTimeout):
+		err = fmt.Errorf("%w: timeout waiting for the musician", ErrStreamClosed)
+	}
+
+	if err != nil {
+		pw.CloseWithError(err)
+		cancel(err)
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *noteStream) run(req *http.Request, pr *io.PipeReader, accepted chan<- error) {
+	defer close(s.done)
+
+	//nolint: exhaustruct
+	httpClient := &http.Client{}
+	defer httpClient.CloseIdleConnections()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		accepted <- err
+		pr.CloseWithError(err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%w: HTTP %s", ErrStreamClosed, resp.Status)
+		accepted <- err
+		pr.CloseWithError(err)
+		return
+	}
+
+	accepted <- nil
+
+	// The musician answers when it stops reading the stream
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	// Unblock any writer if the musician ended the stream before us
+	pr.CloseWithError(ErrStreamClosed)
+}
+
+// Send writes the notes, the stream is closed when the musician does not
+// read them in time
+func (s *noteStream) Send(notes []data.Note) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Closing the reading end unblocks the write, the request is aborted
+	// as the musician may keep it open
+	timeout := time.AfterFunc(streamWriteTimeout, func() {
+		s.pr.CloseWithError(ErrStreamTimeout)
+		s.cancel(ErrStreamTimeout)
+	})
+	err := s.enc.Encode(notes)
+	if !timeout.Stop() {
+		return ErrStreamTimeout
+	}
+	return err
+}
+
+func (s *noteStream) Close() error {
+	s.mu.Lock()
+	err := s.pw.Close()
+	s.mu.Unlock()
+
+	<-s.done
+	s.cancel(ErrStreamClosed)
+	return err
+}
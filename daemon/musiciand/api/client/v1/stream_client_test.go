@@ -0,0 +1,46 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStreamTimeout checks that a musician accepting a stream and not
+// reading it fails the writes rather than blocking them
+func TestStreamTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rc := http.NewResponseController(w)
+		assert.NoError(t, rc.EnableFullDuplex())
+		w.WriteHeader(http.StatusOK)
+		assert.NoError(t, rc.Flush())
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cli, err := New(server.URL)
+	require.NoError(t, err)
+	stream, err := cli.OpenStream()
+	require.NoError(t, err)
+
+	// The writes go on until the connection buffers are full
+	notes := make([]data.Note, 10000)
+	for i := range notes {
+		notes[i] = data.Note{Seq: uint64(i), At: time.Now(), Message: []byte{0x90, 60, 100}}
+	}
+	for range 1000 {
+		if err = stream.Send(notes); err != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, err, ErrStreamTimeout)
+	assert.Error(t, stream.Send(notes[:1]))
+	assert.NoError(t, stream.Close())
+}
@@ -1,13 +1,103 @@
 package api
 
-// func MarkNodeDownParamsDtoToMarkNodeDownParams(params model.MarkNodeDownParams) (data.MarkNodeDownParams, error) {
-// 	nodeId, err := data.IdFromHex(params.NodeId)
-// 	if err != nil {
-// 		return data.MarkNodeDownParams{}, err
-// 	}
-
-// 	return data.MarkNodeDownParams{
-// 		NodeId:      nodeId,
-// 		NodeAddress: params.NodeAddress,
-// 	}, nil
-// }
+import (
+	"encoding/base64"
+	"time"
+
+	"crossjoin.com/gorxestra/daemon/musiciand/api/server/v1/openapi/generated/model"
+	"crossjoin.com/gorxestra/data"
+)
+
+func MusicNoteDtoToNote(dto model.MusicNote) (data.Note, error) {
+	bs, err := base64.RawStdEncoding.DecodeString(dto.Note)
+	if err != nil {
+		return data.Note{}, err
+	}
+
+	note := data.Note{
+		Message: bs,
+	}
+
+	if dto.At != nil {
+		note.At = *dto.At
+	}
+
+	if dto.Seq != nil {
+		note.Seq = *dto.Seq
+	}
+
+	return note, nil
+}
+
+func NoteToMusicNoteDto(note data.Note) model.MusicNote {
+	dto := model.MusicNote{
+		Note: base64.RawStdEncoding.EncodeToString(note.Message),
+		Seq:  &note.Seq,
+	}
+
+	if !note.At.IsZero() {
+		dto.At = &note.At
+	}
+
+	return dto
+}
+
+func ClockSampleToDto(s data.ClockSample) model.ClockSample {
+	return model.ClockSample{
+		Originate: s.Originate,
+		Receive:   s.Receive,
+		Transmit:  s.Transmit,
+	}
+}
+
+func ClockSampleDtoToClockSample(dto model.ClockSample) data.ClockSample {
+	return data.ClockSample{
+		Originate: dto.Originate,
+		Receive:   dto.Receive,
+		Transmit:  dto.Transmit,
+	}
+}
+
+func CapabilitiesToDto(c data.Capabilities) model.Capabilities {
+	dto := model.Capabilities{
+		Channels:   make([]int, len(c.Channels)),
+		Programs:   make([]int, len(c.Programs)),
+		Polyphony:  c.Polyphony,
+		Percussion: c.Percussion,
+		LatencyMs:  c.Latency.Milliseconds(),
+		Labels:     append([]string{}, c.Labels...),
+	}
+	for i, channel := range c.Channels {
+		dto.Channels[i] = int(channel)
+	}
+	for i, program := range c.Programs {
+		dto.Programs[i] = int(program)
+	}
+	return dto
+}
+
+func CapabilitiesDtoToCapabilities(dto model.Capabilities) (data.Capabilities, error) {
+	if dto.Polyphony < 0 || dto.LatencyMs < 0 {
+		return data.Capabilities{}, data.ErrInvalidCapabilities
+	}
+
+	c := data.Capabilities{
+		Polyphony:  dto.Polyphony,
+		Percussion: dto.Percussion,
+		Latency:    time.Duration(dto.LatencyMs) * time.Millisecond,
+		Labels:     dto.Labels,
+	}
+	for _, channel := range dto.Channels {
+		if channel < 0 || channel > 15 {
+			return data.Capabilities{}, data.ErrInvalidCapabilities
+		}
+		c.Channels = append(c.Channels, uint8(channel))
+	}
+	for _, program := range dto.Programs {
+		if program < 0 || program > 127 {
+			return data.Capabilities{}, data.ErrInvalidCapabilities
+		}
+		c.Programs = append(c.Programs, uint8(program))
+	}
+	return c, nil
+}
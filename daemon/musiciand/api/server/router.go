@@ -34,6 +34,8 @@ const (
 	TokenHeader = "X-API-Token" //nolint: all
 	// MaxRequestBodyBytes is the maximum request body size that we allow in our APIs.
 	MaxRequestBodyBytes = "10MB"
+	// StreamPath is the path of the note stream
+	StreamPath = "/v1/stream"
 )
 
 // NewHttpRouter builds and returns a new router with our REST handlers registered.
@@ -78,11 +80,15 @@ func NewHttpRouter(
 
 	// register v1 handlers
 	v1 := v1.Handlers{
-		Node: node,
-		Log:  logger,
+		Node:     node,
+		Log:      logger,
+		Shutdown: shutdown,
 	}
 
 	server.RegisterHandlers(e, &v1, publicMiddleware...)
 
+	// The note stream is long lived so it is not bound by the body limit
+	e.POST(StreamPath, v1.Stream)
+
 	return e
 }
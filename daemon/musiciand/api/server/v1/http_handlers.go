@@ -1,7 +1,7 @@
 package v1
 
 import (
-	"encoding/base64"
+	"net/http"
 
 	"crossjoin.com/gorxestra/daemon/musiciand/api"
 	"crossjoin.com/gorxestra/daemon/musiciand/api/server/v1/openapi/generated/model"
@@ -11,8 +11,9 @@ import (
 
 // Handlers is an implementation to the V1 route handler interface
 type Handlers struct {
-	Node api.NodeInterface
-	Log  logging.Logger
+	Node     api.NodeInterface
+	Log      logging.Logger
+	Shutdown <-chan struct{}
 }
 
 func (h *Handlers) Play(ctx echo.Context) error {
@@ -20,11 +21,37 @@ func (h *Handlers) Play(ctx echo.Context) error {
 
 	ctx.Bind(&a)
 	h.Log.With("note", a.Note).Info("received musical note")
-	bs, err := base64.RawStdEncoding.DecodeString(a.Note)
+	note, err := api.MusicNoteDtoToNote(a)
 	if err != nil {
 		h.Log.With("error", err).Error("decoding note")
 		return err
 	}
 
-	return h.Node.Play(bs)
+	return h.Node.Play(note)
+}
+
+// Clock implements server.ServerInterface.
+func (h *Handlers) Clock(ctx echo.Context) error {
+	var req model.ClockRequest
+	err := ctx.Bind(&req)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, err)
+	}
+
+	sample, err := h.Node.Clock(req.Originate)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.ClockSampleToDto(sample))
+}
+
+// Capabilities implements server.ServerInterface.
+func (h *Handlers) Capabilities(ctx echo.Context) error {
+	c, err := h.Node.Capabilities()
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, api.CapabilitiesToDto(c))
 }
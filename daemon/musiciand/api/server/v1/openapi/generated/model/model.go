@@ -3,6 +3,10 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package model
 
+import (
+	"time"
+)
+
 // BuildVersion defines model for BuildVersion.
 type BuildVersion struct {
 	// Branch Branch the build is based on
@@ -24,6 +28,45 @@ type BuildVersion struct {
 	Minor int `json:"minor"`
 }
 
+// Capabilities defines model for Capabilities.
+type Capabilities struct {
+	// Channels MIDI channels played, from 0 to 15, all when empty
+	Channels []int `json:"channels"`
+
+	// Labels free-form tags describing the musician
+	Labels []string `json:"labels"`
+
+	// LatencyMs time in milliseconds between a note being due and its sound coming out
+	LatencyMs int64 `json:"latencyMs"`
+
+	// Percussion the musician plays the drums of channel 9
+	Percussion bool `json:"percussion"`
+
+	// Polyphony notes sounding at once, unlimited when 0
+	Polyphony int `json:"polyphony"`
+
+	// Programs General MIDI programs played, from 0 to 127, any when empty
+	Programs []int `json:"programs"`
+}
+
+// ClockRequest defines model for ClockRequest.
+type ClockRequest struct {
+	// Originate requester time when the request was sent
+	Originate time.Time `json:"originate"`
+}
+
+// ClockSample defines model for ClockSample.
+type ClockSample struct {
+	// Originate requester time when the request was sent
+	Originate time.Time `json:"originate"`
+
+	// Receive node time when the request arrived
+	Receive time.Time `json:"receive"`
+
+	// Transmit node time when the answer was sent
+	Transmit time.Time `json:"transmit"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Error Error message
@@ -45,9 +88,18 @@ type InfoResponse struct {
 
 // MusicNote defines model for MusicNote.
 type MusicNote struct {
+	// At wall-clock time at which the note must sound, played right away when missing
+	At *time.Time `json:"at,omitempty"`
+
 	// Note base64 encoded note
 	Note string `json:"note"`
+
+	// Seq sequence number ordering notes scheduled at the same time
+	Seq *uint64 `json:"seq,omitempty"`
 }
 
+// ClockJSONRequestBody defines body for Clock for application/json ContentType.
+type ClockJSONRequestBody = ClockRequest
+
 // PlayJSONRequestBody defines body for Play for application/json ContentType.
 type PlayJSONRequestBody = MusicNote
@@ -18,6 +18,12 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Capabilities of the musician
+	// (GET /v1/capabilities)
+	Capabilities(ctx echo.Context) error
+	// Clock synchronization
+	// (POST /v1/clock)
+	Clock(ctx echo.Context) error
 	// Play a note
 	// (POST /v1/play)
 	Play(ctx echo.Context) error
@@ -28,6 +34,24 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// Capabilities converts echo context to params.
+func (w *ServerInterfaceWrapper) Capabilities(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Capabilities(ctx)
+	return err
+}
+
+// Clock converts echo context to params.
+func (w *ServerInterfaceWrapper) Clock(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Clock(ctx)
+	return err
+}
+
 // Play converts echo context to params.
 func (w *ServerInterfaceWrapper) Play(ctx echo.Context) error {
 	var err error
@@ -65,24 +89,37 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/v1/capabilities", wrapper.Capabilities, m...)
+	router.POST(baseURL+"/v1/clock", wrapper.Clock, m...)
 	router.POST(baseURL+"/v1/play", wrapper.Play, m...)
 
 } // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/6xVUW8bNwz+KwQ7YBtws50u2IPfmmFb87AhaIG9LEWh6Gif2jtJoygnRuH/PlAnxzvb",
-	"gAs0bxKPIr+P32f6C9owxODJS8LlF0y2o8GU4012ffs3cXLB6z1yiMTiqHx9YONtp6eWkmUXpaThTYmD",
-	"dAQPWgBcggeTqIXgsUHZRsIlJmHn17hrsCR99Hl4ID6t9kfgJ0rC5vsEg/OBYTMCgvriuaLzQmtiLWk7",
-	"4z31347NhmFw8rEz6QzRtyZ1EFYwJn190cF8CpeYasrXMC0jeZGp7Rpk+jc7phaX/1SQ+wZHKk0H0+yt",
-	"cBj8h12DvzGP0Ka2oX14irhkw0ApmTWdTu0I3VhEu9z6VTjjTUWrh++YVrjEV/ODyefV4fOJvXcN1hGl",
-	"U3BMktknMNC7JKp5yjEGFmohcpBgQ7+fcIIfwM1oBpurBjavgcTO4Eds0AkNpfaJIWrAMJvtCdVnVFWD",
-	"Z9bvKMXgE51hH9rtJfJlbse9ykOt/2dOzv4V5ExxX6PTCanff7kG8ja01EJJuiRiSfqg0UQ2s5PtewVX",
-	"OZBh4jdZusPt98CDEVzinUkkAZtxWWmLMeHQshOJuNParhpkivd9JOtWzhq9q6D66/01+DZbCQxvRSK8",
-	"ubvVgk56KqsjfKb6ZfIan52DS7yaLWYL1TRE8iY6XOLPJdRgNNIVavOOTF947RqcV3x6HEjY2VRvTKbI",
-	"qOckhiXH/e3RrNdlWeptczWPvSmCx5DklOtdb7Zgiib3ilbVLMhv2/oVR2EoyU21jg1eyJdiJsa+Up1/",
-	"SuM/weiiSx47uKgoMUX1bmwIpeP/jSGcqThltHcZ2evF4giV0JMob3eE59hyJ331ITw66eD+/tVPuscU",
-	"MxjfwmfaLjemzwS985RUxuvF9elAq07wSEy6/aPrddlnwdJtZXIvLzbEcY2eIZI9PUWyuoOo5jSY8jAY",
-	"3k5VVxebdSrb5Ep/cbvdfwEAAP//lPD2aPYHAAA=",
+	"H4sIAAAAAAAC/8xXS2/cRgz+K8SkQFtAfqVuiu4tTtvEhwRGErSHOgi4Enc1yTyUGWrXarD/veBotE8Z",
+	"dlsHzWklzZD8SH587GdVett4R46jmnxWsazJYnq8aLWpfqcQtXfy3gTfUGBN6XQa0JW1PFUUy6AbTtfU",
+	"RfoOXBNMRQHoCFOMVIF3qlDcNaQmKnLQbq5WhUqX3rvWTikcanvuww1FDvhtBKudD7DoAUGWWGvUjmlO",
+	"QVSWNTpH5r9jK721mt/XGEccfYGxBj+D/tL9lVr84O/yFD/cz9MUkgeJ2qpQgT61OlClJn9mkIOBvSzt",
+	"BqYYqLAJ/LtVoZ5hg1Nt9MCXXfbkm/EQ+8vLXy5hOIbGYEdVAbPgLZwCezj7sQA0BpY1OSDbcKcKpZls",
+	"0nUYovwFQ8BO3g1OR+3OAtHRzAcLjPMI/eFUu3nKrG2jLjW6EVubzB6aYnJl93LEGmtLoB1YbYyOVHpX",
+	"RZgSL4kcIDjPBFMS61VLgK4CzRGib10lhJMD37IqlCBG7l1+cj5KkoZC2cahivdwbPmWgh2Tu1VobUzk",
+	"7hMBP280T703hC5p9qZrau+6Q8XiQQYsYJHBu5IKaJ3RVjNVfQJPxxEHPw9oR+L2nBwFNJBYMlwbY8nj",
+	"nwpA1/1LmuxVw5qtW9C2vd+J8Xbe13RLFWF8+fE1fWop8mFF+KDn2iHTodOhl6EAiTbJJclS/g5LjBDJ",
+	"7dChQqYjuX7Ygvac2xheg3yDtjH0f2IUiCXpBY0xq6JbjGAIekHVvW1wQBet5nsZQReXFB4s2BsPt3BI",
+	"Bn4NwYfD2NPweRdoug2WYsT53eZ7JWLl0s38yFCXNi8P3wSaqYl6dLLZDk7yanCysxesCpVnSxwjBbfB",
+	"RUAwOrL0k9g2jQ9S/k3w7EtvhtEU4TvQx3QMi7MCFo+BuDyG7/9Bv91zdY0qD6+1168pNt7FEXpPfdXd",
+	"5XyK276tJCj6X0orfeV5RDmOsGyJxhyVUnE915BhWeu8oaQZYNvIfRctcpeDoOc1Ay4xNzerY5SA3Jf0",
+	"zo/Vr2wsT86BXOkrqpLxMeFInw5lo5SfKylvF+BDRSIAeQiUNVWtoUr8E88i2r64tjG3tw2wvVgnZO9W",
+	"CUvZBs3dG0lNziBhoPC05Xrz9ttg4QojsVdFv+OmUZYubGzWzI1aiW6dy2PX0TcNlXqmS5R3obN488y7",
+	"qi3ZB3jB3MDTq0tRqFkaqLoI/iPlkx1pta4bNVFnx6fHpxJe35DDRquJ+iF9KlSDXCfXTmpCk/xaFeok",
+	"45NHSxx0GfNbIKy6/BwZA7fN8LbE+ZxCflucnZR7G9qcRij6R52Ttt4SdAScGpIpK4wsACNgtRCmy9LL",
+	"Pl0vh6hcu8TSQHMdOdHi+Fq8l9pIkbis1GR3W5SE9zWacD0+PZWf0jsmlyBi05gcx5MPsd9r+gK9q3x3",
+	"7KRM77q7ey6nM2wNP5j9vrmPGG4d3TRUSmekfKdQsbUWQ7eHayDe1k4qK2tqemd9aaT0Sl9JfcjHkcS+",
+	"ensFkTtDkC4C3ciWM6djeJuaTzVMvAhLzX1LSk2Ka7p2exM3bajbN7IsGJpxkZuaIY470hSAImuL3Mv4",
+	"2SwSr9fgqee6BxfX+oO0wmvHQTdDGNbAx2gl0qoYtpOL3OAfhkrb69xIRvMRJKPbPYxDS6svyfGtFW6M",
+	"4indMZ9/RRTvcXWurIN3+q+hTY5xWxrP7dS+MtjlP1EjnJDTL0SJzfh/eD4w3bD4rffw7C98B3ZFsC/h",
+	"6+tHR/LPXTCnivpI3WSBpiUw2vUN7/z0/DCgecTAkoJ0dttomea+5a+JP1tZv4U1kQOhlQG4Wv09AAe5",
+	"5Dr4EgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"crossjoin.com/gorxestra/daemon/musiciand/api/stream"
+	"github.com/labstack/echo/v4"
+)
+
+// Stream plays the notes of a long lived note stream as they arrive.
+// It is registered outside of the generated routes so that the request is
+// not bound by the body limit and the server timeouts.
+func (h *Handlers) Stream(ctx echo.Context) error {
+	rc := http.NewResponseController(ctx.Response())
+	if err := rc.EnableFullDuplex(); err != nil {
+		h.Log.With("error", err).Warn("enabling full duplex on note stream")
+	}
+	if err := rc.SetReadDeadline(time.Time{}); err != nil {
+		h.Log.With("error", err).Warn("clearing stream read deadline")
+	}
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.Log.With("error", err).Warn("clearing stream write deadline")
+	}
+
+	// Unblock the reader when the server shuts down
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-h.Shutdown:
+			_ = rc.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	// Accept the stream before reading it so the conductor knows it can
+	// start sending notes. The response ends when the stream is over.
+	ctx.Response().WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return err
+	}
+
+	h.Log.Info("note stream opened")
+
+	dec := stream.NewDecoder(ctx.Request().Body)
+	for {
+		notes, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			h.Log.Info("note stream closed")
+			return nil
+		}
+		if err != nil {
+			h.Log.With("error", err).Error("decoding note stream")
+			return nil
+		}
+
+		for i := range notes {
+			if err := h.Node.Play(notes[i]); err != nil {
+				h.Log.With("error", err).Error("playing streamed note")
+			}
+		}
+	}
+}
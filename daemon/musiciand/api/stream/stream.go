@@ -0,0 +1,111 @@
+// Package stream implements the framing of the note stream sent by the
+// conductor to the musicians over a long lived HTTP request.
+package stream
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/util/http/client/protocol"
+)
+
+// ContentType is the content type of a note stream request body
+const ContentType protocol.ContentType = "application/vnd.gorxestra.notes+msgpack"
+
+// frame is a batch of notes written as a single msgpack value
+type frame struct {
+	Notes []note `codec:"n"`
+}
+
+type note struct {
+	Seq     uint64 `codec:"s"`
+	At      int64  `codec:"t"`
+	Message []byte `codec:"m"`
+}
+
+// Encoder writes batches of notes to a stream
+type Encoder struct {
+	w io.Writer
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes a batch of notes. Each batch is written with a single
+// call to the underlying writer.
+func (e *Encoder) Encode(notes []data.Note) error {
+	f := frame{
+		Notes: make([]note, len(notes)),
+	}
+
+	for i := range notes {
+		f.Notes[i] = note{
+			Seq:     notes[i].Seq,
+			At:      notes[i].At.UnixNano(),
+			Message: notes[i].Message,
+		}
+	}
+
+	_, err := e.w.Write(protocol.EncodeReflect(f))
+	return err
+}
+
+// Decoder reads batches of notes from a stream
+type Decoder struct {
+	in  *countingReader
+	dec protocol.Decoder
+}
+
+// countingReader counts the bytes read. The codec reports a frame cut
+// short as the end of the stream, the count tells them apart.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	in := &countingReader{r: r}
+	return &Decoder{
+		in:  in,
+		dec: protocol.NewDecoder(in),
+	}
+}
+
+// Decode reads the next batch of notes. It returns io.EOF when the
+// stream ends between two batches and io.ErrUnexpectedEOF when it ends
+// within one.
+func (d *Decoder) Decode() ([]data.Note, error) {
+	var f frame
+
+	start := d.in.n
+	err := d.dec.Decode(&f)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		if d.in.n > start {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	notes := make([]data.Note, len(f.Notes))
+	for i := range f.Notes {
+		notes[i] = data.Note{
+			Seq:     f.Notes[i].Seq,
+			At:      time.Unix(0, f.Notes[i].At),
+			Message: f.Notes[i].Message,
+		}
+	}
+
+	return notes, nil
+}
@@ -0,0 +1,63 @@
+package stream
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamRoundTrip(t *testing.T) {
+	at := time.Unix(1700000000, 42)
+	batches := [][]data.Note{
+		{
+			{Seq: 1, At: at, Message: []byte{0x90, 60, 100}},
+			{Seq: 2, At: at, Message: []byte{0x90, 64, 100}},
+		},
+		{
+			{Seq: 3, At: at.Add(time.Second), Message: []byte{0x80, 60, 0}},
+		},
+	}
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	for i := range batches {
+		assert.NoError(t, enc.Encode(batches[i]))
+	}
+
+	dec := NewDecoder(&buf)
+	for i := range batches {
+		notes, err := dec.Decode()
+		assert.NoError(t, err)
+		assert.Len(t, notes, len(batches[i]))
+		for j := range notes {
+			assert.Equal(t, batches[i][j].Seq, notes[j].Seq)
+			assert.True(t, batches[i][j].At.Equal(notes[j].At))
+			assert.Equal(t, batches[i][j].Message, notes[j].Message)
+		}
+	}
+
+	_, err := dec.Decode()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestStreamTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	assert.NoError(t, enc.Encode([]data.Note{{Seq: 1, At: time.Unix(1700000000, 0), Message: []byte{0x90, 60, 100}}}))
+	frame := buf.Len()
+	assert.NoError(t, enc.Encode([]data.Note{{Seq: 2, At: time.Unix(1700000001, 0), Message: []byte{0x80, 60, 0}}}))
+
+	// A stream cut within a batch is not a clean end
+	for _, cut := range []int{frame + 1, frame + 5, buf.Len() - 1} {
+		dec := NewDecoder(bytes.NewReader(buf.Bytes()[:cut]))
+		notes, err := dec.Decode()
+		assert.NoError(t, err)
+		assert.Len(t, notes, 1)
+		_, err = dec.Decode()
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "cut at %d", cut)
+	}
+}
@@ -0,0 +1,13 @@
+package data
+
+import "time"
+
+// ClockSample is the answer of a node to a clock synchronization request
+type ClockSample struct {
+	// Originate is the requester time when the request was sent
+	Originate time.Time
+	// Receive is the node time when the request arrived
+	Receive time.Time
+	// Transmit is the node time when the answer was sent
+	Transmit time.Time
+}
@@ -1,6 +1,45 @@
 package data
 
+import "time"
+
 type Musician struct {
 	Id      ID
 	Address string
+	// Capabilities tell what the musician is able to play, nil when it
+	// did not say and plays anything
+	Capabilities *Capabilities
+}
+
+// PercussionChannel is the MIDI channel of the General MIDI drums,
+// channel 10 counting from 1
+const PercussionChannel = 9
+
+// Capabilities are what a musician advertises when registering. The
+// conductor only gives a musician the tracks it is able to play.
+type Capabilities struct {
+	// Channels are the MIDI channels the musician plays, all when empty
+	Channels []uint8
+	// Programs are the General MIDI programs the musician plays, any when
+	// empty
+	Programs []uint8
+	// Polyphony is the number of notes the musician sounds at once,
+	// unlimited when zero
+	Polyphony int
+	// Percussion tells the musician plays the drums of the percussion
+	// channel
+	Percussion bool
+	// Latency is the time between a note being due and its sound coming
+	// out of the musician
+	Latency time.Duration
+	// Labels are free-form tags describing the musician
+	Labels []string
+}
+
+// Registration is a musician in the roster of the conductor
+type Registration struct {
+	Musician     Musician
+	RegisteredAt time.Time
+	// LastSeen is the last time the musician registered or renewed its
+	// registration
+	LastSeen time.Time
 }
@@ -10,6 +10,28 @@ import (
 var (
 	ErrInternalError        = errors.New("internal error")
 	MusicAlreadyBeingPlayed = errors.New("music already being played")
+	ErrMusicianNotFound     = errors.New("musician not found")
+	ErrInvalidMusic         = errors.New("invalid music file")
+	ErrClockSkew            = errors.New("musician clock skew above threshold")
+	ErrNoMusicPlaying       = errors.New("no music being played")
+	ErrInvalidPosition      = errors.New("position out of the music")
+	ErrInvalidMusicName     = errors.New("invalid music name")
+	ErrMusicNotFound        = errors.New("music not found")
+	ErrMusicExists          = errors.New("music already exists")
+	ErrInvalidAssignment    = errors.New("invalid track assignment")
+	ErrMusicianNotCapable   = errors.New("musician not able to play the track")
+	ErrInvalidCapabilities  = errors.New("invalid musician capabilities")
+	ErrInvalidTempo         = errors.New("tempo factor out of 0.25-4")
+	ErrInvalidTranspose     = errors.New("transposition out of -24-24 semitones")
+	ErrInvalidPlayOptions   = errors.New("invalid play options")
+	ErrNotEnoughMusicians   = errors.New("not enough musicians registered")
+	ErrQueueItemNotFound    = errors.New("queue item not found")
+	ErrInvalidQueueModes    = errors.New("invalid queue modes")
+	ErrPerformanceNotFound  = errors.New("performance not found")
+	ErrSectionNotFound      = errors.New("section not found")
+	ErrInvalidSection       = errors.New("invalid section")
+	ErrChannelConflict      = errors.New("more parts than free MIDI channels")
+	ErrInvalidMix           = errors.New("invalid mix")
 )
 
 type AppError struct {
@@ -29,10 +51,142 @@ var errorMap = map[error]middlewares.AppError{
 		ErrorMessage: MusicAlreadyBeingPlayed.Error(),
 		ShowMessage:  true,
 	},
+	ErrMusicianNotFound: {
+		StatusCode:   http.StatusNotFound,
+		ErrorMessage: ErrMusicianNotFound.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidMusic: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidMusic.Error(),
+		ShowMessage:  true,
+	},
+	ErrClockSkew: {
+		StatusCode:   http.StatusPreconditionFailed,
+		ErrorMessage: ErrClockSkew.Error(),
+		ShowMessage:  true,
+	},
+	ErrNoMusicPlaying: {
+		StatusCode:   http.StatusConflict,
+		ErrorMessage: ErrNoMusicPlaying.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidPosition: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidPosition.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidMusicName: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidMusicName.Error(),
+		ShowMessage:  true,
+	},
+	ErrMusicNotFound: {
+		StatusCode:   http.StatusNotFound,
+		ErrorMessage: ErrMusicNotFound.Error(),
+		ShowMessage:  true,
+	},
+	ErrMusicExists: {
+		StatusCode:   http.StatusConflict,
+		ErrorMessage: ErrMusicExists.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidAssignment: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidAssignment.Error(),
+		ShowMessage:  true,
+	},
+	ErrMusicianNotCapable: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrMusicianNotCapable.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidCapabilities: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidCapabilities.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidTempo: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidTempo.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidTranspose: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidTranspose.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidPlayOptions: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidPlayOptions.Error(),
+		ShowMessage:  true,
+	},
+	ErrNotEnoughMusicians: {
+		StatusCode:   http.StatusPreconditionFailed,
+		ErrorMessage: ErrNotEnoughMusicians.Error(),
+		ShowMessage:  true,
+	},
+	ErrQueueItemNotFound: {
+		StatusCode:   http.StatusNotFound,
+		ErrorMessage: ErrQueueItemNotFound.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidQueueModes: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidQueueModes.Error(),
+		ShowMessage:  true,
+	},
+	ErrPerformanceNotFound: {
+		StatusCode:   http.StatusNotFound,
+		ErrorMessage: ErrPerformanceNotFound.Error(),
+		ShowMessage:  true,
+	},
+	ErrSectionNotFound: {
+		StatusCode:   http.StatusNotFound,
+		ErrorMessage: ErrSectionNotFound.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidSection: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidSection.Error(),
+		ShowMessage:  true,
+	},
+	ErrChannelConflict: {
+		StatusCode:   http.StatusConflict,
+		ErrorMessage: ErrChannelConflict.Error(),
+		ShowMessage:  true,
+	},
+	ErrInvalidMix: {
+		StatusCode:   http.StatusBadRequest,
+		ErrorMessage: ErrInvalidMix.Error(),
+		ShowMessage:  true,
+	},
 }
 
 var strErrorMapper = map[string]error{
-	ErrInternalError.Error(): ErrInternalError,
+	ErrInternalError.Error():       ErrInternalError,
+	ErrMusicianNotFound.Error():    ErrMusicianNotFound,
+	ErrInvalidMusic.Error():        ErrInvalidMusic,
+	ErrClockSkew.Error():           ErrClockSkew,
+	ErrNoMusicPlaying.Error():      ErrNoMusicPlaying,
+	ErrInvalidPosition.Error():     ErrInvalidPosition,
+	ErrInvalidMusicName.Error():    ErrInvalidMusicName,
+	ErrMusicNotFound.Error():       ErrMusicNotFound,
+	ErrMusicExists.Error():         ErrMusicExists,
+	ErrInvalidAssignment.Error():   ErrInvalidAssignment,
+	ErrMusicianNotCapable.Error():  ErrMusicianNotCapable,
+	ErrInvalidCapabilities.Error(): ErrInvalidCapabilities,
+	ErrInvalidTempo.Error():        ErrInvalidTempo,
+	ErrInvalidTranspose.Error():    ErrInvalidTranspose,
+	ErrInvalidPlayOptions.Error():  ErrInvalidPlayOptions,
+	ErrNotEnoughMusicians.Error():  ErrNotEnoughMusicians,
+	ErrQueueItemNotFound.Error():   ErrQueueItemNotFound,
+	ErrInvalidQueueModes.Error():   ErrInvalidQueueModes,
+	ErrPerformanceNotFound.Error(): ErrPerformanceNotFound,
+	ErrSectionNotFound.Error():     ErrSectionNotFound,
+	ErrInvalidSection.Error():      ErrInvalidSection,
+	ErrChannelConflict.Error():     ErrChannelConflict,
+	ErrInvalidMix.Error():          ErrInvalidMix,
 }
 
 func MiddlewareErrorMap(err error) (middlewares.AppError, bool) {
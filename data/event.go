@@ -0,0 +1,89 @@
+package data
+
+import "time"
+
+// EventType tells what happened in the conductor
+type EventType string
+
+const (
+	EventMusicianRegistered   EventType = "musician.registered"
+	EventMusicianUnregistered EventType = "musician.unregistered"
+	EventPlaybackStarted      EventType = "playback.started"
+	EventPlaybackPaused       EventType = "playback.paused"
+	EventPlaybackResumed      EventType = "playback.resumed"
+	EventPlaybackFinished     EventType = "playback.finished"
+	EventPlaybackPosition     EventType = "playback.position"
+	EventNoteError            EventType = "note.error"
+	// EventMusicianExpired tells a musician was removed because it did not
+	// renew its registration in time
+	EventMusicianExpired EventType = "musician.expired"
+	// EventPlaybackHandover tells tracks moved to another musician during
+	// the performance because their musician left or stopped answering
+	EventPlaybackHandover EventType = "playback.handover"
+	// EventDropped tells a subscriber it missed events because it did not
+	// keep up with them
+	EventDropped EventType = "events.dropped"
+)
+
+// Event is something that happened in the conductor. Data holds one of
+// the event payloads below depending on the type.
+type Event struct {
+	// Id orders the events
+	Id   uint64
+	Type EventType
+	Time time.Time
+	Data any
+}
+
+// MusicianEvent is the payload of the musician events
+type MusicianEvent struct {
+	Musician Musician
+}
+
+// PlaybackEvent is the payload of the playback events
+type PlaybackEvent struct {
+	Performance ID
+	Music       string
+	Duration    time.Duration
+	// Completed is set on a finished event when the music ended after its
+	// last note rather than being stopped
+	Completed bool
+	// Next is set on a finished event when the music cued started right
+	// after, without gap
+	Next string
+}
+
+// PositionEvent is sent when the playback enters a new bar
+type PositionEvent struct {
+	Performance ID
+	Music       string
+	// Bar is the number of the bar, starting at 1
+	Bar     int
+	Elapsed time.Duration
+}
+
+// NoteErrorEvent is sent when notes could not be delivered to a musician
+type NoteErrorEvent struct {
+	Performance ID
+	Musician    ID
+	Notes       int
+	Error       string
+}
+
+// HandoverEvent is the payload of EventPlaybackHandover
+type HandoverEvent struct {
+	Performance ID
+	Music       string
+	// From is the musician that played the tracks, nil when they had no
+	// musician left
+	From *ID
+	// To is the musician now playing the tracks, nil when no musician
+	// could take them
+	To     *ID
+	Tracks []TrackAssignment
+}
+
+// DroppedEvent is the payload of EventDropped
+type DroppedEvent struct {
+	Count uint64
+}
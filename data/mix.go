@@ -0,0 +1,102 @@
+package data
+
+// MaxGain is the largest gain of a mix
+const MaxGain = 2
+
+// Mix is how a musician or a track sounds in the ensemble
+type Mix struct {
+	// Gain scales the velocity of the notes and the volume of the
+	// channels, 1 leaves them as written
+	Gain float64
+	// Pan is the pan position of the channels from 0 left to 127 right,
+	// nil leaves the pan of the music
+	Pan *uint8
+	// Mute silences the notes
+	Mute bool
+	// Solo silences the notes of those not soloed
+	Solo bool
+}
+
+// DefaultMix sounds as written
+var DefaultMix = Mix{Gain: 1}
+
+// IsDefault tells whether the mix sounds as written
+func (m Mix) IsDefault() bool {
+	return m.Gain == 1 && m.Pan == nil && !m.Mute && !m.Solo
+}
+
+// MixTarget is what a mix applies to, either a musician or a track
+type MixTarget struct {
+	Musician *ID
+	Track    *int
+}
+
+// Validate checks the target names a musician or a track
+func (t MixTarget) Validate() error {
+	if (t.Musician == nil) == (t.Track == nil) || (t.Track != nil && *t.Track < 0) {
+		return ErrInvalidMix
+	}
+	return nil
+}
+
+// MixChange changes a mix, the nil fields leave it as it is
+type MixChange struct {
+	// Reset brings the mix back to the default before the other changes
+	Reset bool
+	Gain  *float64
+	Pan   *uint8
+	Mute  *bool
+	Solo  *bool
+}
+
+// Validate checks the change is within bounds
+func (c MixChange) Validate() error {
+	if c.Gain != nil && (*c.Gain < 0 || *c.Gain > MaxGain) {
+		return ErrInvalidMix
+	}
+	if c.Pan != nil && *c.Pan > 127 {
+		return ErrInvalidMix
+	}
+	return nil
+}
+
+// Apply returns the mix changed
+func (c MixChange) Apply(m Mix) Mix {
+	if c.Reset {
+		m = DefaultMix
+	}
+	if c.Gain != nil {
+		m.Gain = *c.Gain
+	}
+	if c.Pan != nil {
+		pan := *c.Pan
+		m.Pan = &pan
+	}
+	if c.Mute != nil {
+		m.Mute = *c.Mute
+	}
+	if c.Solo != nil {
+		m.Solo = *c.Solo
+	}
+	return m
+}
+
+// MusicianMix is the mix of the notes sent to a musician
+type MusicianMix struct {
+	Musician ID
+	Mix      Mix
+}
+
+// TrackMix is the mix of the notes of a track
+type TrackMix struct {
+	Track int
+	Mix   Mix
+}
+
+// Mixer is the mix of a performance. The musicians and tracks absent
+// sound as written, a note of a track sent to a musician takes both their
+// mixes.
+type Mixer struct {
+	Musicians []MusicianMix
+	Tracks    []TrackMix
+}
@@ -0,0 +1,20 @@
+package data
+
+import "time"
+
+// MusicInfo describes a music of the library
+type MusicInfo struct {
+	// Name is the file name of the music in the library
+	Name string
+	// Size is the size of the file in bytes
+	Size int64
+	// Format is the SMF format: 0 single track, 1 synchronous tracks,
+	// 2 independent tracks
+	Format uint16
+	Tracks int
+	// Duration is the time of the end of the music
+	Duration time.Duration
+	// Tempo is the tempo at the start of the music in BPM
+	Tempo      float64
+	ModifiedAt time.Time
+}
@@ -0,0 +1,13 @@
+package data
+
+import "time"
+
+// Note is a MIDI message sent by the conductor to a musician
+type Note struct {
+	// Seq orders the notes sent to a musician
+	Seq uint64
+	// At is the time the note is meant to sound
+	At time.Time
+	// Message is the raw MIDI message
+	Message []byte
+}
@@ -0,0 +1,180 @@
+package data
+
+import "time"
+
+// PlaybackState is the state of the music playback
+type PlaybackState string
+
+const (
+	// PlaybackIdle is the state before any music was played
+	PlaybackIdle PlaybackState = "idle"
+	// PlaybackPlaying is the state while a music is being played
+	PlaybackPlaying PlaybackState = "playing"
+	// PlaybackPaused is the state while the music being played is paused
+	PlaybackPaused PlaybackState = "paused"
+	// PlaybackStopped is the state once the last music ended
+	PlaybackStopped PlaybackState = "stopped"
+)
+
+// TrackAssignment tells which musician plays a track
+type TrackAssignment struct {
+	Track int
+	// Channel is set when the musician only plays the notes of one MIDI
+	// channel of the track
+	Channel *uint8
+	// Musician is nil when no musician plays the track
+	Musician *ID
+}
+
+// PlayPlan is how the tracks of a music are distributed among the
+// musicians
+type PlayPlan struct {
+	// Performance is the id of the performance playing the music, zero
+	// for a dry run
+	Performance ID
+	// Tracks are the assignments of the tracks with notes. A track split
+	// by MIDI channel has an assignment per channel.
+	Tracks []TrackAssignment
+	// Skipped are the tracks without notes, such as the tempo track
+	Skipped []int
+	// Excluded are the tracks with notes left out by the play options
+	Excluded []int
+	// Start and End bound the part of the music played
+	Start time.Duration
+	End   time.Duration
+	// Schedule is what the performance asks of each musician, only set
+	// for a dry run
+	Schedule *Schedule
+}
+
+// Position is a point of a music, either a time from its start or a beat
+// of a bar
+type Position struct {
+	// Time is the time from the start of the music, used when Bar is zero
+	Time time.Duration
+	// Bar is the bar of the position, counting from 1
+	Bar int
+	// Beat is the beat of the position in its bar, counting from 1
+	Beat int
+}
+
+// PlayOptions tell how to play a music
+type PlayOptions struct {
+	// Mapping is the distribution of the tracks. When empty the tracks are
+	// distributed among the musicians registered.
+	Mapping []TrackAssignment
+	// Section is the name of the section playing the music, the whole
+	// orchestra when empty
+	Section string
+	// Interpretation is how the music is played at start
+	Interpretation Interpretation
+	// Start is where the music starts, its beginning when nil
+	Start *Position
+	// End is where the music ends, its end when nil
+	End *Position
+	// Tracks are the only tracks played when not empty
+	Tracks []int
+	// Exclude are tracks not played
+	Exclude []int
+	// Loops is the number of times the music is played from start to end
+	Loops int
+	// MinMusicians is the number of musicians registered the music waits
+	// for before starting
+	MinMusicians int
+	// DryRun plans the music without playing it
+	DryRun bool
+}
+
+// DefaultPlayOptions play the whole music once, as written
+var DefaultPlayOptions = PlayOptions{
+	Interpretation: DefaultInterpretation,
+	Loops:          1,
+}
+
+// Validate checks the options not depending on the music
+func (o PlayOptions) Validate() error {
+	if err := o.Interpretation.Validate(); err != nil {
+		return err
+	}
+	if o.Loops < 1 || o.MinMusicians < 0 {
+		return ErrInvalidPlayOptions
+	}
+	for _, p := range []*Position{o.Start, o.End} {
+		if p == nil {
+			continue
+		}
+		if p.Time < 0 || p.Bar < 0 || (p.Bar > 0 && p.Beat < 1) {
+			return ErrInvalidPosition
+		}
+	}
+	return nil
+}
+
+// Bounds of the interpretation of a music
+const (
+	MinTempo     = 0.25
+	MaxTempo     = 4
+	MaxTranspose = 24
+)
+
+// Interpretation is how a music is played, it can change during the
+// performance
+type Interpretation struct {
+	// Tempo is the factor applied to the tempo of the music, 0.5 plays
+	// it twice slower
+	Tempo float64
+	// Transpose is the number of semitones the notes are shifted by, the
+	// percussion channel aside
+	Transpose int
+}
+
+// DefaultInterpretation plays the music as written
+var DefaultInterpretation = Interpretation{Tempo: 1}
+
+// ValidTempo tells whether the tempo factor is within bounds
+func ValidTempo(tempo float64) bool {
+	return tempo >= MinTempo && tempo <= MaxTempo
+}
+
+// ValidTranspose tells whether the transposition is within bounds
+func ValidTranspose(semitones int) bool {
+	return semitones >= -MaxTranspose && semitones <= MaxTranspose
+}
+
+// Validate checks the interpretation is within bounds
+func (i Interpretation) Validate() error {
+	if !ValidTempo(i.Tempo) {
+		return ErrInvalidTempo
+	}
+	if !ValidTranspose(i.Transpose) {
+		return ErrInvalidTranspose
+	}
+	return nil
+}
+
+// PlaybackStatus describes the music being played, or the last one
+// played
+type PlaybackStatus struct {
+	// Performance is the id of the performance, zero when idle
+	Performance ID
+	// Section is the section playing the music, empty for the whole
+	// orchestra
+	Section string
+	// Music is the name of the music
+	Music string
+	State PlaybackState
+	// Completed is set when the music ended after its last note rather
+	// than being stopped
+	Completed bool
+	Elapsed   time.Duration
+	Duration  time.Duration
+	Tracks    []TrackAssignment
+	// DroppedNotes counts the notes of tracks without musician
+	DroppedNotes uint64
+	// FailedNotes counts the notes that could not be sent to a musician
+	FailedNotes uint64
+	// Interpretation is how the music is being played
+	Interpretation Interpretation
+	// Mixer is the mix of the musicians and tracks
+	Mixer Mixer
+}
@@ -0,0 +1,51 @@
+package data
+
+// RepeatMode tells what the queue does with the items played
+type RepeatMode string
+
+const (
+	// RepeatOff drops the items once played
+	RepeatOff RepeatMode = "off"
+	// RepeatOne plays the current item again until it is skipped
+	RepeatOne RepeatMode = "one"
+	// RepeatAll puts the items played back at the end of the queue
+	RepeatAll RepeatMode = "all"
+)
+
+// Valid tells whether the repeat mode is known
+func (m RepeatMode) Valid() bool {
+	return m == RepeatOff || m == RepeatOne || m == RepeatAll
+}
+
+// QueueItem is a music waiting in the queue with the options it is
+// played with
+type QueueItem struct {
+	Id      ID
+	Music   string
+	Options PlayOptions
+}
+
+// QueueModes tell how the queue moves from an item to the next
+type QueueModes struct {
+	Repeat RepeatMode
+	// Shuffle plays the items in a random order
+	Shuffle bool
+	// Gapless starts the next item at the end of the last note of the
+	// current one, without waiting for the musicians
+	Gapless bool
+}
+
+// DefaultQueueModes play the items once, in order
+var DefaultQueueModes = QueueModes{Repeat: RepeatOff}
+
+// Queue is the musics the conductor plays one after the other
+type Queue struct {
+	// Current is the item being played, nil when the queue is not playing
+	Current *QueueItem
+	// Items are the items to play, in order
+	Items []QueueItem
+	Modes QueueModes
+	// Held is set when the queue waits to be started again, after the
+	// music was stopped or the conductor restarted
+	Held bool
+}
@@ -0,0 +1,43 @@
+package data
+
+import "time"
+
+// Load is what a part of a performance asks of the one playing it
+type Load struct {
+	// Events counts the messages sent, including the ends of the notes
+	Events int
+	// Notes counts the notes started
+	Notes int
+	// PeakRate is the largest number of notes started within a second
+	PeakRate int
+	// Polyphony is the largest number of notes sounding at once
+	Polyphony int
+	// First and Last are the times of the first and last messages from
+	// the start of the performance
+	First time.Duration
+	Last  time.Duration
+}
+
+// MusicianSchedule is what a musician is asked to play
+type MusicianSchedule struct {
+	Musician ID
+	Load     Load
+}
+
+// TrackSchedule is what a track nobody plays would ask
+type TrackSchedule struct {
+	Track int
+	Load  Load
+}
+
+// Schedule is what a performance asks of each musician, as simulated by
+// a dry run
+type Schedule struct {
+	// Musicians are the musicians available, those without part having an
+	// empty load
+	Musicians []MusicianSchedule
+	// Unassigned are the tracks with notes played by nobody
+	Unassigned []TrackSchedule
+	// Duration is the time the performance lasts, with its loops
+	Duration time.Duration
+}
@@ -0,0 +1,46 @@
+package data
+
+import (
+	"regexp"
+	"slices"
+)
+
+// sectionName is the pattern of the names of the sections
+var sectionName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Section is a named group of musicians. Each section plays its own
+// performances, at the same time as the other sections.
+type Section struct {
+	Name string
+	// Labels select the musicians having any of them
+	Labels []string
+	// Musicians select musicians by their id
+	Musicians []ID
+}
+
+// Validate checks the section has a name and selects musicians
+func (s Section) Validate() error {
+	if !sectionName.MatchString(s.Name) {
+		return ErrInvalidSection
+	}
+	if len(s.Labels) == 0 && len(s.Musicians) == 0 {
+		return ErrInvalidSection
+	}
+	return nil
+}
+
+// Includes tells whether the musician belongs to the section
+func (s Section) Includes(m Musician) bool {
+	if slices.Contains(s.Musicians, m.Id) {
+		return true
+	}
+	if m.Capabilities == nil {
+		return false
+	}
+	for _, label := range m.Capabilities.Labels {
+		if slices.Contains(s.Labels, label) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package baton
 
 import (
 	"io"
+	"time"
 
 	"crossjoin.com/gorxestra/data"
 )
@@ -9,5 +10,59 @@ import (
 type Baton interface {
 	RegisterMusician(m data.Musician) error
 	UnregisterMusician(id data.ID) error
-	Play(r io.Reader) error
+	// Heartbeat renews the registration of a musician
+	Heartbeat(id data.ID) error
+	// Expire removes the musicians not seen since the deadline and
+	// returns them
+	Expire(deadline time.Time) []data.Musician
+	// Clock is the time the baton schedules on, the registrations expire
+	// on it
+	Clock() Clock
+	// Roster returns the registered musicians
+	Roster() []data.Registration
+	// Restore registers again a musician of a previous run, keeping its
+	// registration time
+	Restore(r data.Registration) error
+	// Play starts the music read from r as the options say. The tracks
+	// are played as the mapping of the options says, or as planned from
+	// the musicians registered when the mapping is empty.
+	Play(music string, r io.Reader, opts data.PlayOptions) (data.PlayPlan, error)
+	// Cue reads the music started right after the performance ends,
+	// without gap. It replaces the music cued before and is dropped when
+	// the performance is stopped.
+	Cue(id data.ID, music string, r io.Reader, opts data.PlayOptions) error
+	// Uncue forgets the music cued after the performance
+	Uncue(id data.ID)
+
+	// The transport methods address a performance by its id, the zero id
+	// addressing the performance started last
+	Stop(id data.ID) error
+	Pause(id data.ID) error
+	Resume(id data.ID) error
+	Seek(id data.ID, pos time.Duration) error
+	// SetTempo changes the tempo factor of the performance
+	SetTempo(id data.ID, factor float64) error
+	// Transpose changes the number of semitones the notes of the
+	// performance are shifted by
+	Transpose(id data.ID, semitones int) error
+	// Mix changes the mix of a musician or a track of the performance and
+	// returns the mixer
+	Mix(id data.ID, target data.MixTarget, change data.MixChange) (data.Mixer, error)
+	// Status reports the performance being played or ended lately. The
+	// zero id reports the performance started last.
+	Status(id data.ID) (data.PlaybackStatus, error)
+	// Performances returns the status of the performances being played
+	Performances() []data.PlaybackStatus
+
+	// Panic ends the notes sounding on every musician registered and
+	// turns all notes and sound off on all their channels
+	Panic()
+	// Close stops the performances and silences the musicians before the
+	// conductor shuts down
+	Close()
+
+	// SetSection defines a section, replacing the one of the same name
+	SetSection(s data.Section) error
+	RemoveSection(name string) error
+	Sections() []data.Section
 }
@@ -0,0 +1,117 @@
+package baton
+
+import (
+	"sort"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+// positionPollInterval bounds the time the position ticker sleeps, so that
+// seeks and pauses are reflected quickly
+const positionPollInterval = 100 * time.Millisecond
+
+// meter is a time signature change
+type meter struct {
+	tick  int64
+	num   uint8
+	denom uint8
+}
+
+// barTimes returns the start time of every bar of the file before the end
+// tick, and of every beat of the bars, following its time signature and
+// tempo changes
+func barTimes(file *smf.SMF, meters []meter, end int64) ([]time.Duration, [][]time.Duration) {
+	mt, ok := file.TimeFormat.(smf.MetricTicks)
+	if !ok {
+		return nil, nil
+	}
+
+	sort.SliceStable(meters, func(i, j int) bool {
+		return meters[i].tick < meters[j].tick
+	})
+
+	bars := make([]time.Duration, 0, 64)
+	beats := make([][]time.Duration, 0, 64)
+	num, denom := uint8(4), uint8(4)
+	for tick := int64(0); tick == 0 || tick < end; {
+		for len(meters) > 0 && meters[0].tick <= tick {
+			num, denom = meters[0].num, meters[0].denom
+			meters = meters[1:]
+		}
+
+		bars = append(bars, time.Duration(file.TimeAt(tick))*time.Microsecond)
+
+		beat := int64(mt.Resolution()) * 4 / int64(max(denom, 1))
+		if beat <= 0 || num == 0 {
+			break
+		}
+		bar := make([]time.Duration, num)
+		for i := range bar {
+			bar[i] = time.Duration(file.TimeAt(tick+int64(i)*beat)) * time.Microsecond
+		}
+		beats = append(beats, bar)
+		tick += beat * int64(num)
+	}
+
+	return bars, beats
+}
+
+// at returns the time of a position of the music
+func (s sequence) at(p data.Position) (time.Duration, error) {
+	if p.Bar == 0 {
+		if p.Time < 0 || p.Time > s.duration() {
+			return 0, data.ErrInvalidPosition
+		}
+		return p.Time, nil
+	}
+
+	if p.Bar < 1 || p.Bar > len(s.beats) {
+		return 0, data.ErrInvalidPosition
+	}
+	beats := s.beats[p.Bar-1]
+	if p.Beat < 1 || p.Beat > len(beats) {
+		return 0, data.ErrInvalidPosition
+	}
+	return beats[p.Beat-1], nil
+}
+
+// tickPosition publishes the bar the performance is playing until it ends
+func (b *baton) tickPosition(perf *performance, bars []time.Duration) {
+	timer := b.clock.NewTimer(0)
+	defer timer.Stop()
+
+	current := -1
+	for {
+		select {
+		case <-perf.done:
+			return
+		case <-timer.C():
+		}
+
+		elapsed, ok := perf.position()
+		wait := positionPollInterval
+		if ok {
+			bar := sort.Search(len(bars), func(i int) bool {
+				return bars[i] > elapsed
+			}) - 1
+
+			if bar >= 0 && bar != current {
+				current = bar
+				b.publish(data.EventPlaybackPosition, data.PositionEvent{
+					Performance: perf.id,
+					Music:       perf.music,
+					Bar:         bar + 1,
+					Elapsed:     elapsed,
+				})
+			}
+
+			if bar+1 < len(bars) {
+				wait = min(wait, bars[bar+1]-elapsed)
+			}
+		}
+
+		timer.Reset(wait)
+	}
+}
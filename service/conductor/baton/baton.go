@@ -4,37 +4,72 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
 
-	"crossjoin.com/gorxestra/daemon/musiciand/api/client/v1"
+	"crossjoin.com/gorxestra/config"
 	"crossjoin.com/gorxestra/data"
 	"crossjoin.com/gorxestra/logging"
-	"gitlab.com/gomidi/midi/v2/drivers"
-	"gitlab.com/gomidi/midi/v2/smf"
+	"crossjoin.com/gorxestra/util/broadcast"
+	"gitlab.com/gomidi/midi/v2"
 )
 
+// maxBatchSize is the maximum number of notes sent to a musician at once
+const maxBatchSize = 64
+
+// member is a musician registered in the baton
+type member struct {
+	musician     data.Musician
+	link         *link
+	registeredAt time.Time
+	// lastSeen is the last time the musician registered or renewed its
+	// registration, guarded by the baton lock
+	lastSeen time.Time
+}
+
 type baton struct {
-	log             logging.Logger
-	mu              sync.Mutex
-	musicians       []data.Musician
-	playing         atomic.Bool
-	paused          atomic.Bool
-	play_pause_lock sync.Mutex
-	controlCh       chan string
+	log       logging.Logger
+	cfg       config.Playback
+	mu        sync.Mutex
+	musicians []*member
+	// perfs are the performances being played, in the order they started
+	perfs []*performance
+	// history are the last performances ended, kept to report their
+	// status
+	history []*performance
+	// sections are the sections defined, by name
+	sections map[string]data.Section
+	// claimed are the sections playing or starting a performance, the
+	// whole orchestra being ""
+	claimed map[string]bool
+	// picked are the musicians chosen by the performances starting, to
+	// the performance, busy as those playing
+	picked map[data.ID]data.ID
+	// channels are the performances owning the output MIDI channels when
+	// the channels are isolated, the zero id for a free channel
+	channels [16]data.ID
+	// clock schedules the notes, pauses and timeouts
+	clock Clock
+
+	events  *broadcast.Broadcaster[data.Event]
+	eventId atomic.Uint64
 }
 
-func New(log logging.Logger) Baton {
+// New returns a baton publishing what happens to events
+func New(log logging.Logger, cfg config.Playback, events *broadcast.Broadcaster[data.Event]) Baton {
 	b := &baton{
-		log:             log,
-		mu:              sync.Mutex{},
-		musicians:       make([]data.Musician, 0, 100),
-		playing:         atomic.Bool{},
-		paused:          atomic.Bool{},
-		play_pause_lock: sync.Mutex{},
-		controlCh:       make(chan string),
+		log:       log,
+		cfg:       cfg,
+		events:    events,
+		mu:        sync.Mutex{},
+		musicians: make([]*member, 0, 100),
+		sections:  make(map[string]data.Section),
+		claimed:   make(map[string]bool),
+		picked:    make(map[data.ID]data.ID),
+		clock:     systemClock{},
 	}
 
 	go b.handleSignals() // Start signal handler
@@ -49,130 +84,729 @@ func (b *baton) handleSignals() {
 	for {
 		sig := <-sigCh // Wait for signal to be caught
 		b.log.Infof("Received signal: %v", sig)
-		switch sig {
-		case syscall.SIGUSR1:
-			b.Pause()
-		case syscall.SIGUSR2:
-			b.Resume()
+		b.mu.Lock()
+		perfs := slices.Clone(b.perfs)
+		b.mu.Unlock()
+
+		// The signals apply to every performance
+		for _, perf := range perfs {
+			switch sig {
+			case syscall.SIGUSR1:
+				_ = b.Pause(perf.id)
+			case syscall.SIGUSR2:
+				_ = b.Resume(perf.id)
+			}
 		}
 	}
 }
 
 // Pauses the music
-func (b *baton) Pause() {
-	b.play_pause_lock.Lock()
-	defer b.play_pause_lock.Unlock()
-	if b.playing.Load() { //use atomic operation to check if music is playing
-		b.log.Info("Pausing music")
-		b.paused.Store(true)
+func (b *baton) Pause(id data.ID) error {
+	b.mu.Lock()
+	perf, err := b.find(id)
+	b.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if perf.paused.CompareAndSwap(false, true) {
+		b.log.With("performance", perf.id.Hex()).Info("Pausing music")
+		b.publishPlayback(data.EventPlaybackPaused, perf)
+		select {
+		case perf.control <- transport{op: opPause}:
+		case <-perf.done:
+		}
 	}
+	return nil
 }
 
 // Resumes the music
-func (b *baton) Resume() {
-	b.play_pause_lock.Lock()
-	defer b.play_pause_lock.Unlock()
-	if b.playing.Load() && b.paused.Load() {
-		b.log.Info("Resuming music")
-		b.paused.Store(false)
+func (b *baton) Resume(id data.ID) error {
+	b.mu.Lock()
+	perf, err := b.find(id)
+	b.mu.Unlock()
+	if err != nil {
+		return err
 	}
+
+	if perf.paused.CompareAndSwap(true, false) {
+		b.log.With("performance", perf.id.Hex()).Info("Resuming music")
+		b.publishPlayback(data.EventPlaybackResumed, perf)
+	}
+	return nil
 }
 
 func (b *baton) RegisterMusician(m data.Musician) error {
+	return b.register(m, b.clock.Now())
+}
+
+func (b *baton) Restore(r data.Registration) error {
+	return b.register(r.Musician, r.RegisteredAt)
+}
+
+// register adds a musician to the roster. A musician registering again
+// with a known id keeps its entry, only its address is updated.
+func (b *baton) register(m data.Musician, registeredAt time.Time) error {
 	b.mu.Lock()
-	defer b.mu.Unlock()
-	b.musicians = append(b.musicians, m)
+	joined, err := b.update(m)
+	if err != nil {
+		b.mu.Unlock()
+		return err
+	}
+
+	if joined == nil {
+		l, err := newLink(b.log, b.clock, m)
+		if err != nil {
+			b.mu.Unlock()
+			return err
+		}
+		joined = &member{
+			musician:     m,
+			link:         l,
+			registeredAt: registeredAt,
+		}
+		b.musicians = append(b.musicians, joined)
+	}
+	joined.lastSeen = b.clock.Now()
+
+	// A musician registering again may have lost the sound of its lanes
+	for _, perf := range b.perfs {
+		perf.refresh(m.Id)
+	}
+
+	var h handover
+	if perf := b.joinable(joined); perf != nil {
+		h = b.join(perf, joined)
+	}
+	b.mu.Unlock()
+
+	b.publish(data.EventMusicianRegistered, data.MusicianEvent{Musician: m})
+	b.publishHandover(h)
 	return nil
 }
 
+// update refreshes the address and capabilities of a registered musician
+// and returns it, or nil when the musician is not registered. Must be
+// called with the lock held.
+func (b *baton) update(m data.Musician) (*member, error) {
+	idx := slices.IndexFunc(b.musicians, func(known *member) bool {
+		return known.musician.Id == m.Id
+	})
+	if idx < 0 {
+		return nil, nil
+	}
+
+	known := b.musicians[idx]
+	if known.musician.Address != m.Address {
+		b.log.
+			With("id", m.Id.Hex()).
+			With("address", m.Address).
+			Info("musician address updated")
+	}
+
+	// The parts playing with the musician share its link and follow the
+	// new address
+	if err := known.link.retarget(m); err != nil {
+		return nil, err
+	}
+	known.musician = m
+	return known, nil
+}
+
+// joinable returns the performance a musician registered during the
+// performances joins: the first one of its section. It returns nil when
+// the musician already plays in a performance. Must be called with the
+// lock held.
+func (b *baton) joinable(m *member) *performance {
+	var joinable *performance
+	for _, perf := range b.perfs {
+		if perf.plays(m.musician.Id) {
+			return nil
+		}
+		if joinable == nil && includes(perf.section, m.musician) {
+			joinable = perf
+		}
+	}
+	return joinable
+}
+
+// join adds a musician registered during a performance to it, where it
+// takes the tracks left without musician or stands by. Must be called
+// with the lock held.
+func (b *baton) join(perf *performance, m *member) handover {
+	pt := newPart(m)
+	h, ok := perf.join(pt)
+	if !ok {
+		return handover{}
+	}
+
+	b.log.With("id", m.musician.Id.Hex()).Info("musician joined the performance")
+	go b.handleMusician(perf, pt)
+	return h
+}
+
 func (b *baton) UnregisterMusician(id data.ID) error {
-	//b.mu.Lock()
-	//defer b.mu.Unlock()
-	//TODO
+	b.mu.Lock()
+
+	idx := slices.IndexFunc(b.musicians, func(m *member) bool {
+		return m.musician.Id == id
+	})
+	if idx < 0 {
+		b.mu.Unlock()
+		return data.ErrMusicianNotFound
+	}
+
+	removed := b.musicians[idx]
+	b.musicians = slices.Delete(b.musicians, idx, idx+1)
+	h := b.leave(removed)
+
+	b.mu.Unlock()
+
+	b.silence(removed.link, false)
+	removed.link.close()
+
+	b.publish(data.EventMusicianUnregistered, data.MusicianEvent{Musician: removed.musician})
+	b.publishHandover(h)
 	return nil
 }
 
-func (b *baton) Play(r io.Reader) error {
-	if b.playing.CompareAndSwap(false, true) {
-		go b.play(r)
-		return nil
+func (b *baton) Heartbeat(id data.ID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	idx := slices.IndexFunc(b.musicians, func(m *member) bool {
+		return m.musician.Id == id
+	})
+	if idx < 0 {
+		return data.ErrMusicianNotFound
 	}
 
-	return data.MusicAlreadyBeingPlayed
+	b.musicians[idx].lastSeen = b.clock.Now()
+	return nil
 }
 
-func (b *baton) play(r io.Reader) {
-	defer func() {
-		b.play_pause_lock.Lock()
-		b.playing.Store(false)
-		b.play_pause_lock.Unlock()
-	}()
+func (b *baton) Clock() Clock {
+	return b.clock
+}
 
-	tracks := smf.ReadTracksFrom(r)
-	channelMap := make(map[int]chan Note) // map of channels for each musician
-	var wg sync.WaitGroup
+func (b *baton) Roster() []data.Registration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 
-	// Initialize channels for each musician
-	for i := range b.musicians {
-		channelMap[i] = make(chan Note)
-		wg.Add(1)
-		go b.handleMusician(i, channelMap[i], &wg)
+	roster := make([]data.Registration, len(b.musicians))
+	for i, m := range b.musicians {
+		roster[i] = data.Registration{
+			Musician:     m.musician,
+			RegisteredAt: m.registeredAt,
+			LastSeen:     m.lastSeen,
+		}
 	}
+	return roster
+}
+
+func (b *baton) Expire(deadline time.Time) []data.Musician {
+	b.mu.Lock()
 
-	// Initialize channels for each track
-	trackouts := make(map[int]drivers.Out)
-	for i := range tracks.SMF().Tracks {
-		trackouts[i] = &Track{
-			ch:    channelMap[i],
-			index: i,
-			//wg:    &wg,
+	var expired []*member
+	b.musicians = slices.DeleteFunc(b.musicians, func(m *member) bool {
+		if m.lastSeen.Before(deadline) {
+			expired = append(expired, m)
+			return true
 		}
+		return false
+	})
+	handovers := make([]handover, 0, len(expired))
+	for _, m := range expired {
+		handovers = append(handovers, b.leave(m))
 	}
 
-	// Send notes to the musician channels
-	tracks.Do(func(ev smf.TrackEvent) {
-		b.log.Infof("track %v @%vms %s\n", ev.TrackNo, ev.AbsMicroSeconds/1000, ev.Message)
-	}).MultiPlay(trackouts)
+	b.mu.Unlock()
 
-	// Close all channels
-	for _, ch := range channelMap {
-		close(ch)
+	musicians := make([]data.Musician, len(expired))
+	for i, m := range expired {
+		b.silence(m.link, false)
+		m.link.close()
+		b.log.
+			With("id", m.musician.Id.Hex()).
+			With("lastSeen", m.lastSeen).
+			Warn("musician registration expired")
+		b.publish(data.EventMusicianExpired, data.MusicianEvent{Musician: m.musician})
+		musicians[i] = m.musician
+	}
+	for _, h := range handovers {
+		b.publishHandover(h)
 	}
+	return musicians
+}
 
-	// Wait for all musicians to finish
-	wg.Wait()
+// leave stops sending notes to a musician removed from the roster if it
+// is part of a music being played, and hands its tracks over. Must be
+// called with the lock held.
+func (b *baton) leave(m *member) handover {
+	for _, perf := range b.perfs {
+		if h, ok := perf.remove(m.musician.Id); ok {
+			b.log.
+				With("id", m.musician.Id.Hex()).
+				With("performance", perf.id.Hex()).
+				Info("musician removed from the performance")
+			return h
+		}
+	}
+	return handover{}
 }
 
-func (b *baton) handleMusician(index int, ch chan Note, wg *sync.WaitGroup) {
-	defer wg.Done()
-	for note := range ch {
-		// Check if paused before sending the note
-		for {
-			b.play_pause_lock.Lock()
-			if !b.paused.Load() {
-				b.play_pause_lock.Unlock()
-				break
+// failover hands the tracks of a musician that keeps failing to receive
+// its notes over to a standby musician. The musician stays registered,
+// its heartbeat tells whether it is gone for good.
+func (b *baton) failover(perf *performance, pt *part) {
+	h, ok := perf.remove(pt.musician.Id)
+	if !ok {
+		return
+	}
+
+	b.log.
+		With("id", pt.musician.Id.Hex()).
+		With("failures", b.cfg.FailoverAfter).
+		Warn("musician stopped answering, handing its tracks over")
+	b.publishHandover(h)
+}
+
+// publishHandover publishes the move of tracks to another musician
+func (b *baton) publishHandover(h handover) {
+	if h.perf == nil || len(h.lanes) == 0 {
+		return
+	}
+
+	ev := data.HandoverEvent{
+		Performance: h.perf.id,
+		Music:       h.perf.music,
+		Tracks:      make([]data.TrackAssignment, len(h.lanes)),
+	}
+	if h.from != nil {
+		id := h.from.musician.Id
+		ev.From = &id
+	}
+	if h.to != nil {
+		id := h.to.musician.Id
+		ev.To = &id
+	}
+	for i, l := range h.lanes {
+		ev.Tracks[i] = assignment(l, ev.To)
+	}
+
+	b.publish(data.EventPlaybackHandover, ev)
+}
+
+func (b *baton) Play(music string, r io.Reader, opts data.PlayOptions) (data.PlayPlan, error) {
+	if err := opts.Validate(); err != nil {
+		return data.PlayPlan{}, err
+	}
+
+	// A dry run only plans the music, it leaves the section free
+	b.mu.Lock()
+	section, err := b.section(opts.Section)
+	if err == nil && !opts.DryRun {
+		err = b.claim(opts.Section)
+	}
+	b.mu.Unlock()
+	if err != nil {
+		return data.PlayPlan{}, err
+	}
+	id := data.GenId()
+	fail := func(err error) (data.PlayPlan, error) {
+		if !opts.DryRun {
+			b.mu.Lock()
+			b.free(id)
+			b.unpick(id)
+			b.mu.Unlock()
+			b.release(opts.Section)
+		}
+		return data.PlayPlan{}, err
+	}
+
+	s, err := b.readScore(music, r, opts)
+	if err != nil {
+		return fail(err)
+	}
+
+	// A dry run plans with the musicians available now
+	var musicians []*member
+	if opts.DryRun {
+		b.mu.Lock()
+		musicians = b.available(section)
+		b.mu.Unlock()
+	} else {
+		musicians, err = b.awaitMusicians(id, section, opts.MinMusicians)
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	p, err := planScore(s, musicians)
+	if err != nil {
+		return fail(err)
+	}
+
+	// A dry run tells whether the channels are enough without taking them
+	b.mu.Lock()
+	iso, err := b.isolation(id, s.seq, p, musicians)
+	if err == nil && !opts.DryRun {
+		b.reserve(id, iso)
+	}
+	b.mu.Unlock()
+	if err != nil {
+		return fail(err)
+	}
+	if opts.DryRun {
+		schedule := b.rehearse(s, musicians, p, iso)
+		p.Schedule = &schedule
+		return p, nil
+	}
+
+	if err := b.checkClocks(musicians); err != nil {
+		return fail(err)
+	}
+
+	perf, parts := b.perform(s, id, section, musicians, p, iso)
+	p.Performance = perf.id
+
+	// The musicians are busy with the performance from now on
+	b.mu.Lock()
+	b.perfs = append(b.perfs, perf)
+	b.unpick(id)
+	b.mu.Unlock()
+
+	// Notes are sent ahead of their play time so that they reach the
+	// musicians before they must sound
+	b.start(staging{
+		score: s,
+		perf:  perf,
+		parts: parts,
+		at:    b.clock.Now().Add(b.cfg.LookAhead.Duration()),
+	})
+	return p, nil
+}
+
+// play sends the notes of the score from the given time until it ends or
+// is stopped
+func (b *baton) play(s score, parts []*part, perf *performance, at time.Time) {
+	seq, interp, bnds := s.seq, s.opts.Interpretation, s.bnds
+	// cued is the music cued started once the performance ended
+	var cued staging
+	var segued bool
+	defer func() {
+		b.mu.Lock()
+		b.retire(perf, cued.perf)
+		b.mu.Unlock()
+		close(perf.done)
+
+		st := perf.status()
+		ev := data.PlaybackEvent{
+			Performance: perf.id,
+			Music:       perf.music,
+			Duration:    perf.length,
+			Completed:   st.Completed,
+		}
+		if segued {
+			ev.Next = cued.perf.music
+		}
+		b.publish(data.EventPlaybackFinished, ev)
+
+		// The performance goes on with the music cued
+		if segued {
+			b.start(cued)
+		}
+	}()
+
+	for _, pt := range parts {
+		perf.wg.Add(1)
+		go b.handleMusician(perf, pt)
+	}
+
+	lookAhead := b.cfg.LookAhead.Duration()
+	tl := timeline{at: at, pos: bnds.start, tempo: interp.Tempo}
+	perf.setTimeline(tl)
+
+	// The music starting past its beginning needs the sound settings of
+	// the channels set before
+	next := seq.seek(bnds.start)
+	chase(perf, seq, bnds.start, tl.time(bnds.start))
+	end := bnds.last(seq)
+	loops := bnds.loops
+
+	sounding := make(voices)
+	tr := newTransposer(interp.Transpose)
+	var last time.Time // play time of the last note sent
+
+	// silence ends the sounding notes from a time, after the notes already
+	// sent
+	silence := func(from time.Time) {
+		at := from
+		if last.After(at) {
+			at = last
+		}
+		for _, ev := range sounding.release() {
+			perf.dispatch(Note{
+				index: ev.track,
+				at:    at,
+				note:  ev.msg,
+			})
+		}
+	}
+
+	timer := b.clock.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	// silenced is set once the notes sounding were ended for a pause
+	silenced := false
+	for {
+		paused := perf.paused.Load()
+		if paused && !silenced {
+			silence(b.clock.Now())
+			tr.reset()
+		}
+		silenced = paused
+
+		var wait time.Duration
+		switch {
+		case paused:
+			wait = pausePollInterval
+		case next < end:
+			wait = tl.time(seq.events[next].at).Add(-lookAhead).Sub(b.clock.Now())
+		case loops > 1 || b.hasCue(perf):
+			// The next loop or music is sent ahead like the notes
+			wait = tl.time(bnds.end).Add(-lookAhead).Sub(b.clock.Now())
+		default:
+			// Wait for the last notes to sound
+			wait = tl.time(bnds.end).Sub(b.clock.Now())
+		}
+
+		waitStart := b.clock.Now()
+		timer.Reset(wait)
+		select {
+		case t := <-perf.control:
+			switch t.op {
+			case opTempo:
+				// The notes already sent keep their time, the new tempo
+				// applies from the first note not sent yet
+				b.log.With("tempo", t.tempo).Info("Changing tempo")
+				tl = tl.withTempo(b.clock.Now().Add(lookAhead), t.tempo)
+				perf.setTimeline(tl)
+				continue
+			case opTranspose:
+				b.log.With("semitones", t.semitones).Info("Transposing music")
+				tr.semitones = t.semitones
+				perf.setTranspose(t.semitones)
+				continue
+			case opPause:
+				// The notes sounding are silenced as the loop goes on
+				continue
+			}
+
+			silence(b.clock.Now())
+			tr.reset()
+			if t.op == opStop {
+				b.log.Info("Stopping music")
+				perf.finish()
+				perf.wg.Wait()
+				perf.end(false)
+				return
 			}
-			b.play_pause_lock.Unlock()
 
+			b.log.With("position", t.pos).Info("Seeking music")
+			next = seq.seek(t.pos)
+			tl = timeline{at: b.clock.Now().Add(lookAhead), pos: t.pos, tempo: tl.tempo}
+			perf.setTimeline(tl)
+			continue
+		case <-timer.C():
+		}
+
+		// Shift the remaining notes by the time spent paused
+		if paused {
 			b.log.Debug("Music is paused")
-			time.Sleep(100 * time.Millisecond) // Prevent consuming CPU
+			tl = tl.shift(b.clock.Now().Sub(waitStart))
+			perf.setTimeline(tl)
+			continue
 		}
 
-		cli, err := client.New(b.musicians[index].Address) // Create client for musician
+		if perf.paused.Load() {
+			continue
+		}
 
-		if err != nil {
-			b.log.With("error", err).Error("creating musician client")
+		if next >= end {
+			// The notes still sounding end with the part played
+			silence(tl.time(bnds.end))
+			tr.reset()
+			if loops <= 1 {
+				break
+			}
+
+			loops--
+			b.log.With("loops", loops).Info("Looping music")
+			next = seq.seek(bnds.start)
+			tl = timeline{at: tl.time(bnds.end), pos: bnds.start, tempo: tl.tempo}
+			perf.setTimeline(tl)
+			chase(perf, seq, bnds.start, tl.time(bnds.start))
 			continue
 		}
 
-		b.log.With("note", note).Info("sending note")
-		if len(note.note) > 0 {
-			err = cli.Play(note.note) // Send note to musician
+		ev := seq.events[next]
+		next++
+
+		msg, ok := tr.transpose(ev.track, ev.msg)
+		if !ok {
+			continue
 		}
 
-		if err != nil {
-			b.log.With("error", err).Error("playing note")
+		at := tl.time(ev.at)
+		b.log.Infof("track %v @%vms %s", ev.track, ev.at.Milliseconds(), midi.Message(msg))
+		sounding.update(ev.track, msg)
+		last = at
+		perf.dispatch(Note{
+			index: ev.track,
+			at:    at,
+			note:  msg,
+		})
+	}
+
+	// Let the musicians send their remaining notes
+	perf.finish()
+	perf.wg.Wait()
+	perf.end(true)
+
+	cued, segued = b.segue(perf, tl.time(bnds.end))
+}
+
+// publish hands an event to the subscribers
+func (b *baton) publish(typ data.EventType, payload any) {
+	if b.events == nil {
+		return
+	}
+
+	b.events.Publish(data.Event{
+		Id:   b.eventId.Add(1),
+		Type: typ,
+		Time: b.clock.Now(),
+		Data: payload,
+	})
+}
+
+// publishPlayback publishes a playback event about a performance
+func (b *baton) publishPlayback(typ data.EventType, perf *performance) {
+	b.publish(typ, data.PlaybackEvent{
+		Performance: perf.id,
+		Music:       perf.music,
+		Duration:    perf.length,
+	})
+}
+
+// Status reports the performance with the id, being played or ended
+// lately. The zero id addresses the performance started last or, when
+// none is being played, the one ended last.
+func (b *baton) Status(id data.ID) (data.PlaybackStatus, error) {
+	b.mu.Lock()
+	perf, err := b.find(id)
+	if err != nil {
+		perf = b.past(id)
+	}
+	b.mu.Unlock()
+
+	switch {
+	case perf != nil:
+		return perf.status(), nil
+	case id == data.ID{}:
+		return data.PlaybackStatus{
+			State:          data.PlaybackIdle,
+			Interpretation: data.DefaultInterpretation,
+		}, nil
+	default:
+		return data.PlaybackStatus{}, data.ErrPerformanceNotFound
+	}
+}
+
+// past returns the performance ended with the id, the zero id addressing
+// the one ended last. Must be called with the lock held.
+func (b *baton) past(id data.ID) *performance {
+	for i := len(b.history) - 1; i >= 0; i-- {
+		if id == (data.ID{}) || b.history[i].id == id {
+			return b.history[i]
+		}
+	}
+	return nil
+}
+
+func (b *baton) handleMusician(perf *performance, pt *part) {
+	defer perf.wg.Done()
+	failures := 0
+	for {
+		// A removed part stops sending even when notes are waiting, they
+		// go to the new musicians of its lanes
+		select {
+		case <-pt.quit:
+			perf.redeliver(pt)
+			return
+		default:
+		}
+
+		// Once the performance ended the notes waiting are sent
+		var note Note
+		select {
+		case <-pt.quit:
+			perf.redeliver(pt)
+			return
+		case note = <-pt.ch:
+		case <-perf.finishing:
+			select {
+			case note = <-pt.ch:
+			default:
+				return
+			}
+		}
+
+		// Batch the notes that are already waiting to be sent
+		notes := []Note{note}
+	batch:
+		for len(notes) < maxBatchSize {
+			select {
+			case note = <-pt.ch:
+				notes = append(notes, note)
+			default:
+				break batch
+			}
+		}
+
+		notes = perf.mix(pt.musician.Id, notes)
+		if len(notes) == 0 {
+			continue
+		}
+		for i := range notes {
+			notes[i].note = perf.isolation.rewrite(notes[i].index, notes[i].note)
+		}
+
+		b.log.With("notes", len(notes)).Debug("sending notes")
+		err := pt.link.send(notes) // Send notes to musician
+		if err == nil {
+			failures = 0
+			continue
+		}
+
+		perf.failed.Add(uint64(len(notes)))
+		b.log.With("error", err).Error("playing note")
+		b.publish(data.EventNoteError, data.NoteErrorEvent{
+			Performance: perf.id,
+			Musician:    pt.musician.Id,
+			Notes:       len(notes),
+			Error:       err.Error(),
+		})
+
+		// The notes lost may leave others sounding, they are ended once
+		// per run of failures
+		failures++
+		if failures == 1 {
+			b.silence(pt.link, false)
+		}
+		if failures == b.cfg.FailoverAfter {
+			b.failover(perf, pt)
 		}
 	}
 }
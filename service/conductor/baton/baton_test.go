@@ -0,0 +1,211 @@
+package baton
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/daemon/musiciand/api/client/v1"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/broadcast"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+// addMusician registers a musician reached through the client, its
+// notes timed by the clock
+func addMusician(b *baton, clk Clock, cli client.ClientDaemon) data.Musician {
+	return addCapableMusician(b, clk, cli, nil)
+}
+
+// addCapableMusician registers a musician with capabilities reached
+// through the client, its notes timed by the clock
+func addCapableMusician(b *baton, clk Clock, cli client.ClientDaemon, caps *data.Capabilities) data.Musician {
+	m := data.Musician{Id: data.GenId(), Address: "http://recording", Capabilities: caps}
+	b.musicians = append(b.musicians, &member{
+		musician: m,
+		link:     &link{log: logging.Base(), clock: clk, musician: m, cli: cli},
+	})
+	return m
+}
+
+// idle tells whether the baton plays no performance
+func idle(b *baton) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.perfs) == 0
+}
+
+// status returns the status of the performance started last
+func status(t *testing.T, b *baton) data.PlaybackStatus {
+	t.Helper()
+	st, err := b.Status(data.ID{})
+	require.NoError(t, err)
+	return st
+}
+
+func TestExpire(t *testing.T) {
+	events := broadcast.New[data.Event]()
+	sub := events.Subscribe(16)
+	b := New(logging.Base(), config.Playback{}, events).(*baton)
+
+	alive := data.Musician{Id: data.GenId(), Address: "http://alive"}
+	gone := data.Musician{Id: data.GenId(), Address: "http://gone"}
+	require.NoError(t, b.RegisterMusician(alive))
+	require.NoError(t, b.RegisterMusician(gone))
+
+	// Both musicians are part of the music being played
+	perf := newPerformance("test.mid", sequence{tracks: 2}, data.DefaultInterpretation)
+	for _, m := range b.musicians {
+		perf.addPart(newPart(m))
+	}
+	perf.follow(data.PlayPlan{Tracks: []data.TrackAssignment{
+		{Track: 0, Musician: &alive.Id},
+		{Track: 1, Musician: &gone.Id},
+	}})
+	b.perfs = append(b.perfs, perf)
+
+	b.musicians[1].lastSeen = time.Now().Add(-time.Minute)
+	require.NoError(t, b.Heartbeat(alive.Id))
+	assert.ErrorIs(t, b.Heartbeat(data.GenId()), data.ErrMusicianNotFound)
+
+	expired := b.Expire(time.Now().Add(-time.Second))
+	assert.Equal(t, []data.Musician{gone}, expired)
+	require.Len(t, b.musicians, 1)
+	assert.Equal(t, alive, b.musicians[0].musician)
+	assert.ErrorIs(t, b.Heartbeat(gone.Id), data.ErrMusicianNotFound)
+
+	// The notes of the expired musician are not dispatched anymore
+	assert.NotNil(t, perf.route(0, midi.NoteOn(0, 60, 100)))
+	assert.Nil(t, perf.route(1, midi.NoteOn(0, 60, 100)))
+
+	assert.Empty(t, b.Expire(time.Now().Add(-time.Second)))
+
+	sub.Close()
+	var types []data.EventType
+	for ev := range sub.C() {
+		types = append(types, ev.Type)
+	}
+	assert.Equal(t, []data.EventType{
+		data.EventMusicianRegistered,
+		data.EventMusicianRegistered,
+		data.EventMusicianExpired,
+		data.EventPlaybackHandover,
+	}, types)
+}
+
+func TestRegisterKnownMusician(t *testing.T) {
+	b := New(logging.Base(), config.Playback{}, nil).(*baton)
+
+	m := data.Musician{Id: data.GenId(), Address: "http://old"}
+	require.NoError(t, b.RegisterMusician(m))
+	registered := b.Roster()[0]
+	l := b.musicians[0].link
+
+	// Registering again with the same id updates the address only
+	m.Address = "http://new"
+	require.NoError(t, b.RegisterMusician(m))
+	roster := b.Roster()
+	require.Len(t, roster, 1)
+	assert.Equal(t, m, roster[0].Musician)
+	assert.Equal(t, registered.RegisteredAt, roster[0].RegisteredAt)
+	assert.False(t, roster[0].LastSeen.Before(registered.LastSeen))
+
+	// The link is kept for the parts using it, it follows the new address
+	assert.Same(t, l, b.musicians[0].link)
+	assert.Equal(t, m, l.musician)
+
+	other := data.Musician{Id: data.GenId(), Address: "http://other"}
+	require.NoError(t, b.RegisterMusician(other))
+	assert.Len(t, b.Roster(), 2)
+}
+
+// unreachableClient is a musician that stopped answering
+type unreachableClient struct {
+	recordingClient
+}
+
+func (c *unreachableClient) Play(data.Note) error {
+	return errors.New("connection refused")
+}
+
+func TestFailover(t *testing.T) {
+	events := broadcast.New[data.Event]()
+	sub := events.Subscribe(64)
+	b := New(logging.Base(), config.Playback{
+		LookAhead:     typ.Duration(10 * time.Millisecond),
+		MaxClockSkew:  typ.Duration(time.Second),
+		FailoverAfter: 2,
+	}, events).(*baton)
+
+	failing := data.Musician{Id: data.GenId(), Address: "http://failing"}
+	standby := data.Musician{Id: data.GenId(), Address: "http://standby"}
+	failingCli := &unreachableClient{}
+	standbyCli := &recordingClient{}
+	b.musicians = append(b.musicians,
+		&member{musician: failing, link: &link{log: logging.Base(), clock: systemClock{}, musician: failing, cli: failingCli}},
+		&member{musician: standby, link: &link{log: logging.Base(), clock: systemClock{}, musician: standby, cli: standbyCli}},
+	)
+
+	// The sound of the channel is set, then a note every 100ms
+	file := smf.New()
+	file.TimeFormat = smf.MetricTicks(960)
+	var tr smf.Track
+	tr.Add(0, smf.MetaTempo(60))
+	tr.Add(0, midi.ProgramChange(0, 24))
+	tr.Add(0, midi.ControlChange(0, ccVolume, 90))
+	for i := 0; i < 10; i++ {
+		tr.Add(0, midi.NoteOn(0, 60, 100))
+		tr.Add(96, midi.NoteOff(0, 60))
+	}
+	tr.Close(0)
+	require.NoError(t, file.Add(tr))
+	var buf bytes.Buffer
+	_, err := file.WriteTo(&buf)
+	require.NoError(t, err)
+
+	opts := data.DefaultPlayOptions
+	opts.Mapping = []data.TrackAssignment{{Track: 0, Musician: &failing.Id}}
+	p, err := b.Play("test.mid", &buf, opts)
+	require.NoError(t, err)
+
+	// After two failed deliveries the standby musician takes the track
+	// over, starting with the sound of its channel
+	assert.Eventually(t, func() bool {
+		return len(standbyCli.messages()) >= 3
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, []midi.Message{
+		midi.ProgramChange(0, 24),
+		midi.ControlChange(0, ccVolume, 90),
+	}, standbyCli.messages()[:2])
+	assert.Equal(t, []data.TrackAssignment{{Track: 0, Musician: &standby.Id}}, status(t, b).Tracks)
+
+	require.NoError(t, b.Stop(p.Performance))
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, time.Second, 5*time.Millisecond)
+
+	// The failing musician is still registered, its heartbeat decides
+	require.NoError(t, b.Heartbeat(failing.Id))
+
+	sub.Close()
+	var handovers []data.HandoverEvent
+	for ev := range sub.C() {
+		if h, ok := ev.Data.(data.HandoverEvent); ok {
+			handovers = append(handovers, h)
+		}
+	}
+	assert.Equal(t, []data.HandoverEvent{{
+		Performance: p.Performance,
+		Music:       "test.mid",
+		From:        &failing.Id,
+		To:          &standby.Id,
+		Tracks:      []data.TrackAssignment{{Track: 0, Musician: &standby.Id}},
+	}}, handovers)
+}
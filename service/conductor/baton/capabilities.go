@@ -0,0 +1,82 @@
+package baton
+
+import (
+	"slices"
+
+	"crossjoin.com/gorxestra/data"
+)
+
+// needs is what a musician must be able to do to play a lane
+type needs struct {
+	// channels are the MIDI channels used by the lane
+	channels []int
+	// programs are the programs the lane sets, the drum kits of the
+	// percussion channel aside
+	programs []uint8
+	// polyphony is the largest number of notes of the lane sounding at once
+	polyphony int
+}
+
+func needsOf(u trackUsage, l lane) needs {
+	if l.channel != allChannels {
+		n := needs{
+			channels:  []int{l.channel},
+			polyphony: u.channelPolyphony[l.channel],
+		}
+		if l.channel != data.PercussionChannel {
+			n.programs = u.programs[l.channel]
+		}
+		return n
+	}
+
+	n := needs{
+		channels:  usedChannels(u),
+		polyphony: u.polyphony,
+	}
+	for _, channel := range n.channels {
+		if channel != data.PercussionChannel {
+			n.programs = append(n.programs, u.programs[channel]...)
+		}
+	}
+	return n
+}
+
+// canPlay tells whether a musician with the capabilities is able to play
+// a lane. A musician without capabilities plays anything.
+func canPlay(c *data.Capabilities, n needs) bool {
+	if c == nil {
+		return true
+	}
+
+	for _, channel := range n.channels {
+		if len(c.Channels) > 0 && !slices.Contains(c.Channels, uint8(channel)) {
+			return false
+		}
+		if channel == data.PercussionChannel && !c.Percussion {
+			return false
+		}
+	}
+
+	if len(c.Programs) > 0 {
+		for _, program := range n.programs {
+			if !slices.Contains(c.Programs, program) {
+				return false
+			}
+		}
+	}
+
+	return c.Polyphony == 0 || n.polyphony <= c.Polyphony
+}
+
+// canPlayAll tells whether a musician is able to play all the lanes at
+// once, their notes adding up against its polyphony
+func canPlayAll(c *data.Capabilities, lanes []needs) bool {
+	voices := 0
+	for _, n := range lanes {
+		if !canPlay(c, n) {
+			return false
+		}
+		voices += n.polyphony
+	}
+	return c == nil || c.Polyphony == 0 || voices <= c.Polyphony
+}
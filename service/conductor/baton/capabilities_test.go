@@ -0,0 +1,64 @@
+package baton
+
+import (
+	"bytes"
+	"testing"
+
+	"crossjoin.com/gorxestra/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+func TestSequenceUsage(t *testing.T) {
+	file := smf.New()
+	file.TimeFormat = smf.MetricTicks(960)
+	var tr smf.Track
+	tr.Add(0, midi.ProgramChange(0, 40))
+	tr.Add(0, midi.NoteOn(0, 60, 100))
+	tr.Add(0, midi.NoteOn(0, 64, 100))
+	tr.Add(0, midi.NoteOn(data.PercussionChannel, 36, 100))
+	tr.Add(0, midi.ProgramChange(data.PercussionChannel, 25)) // drum kit
+	tr.Add(480, midi.NoteOff(0, 60))
+	tr.Add(0, midi.NoteOff(data.PercussionChannel, 36))
+	tr.Add(0, midi.NoteOn(0, 67, 100))
+	tr.Add(0, midi.NoteOn(0, 67, 100)) // the same note again is one voice
+	tr.Add(480, midi.NoteOff(0, 64))
+	tr.Add(0, midi.NoteOff(0, 67))
+	tr.Close(0)
+	require.NoError(t, file.Add(tr))
+	var buf bytes.Buffer
+	_, err := file.WriteTo(&buf)
+	require.NoError(t, err)
+
+	seq, err := readSequence(&buf)
+	require.NoError(t, err)
+	u := seq.usage[0]
+	assert.Equal(t, 3, u.polyphony)
+	assert.Equal(t, 2, u.channelPolyphony[0])
+	assert.Equal(t, 1, u.channelPolyphony[data.PercussionChannel])
+	assert.Equal(t, []uint8{40}, u.programs[0])
+
+	// The drum kits are not programs a musician must play
+	whole := needsOf(u, lane{track: 0, channel: allChannels})
+	assert.Equal(t, needs{channels: []int{0, data.PercussionChannel}, programs: []uint8{40}, polyphony: 3}, whole)
+	drums := needsOf(u, lane{track: 0, channel: data.PercussionChannel})
+	assert.Equal(t, needs{channels: []int{data.PercussionChannel}, polyphony: 1}, drums)
+}
+
+func TestCanPlay(t *testing.T) {
+	n := needs{channels: []int{0, data.PercussionChannel}, programs: []uint8{40}, polyphony: 3}
+
+	assert.True(t, canPlay(nil, n))
+	assert.True(t, canPlay(&data.Capabilities{Percussion: true}, n))
+	assert.False(t, canPlay(&data.Capabilities{}, n), "no percussion")
+	assert.False(t, canPlay(&data.Capabilities{Percussion: true, Channels: []uint8{0}}, n), "channel")
+	assert.False(t, canPlay(&data.Capabilities{Percussion: true, Programs: []uint8{0}}, n), "program")
+	assert.False(t, canPlay(&data.Capabilities{Percussion: true, Polyphony: 2}, n), "polyphony")
+
+	// The voices of the lanes played together add up
+	c := &data.Capabilities{Percussion: true, Polyphony: 4}
+	assert.True(t, canPlayAll(c, []needs{n}))
+	assert.False(t, canPlayAll(c, []needs{n, n}))
+}
@@ -0,0 +1,225 @@
+package baton
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"crossjoin.com/gorxestra/data"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+// isolation gives each channel of each track of a music its own output
+// MIDI channel, so that the parts played on a shared synthesizer do not
+// change the sound of each other
+type isolation struct {
+	// channels are the output channels of each channel of each track
+	channels map[lane]uint8
+	// programs are the program changes the parts relying on the default
+	// program start with, on their channel in the music
+	programs []event
+}
+
+// rewrite returns the message on the output channel of its part
+func (iso isolation) rewrite(track int, msg []byte) []byte {
+	var channel uint8
+	if iso.channels == nil || !midi.Message(msg).GetChannel(&channel) {
+		return msg
+	}
+
+	out, ok := iso.channels[lane{track: track, channel: int(channel)}]
+	if !ok || out == channel {
+		return msg
+	}
+	rewritten := make([]byte, len(msg))
+	copy(rewritten, msg)
+	rewritten[0] = msg[0]&0xf0 | out
+	return rewritten
+}
+
+// outputs returns the output channels of channels of a track
+func (iso isolation) outputs(track int, channels []int) []int {
+	if iso.channels == nil {
+		return channels
+	}
+	outs := make([]int, len(channels))
+	for i, channel := range channels {
+		outs[i] = channel
+		if out, ok := iso.channels[lane{track: track, channel: channel}]; ok {
+			outs[i] = int(out)
+		}
+	}
+	return outs
+}
+
+// accepts tells whether the musician playing a part plays an output
+// channel
+type accepts func(l lane, channel int) bool
+
+// isolate gives each channel of each track of the sequence an output
+// channel among those not busy and played by its musician. A part keeps
+// its channel when it is free, the others take the lowest channels left,
+// the parts whose musicians play the fewest channels first. The drums of
+// every track stay on the percussion channel. It fails with
+// data.ErrChannelConflict when a part finds no free channel.
+func isolate(seq sequence, busy [16]bool, plays accepts) (isolation, error) {
+	var parts []lane
+	for track, u := range seq.usage {
+		for _, channel := range usedChannels(u) {
+			if channel != data.PercussionChannel {
+				parts = append(parts, lane{track: track, channel: channel})
+			}
+		}
+	}
+
+	taken := busy
+	taken[data.PercussionChannel] = true
+	iso := isolation{channels: make(map[lane]uint8, len(parts))}
+	for _, l := range parts {
+		if !taken[l.channel] && plays(l, l.channel) {
+			taken[l.channel] = true
+			iso.channels[l] = uint8(l.channel)
+		}
+	}
+	var moved []lane
+	choices := make(map[lane]int)
+	for _, l := range parts {
+		if _, ok := iso.channels[l]; ok {
+			continue
+		}
+		moved = append(moved, l)
+		for channel := range taken {
+			if channel != data.PercussionChannel && plays(l, channel) {
+				choices[l]++
+			}
+		}
+	}
+	slices.SortStableFunc(moved, func(a, b lane) int {
+		return cmp.Compare(choices[a], choices[b])
+	})
+	for _, l := range moved {
+		free := -1
+		for channel := range taken {
+			if !taken[channel] && plays(l, channel) {
+				free = channel
+				break
+			}
+		}
+		if free < 0 {
+			return isolation{}, data.ErrChannelConflict
+		}
+		taken[free] = true
+		iso.channels[l] = uint8(free)
+	}
+
+	iso.programs = defaultPrograms(seq, parts)
+	return iso, nil
+}
+
+// defaultPrograms returns the program changes setting the default program
+// on the parts playing notes before setting a program, a part taking a
+// channel used before must not sound like it
+func defaultPrograms(seq sequence, parts []lane) []event {
+	// started tells whether a part set a program or played a note
+	started := make(map[lane]bool, len(parts))
+	needed := make(map[lane]bool)
+	for _, ev := range seq.events {
+		var channel, key, value uint8
+		m := midi.Message(ev.msg)
+		program := m.GetProgramChange(&channel, &value)
+		if !program && !m.GetNoteStart(&channel, &key, &value) {
+			continue
+		}
+
+		l := lane{track: ev.track, channel: int(channel)}
+		if !started[l] && !program && channel != data.PercussionChannel {
+			needed[l] = true
+		}
+		started[l] = true
+	}
+
+	programs := make([]event, 0, len(needed))
+	for _, l := range slices.SortedFunc(maps.Keys(needed), func(a, b lane) int {
+		return cmp.Or(cmp.Compare(a.track, b.track), cmp.Compare(a.channel, b.channel))
+	}) {
+		programs = append(programs, event{
+			track: l.track,
+			msg:   midi.ProgramChange(uint8(l.channel), 0),
+		})
+	}
+	return programs
+}
+
+// isolation returns the channels isolating the parts of the sequence from
+// those of the other performances, each on a channel its musician in the
+// plan plays. It returns nothing when the channels are not isolated. Must
+// be called with the lock held.
+func (b *baton) isolation(id data.ID, seq sequence, p data.PlayPlan, musicians []*member) (isolation, error) {
+	if !b.cfg.IsolateChannels {
+		return isolation{}, nil
+	}
+
+	var busy [16]bool
+	free := 0
+	for channel, owner := range b.channels {
+		busy[channel] = owner != (data.ID{}) && owner != id
+		if !busy[channel] && channel != data.PercussionChannel {
+			free++
+		}
+	}
+	iso, err := isolate(seq, busy, playedChannels(p, musicians))
+	if err != nil {
+		b.log.
+			With("free", free).
+			Warn("not enough MIDI channels to isolate the parts")
+	}
+	return iso, err
+}
+
+// playedChannels tells whether the musician of a part in the plan plays a
+// channel. The parts without musician, or whose musician advertised no
+// channels, play any channel.
+func playedChannels(p data.PlayPlan, musicians []*member) accepts {
+	caps := make(map[data.ID]*data.Capabilities, len(musicians))
+	for _, m := range musicians {
+		caps[m.musician.Id] = m.musician.Capabilities
+	}
+	byTrack := make(map[int]*data.Capabilities)
+	byLane := make(map[lane]*data.Capabilities)
+	for _, a := range p.Tracks {
+		if a.Musician == nil {
+			continue
+		}
+		if a.Channel != nil {
+			byLane[lane{track: a.Track, channel: int(*a.Channel)}] = caps[*a.Musician]
+		} else {
+			byTrack[a.Track] = caps[*a.Musician]
+		}
+	}
+
+	return func(l lane, channel int) bool {
+		c, ok := byLane[l]
+		if !ok {
+			c = byTrack[l.track]
+		}
+		return c == nil || len(c.Channels) == 0 || slices.Contains(c.Channels, uint8(channel))
+	}
+}
+
+// reserve keeps the channels of the isolation for the performance until
+// it ends. Must be called with the lock held.
+func (b *baton) reserve(id data.ID, iso isolation) {
+	for _, channel := range iso.channels {
+		b.channels[channel] = id
+	}
+}
+
+// free gives back the channels of the performance. Must be called with
+// the lock held.
+func (b *baton) free(id data.ID) {
+	for channel, owner := range b.channels {
+		if owner == id {
+			b.channels[channel] = data.ID{}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package baton
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+// sharedChannelMusic returns a piano and strings both on the first
+// channel, the strings also playing on the second one, and drums
+func sharedChannelMusic(t *testing.T) *bytes.Buffer {
+	var tempo, piano, strings, drums smf.Track
+	tempo.Add(0, smf.MetaTempo(240))
+	tempo.Close(0)
+	piano.Add(0, midi.NoteOn(0, 60, 100))
+	piano.Add(960, midi.NoteOff(0, 60))
+	piano.Close(0)
+	strings.Add(0, midi.ProgramChange(0, 48))
+	strings.Add(0, midi.NoteOn(0, 64, 100))
+	strings.Add(0, midi.NoteOn(1, 67, 100))
+	strings.Add(960, midi.NoteOff(0, 64))
+	strings.Add(0, midi.NoteOff(1, 67))
+	strings.Close(0)
+	drums.Add(0, midi.NoteOn(data.PercussionChannel, 36, 100))
+	drums.Add(960, midi.NoteOff(data.PercussionChannel, 36))
+	drums.Close(0)
+	return writeMusic(t, tempo, piano, strings, drums)
+}
+
+func TestIsolate(t *testing.T) {
+	seq, err := readSequence(sharedChannelMusic(t))
+	require.NoError(t, err)
+
+	// The piano keeps its channel, the strings take the lowest channels
+	// left and the drums stay on theirs
+	var busy [16]bool
+	busy[1] = true
+	iso, err := isolate(seq, busy, anyChannel)
+	require.NoError(t, err)
+	assert.Equal(t, map[lane]uint8{
+		{track: 1, channel: 0}: 0,
+		{track: 2, channel: 0}: 2,
+		{track: 2, channel: 1}: 3,
+	}, iso.channels)
+
+	// The parts playing before setting a program start with the default
+	// one
+	assert.Equal(t, []event{
+		{track: 1, msg: midi.ProgramChange(0, 0)},
+		{track: 2, msg: midi.ProgramChange(1, 0)},
+	}, iso.programs)
+
+	assert.Equal(t, []byte(midi.NoteOn(0, 60, 100)), iso.rewrite(1, midi.NoteOn(0, 60, 100)))
+	assert.Equal(t, []byte(midi.ProgramChange(2, 48)), iso.rewrite(2, midi.ProgramChange(0, 48)))
+	assert.Equal(t, []byte(midi.NoteOff(3, 67)), iso.rewrite(2, midi.NoteOff(1, 67)))
+	assert.Equal(t, []byte(midi.NoteOn(9, 36, 100)), iso.rewrite(3, midi.NoteOn(9, 36, 100)))
+	assert.Equal(t, []byte(midi.NoteOn(0, 60, 100)), isolation{}.rewrite(2, midi.NoteOn(0, 60, 100)))
+
+	// Three parts do not fit in two channels
+	for channel := range busy {
+		busy[channel] = channel > 1
+	}
+	_, err = isolate(seq, busy, anyChannel)
+	assert.ErrorIs(t, err, data.ErrChannelConflict)
+
+	// The parts move to channels their musicians play, those playing the
+	// fewest choosing first
+	busy = [16]bool{1: true}
+	strings := &data.Capabilities{Channels: []uint8{0, 1, 2, 3, 5}}
+	second := &data.Capabilities{Channels: []uint8{1, 2}}
+	plays := func(l lane, channel int) bool {
+		c := strings
+		if l.channel == 1 {
+			c = second
+		}
+		return l.track != 2 || slices.Contains(c.Channels, uint8(channel))
+	}
+	iso, err = isolate(seq, busy, plays)
+	require.NoError(t, err)
+	assert.Equal(t, map[lane]uint8{
+		{track: 1, channel: 0}: 0,
+		{track: 2, channel: 0}: 3,
+		{track: 2, channel: 1}: 2,
+	}, iso.channels)
+
+	// A musician playing no free channel cannot be isolated
+	busy = [16]bool{1: true, 2: true}
+	_, err = isolate(seq, busy, plays)
+	assert.ErrorIs(t, err, data.ErrChannelConflict)
+}
+
+func TestPlayedChannels(t *testing.T) {
+	violin := &member{musician: data.Musician{Id: data.GenId(), Capabilities: &data.Capabilities{Channels: []uint8{0}}}}
+	piano := &member{musician: data.Musician{Id: data.GenId()}}
+	first := uint8(0)
+	plays := playedChannels(data.PlayPlan{Tracks: []data.TrackAssignment{
+		{Track: 1, Musician: &piano.musician.Id},
+		{Track: 2, Channel: &first, Musician: &violin.musician.Id},
+		{Track: 3},
+	}}, []*member{violin, piano})
+
+	assert.True(t, plays(lane{track: 1, channel: 0}, 5))
+	assert.True(t, plays(lane{track: 2, channel: 0}, 0))
+	assert.False(t, plays(lane{track: 2, channel: 0}, 3))
+	assert.True(t, plays(lane{track: 3, channel: 0}, 3))
+
+	// A lane handed over goes to a musician playing its output channel
+	perf := newPerformance("test.mid", sequence{tracks: 3, usage: make([]trackUsage, 3)}, data.DefaultInterpretation)
+	perf.usage[2] = trackUsage{channels: [16]int{0: 1}}
+	perf.isolation = isolation{channels: map[lane]uint8{{track: 2, channel: 0}: 3}}
+	assert.Equal(t, []int{3}, perf.needs(lane{track: 2, channel: allChannels}).channels)
+	perf.addPart(newPart(violin))
+	assert.Nil(t, perf.idlePart([]lane{{track: 2, channel: allChannels}}))
+}
+
+func anyChannel(lane, int) bool {
+	return true
+}
+
+func TestChannelIsolation(t *testing.T) {
+	b := New(logging.Base(), config.Playback{
+		LookAhead:       typ.Duration(10 * time.Millisecond),
+		MaxClockSkew:    typ.Duration(time.Second),
+		MusiciansWait:   typ.Duration(50 * time.Millisecond),
+		IsolateChannels: true,
+	}, nil).(*baton)
+
+	cli := &recordingClient{}
+	addMusician(b, systemClock{}, cli)
+
+	// The channels taken by another performance are left to it
+	other := data.GenId()
+	b.channels[0] = other
+	b.channels[1] = other
+
+	opts := data.DefaultPlayOptions
+	opts.DryRun = true
+	opts.Exclude = []int{2}
+	_, err := b.Play("test.mid", sharedChannelMusic(t), opts)
+	require.NoError(t, err)
+	for channel := 2; channel < 16; channel++ {
+		b.channels[channel] = other
+	}
+	_, err = b.Play("test.mid", sharedChannelMusic(t), opts)
+	assert.ErrorIs(t, err, data.ErrChannelConflict)
+	b.free(other)
+
+	p, err := b.Play("test.mid", sharedChannelMusic(t), data.DefaultPlayOptions)
+	require.NoError(t, err)
+	b.mu.Lock()
+	assert.Equal(t, [16]data.ID{0: p.Performance, 1: p.Performance, 2: p.Performance}, b.channels)
+	b.mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, [16]data.ID{}, b.channels)
+
+	// Each part sounds on its own channel, the parts without program
+	// with the default one
+	msgs := cli.messages()
+	require.Len(t, msgs, 11)
+	assert.ElementsMatch(t, []midi.Message{
+		midi.ProgramChange(0, 0),
+		midi.ProgramChange(1, 0),
+		midi.ProgramChange(2, 48),
+		midi.NoteOn(0, 60, 100),
+		midi.NoteOn(2, 64, 100),
+		midi.NoteOn(1, 67, 100),
+		midi.NoteOn(9, 36, 100),
+		midi.NoteOff(0, 60),
+		midi.NoteOff(2, 64),
+		midi.NoteOff(1, 67),
+		midi.NoteOff(9, 36),
+	}, msgs)
+	assert.Equal(t, []midi.Message{midi.ProgramChange(0, 0), midi.ProgramChange(1, 0)}, msgs[:2])
+}
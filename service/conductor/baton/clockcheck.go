@@ -0,0 +1,74 @@
+package baton
+
+import (
+	"sync"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/util/clocksync"
+)
+
+// clockSamples is the number of clock exchanges made with a musician to
+// measure its skew. The exchange with the lowest round trip is kept.
+const clockSamples = 3
+
+// measureClock estimates the offset of the musician clock from the
+// conductor clock
+func (l *link) measureClock() (clocksync.Measurement, error) {
+	ms := make([]clocksync.Measurement, 0, clockSamples)
+	for i := 0; i < clockSamples; i++ {
+		originate := time.Now()
+		sample, err := l.cli.Clock(originate)
+		if err != nil {
+			return clocksync.Measurement{}, err
+		}
+		ms = append(ms, clocksync.Measure(originate, sample.Receive, sample.Transmit, time.Now()))
+	}
+
+	return clocksync.Best(ms), nil
+}
+
+// checkClocks measures the clock skew of the musicians. It fails with
+// data.ErrClockSkew when a musician is skewed above the configured
+// threshold and the baton is configured to refuse playing.
+func (b *baton) checkClocks(musicians []*member) error {
+	skews := make([]time.Duration, len(musicians))
+	errs := make([]error, len(musicians))
+
+	var wg sync.WaitGroup
+	for i := range musicians {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m, err := musicians[i].link.measureClock()
+			skews[i], errs[i] = m.Offset, err
+		}(i)
+	}
+	wg.Wait()
+
+	maxSkew := b.cfg.MaxClockSkew.Duration()
+	skewed := false
+	for i := range musicians {
+		log := b.log.With("musician", musicians[i].musician.Id.Hex())
+		if errs[i] != nil {
+			log.With("error", errs[i]).Warn("measuring musician clock")
+			continue
+		}
+
+		if skews[i].Abs() <= maxSkew {
+			continue
+		}
+
+		skewed = true
+		log.
+			With("skew", skews[i]).
+			With("max", maxSkew).
+			Warn("musician clock skewed")
+	}
+
+	if skewed && b.cfg.RefuseOnClockSkew {
+		return data.ErrClockSkew
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package baton
+
+import (
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/daemon/musiciand/api/client/v1"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+)
+
+// skewedClient is a musician whose clock is off by a fixed offset
+type skewedClient struct {
+	client.ClientDaemon
+	offset time.Duration
+}
+
+func (c skewedClient) Clock(originate time.Time) (data.ClockSample, error) {
+	now := time.Now().Add(c.offset)
+	return data.ClockSample{Originate: originate, Receive: now, Transmit: now}, nil
+}
+
+func skewedMember(offset time.Duration) *member {
+	m := data.Musician{Id: data.GenId(), Address: "http://skewed"}
+	return &member{
+		musician: m,
+		link: &link{
+			log:      logging.Base(),
+			clock:    systemClock{},
+			musician: m,
+			cli:      skewedClient{offset: offset},
+		},
+	}
+}
+
+func TestCheckClocks(t *testing.T) {
+	cfg := config.Playback{
+		MaxClockSkew:      typ.Duration(20 * time.Millisecond),
+		RefuseOnClockSkew: true,
+	}
+	b := &baton{log: logging.Base(), cfg: cfg}
+
+	synced := []*member{skewedMember(0), skewedMember(-5 * time.Millisecond)}
+	assert.NoError(t, b.checkClocks(synced))
+
+	skewed := append(synced, skewedMember(time.Second))
+	assert.ErrorIs(t, b.checkClocks(skewed), data.ErrClockSkew)
+
+	// Only warn when not configured to refuse
+	b.cfg.RefuseOnClockSkew = false
+	assert.NoError(t, b.checkClocks(skewed))
+}
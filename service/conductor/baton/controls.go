@@ -0,0 +1,76 @@
+package baton
+
+import "gitlab.com/gomidi/midi/v2"
+
+const (
+	ccVolume      = 7
+	ccPan         = 10
+	ccAllSoundOff = 120
+	ccAllNotesOff = 123
+)
+
+// channelControls holds the last messages setting the sound of a channel
+type channelControls struct {
+	program []byte
+	volume  []byte
+	pan     []byte
+	bend    []byte
+}
+
+// controls keeps the sound settings of each channel of each track, so that
+// a musician taking a lane over during a performance sounds like the one
+// it replaces
+type controls map[int]*[16]channelControls
+
+// update records the message if it changes the sound of its channel
+func (c controls) update(track int, msg []byte) {
+	m := midi.Message(msg)
+
+	var channel, value, controller uint8
+	var relative int16
+	var absolute uint16
+	switch {
+	case m.GetProgramChange(&channel, &value):
+		c.channel(track, channel).program = msg
+	case m.GetControlChange(&channel, &controller, &value):
+		switch controller {
+		case ccVolume:
+			c.channel(track, channel).volume = msg
+		case ccPan:
+			c.channel(track, channel).pan = msg
+		}
+	case m.GetPitchBend(&channel, &relative, &absolute):
+		c.channel(track, channel).bend = msg
+	}
+}
+
+func (c controls) channel(track int, channel uint8) *channelControls {
+	channels, ok := c[track]
+	if !ok {
+		channels = new([16]channelControls)
+		c[track] = channels
+	}
+	return &channels[channel]
+}
+
+// state returns the messages restoring the sound of the channels of a
+// lane: program change first, then volume, pan and pitch bend
+func (c controls) state(l lane) [][]byte {
+	channels, ok := c[l.track]
+	if !ok {
+		return nil
+	}
+
+	var msgs [][]byte
+	for channel, cc := range channels {
+		if l.channel != allChannels && l.channel != channel {
+			continue
+		}
+		for _, msg := range [][]byte{cc.program, cc.volume, cc.pan, cc.bend} {
+			if msg != nil {
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+	return msgs
+}
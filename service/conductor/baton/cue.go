@@ -0,0 +1,205 @@
+package baton
+
+import (
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+)
+
+// score is a music read and checked against its play options, ready to be
+// played
+type score struct {
+	music    string
+	seq      sequence
+	opts     data.PlayOptions
+	bnds     bounds
+	excluded []int
+}
+
+// readScore reads the music from r and resolves the options in it
+func (b *baton) readScore(music string, r io.Reader, opts data.PlayOptions) (score, error) {
+	seq, err := readSequence(r)
+	if err != nil {
+		b.log.With("error", err).Error("reading music")
+		return score{}, data.ErrInvalidMusic
+	}
+
+	bnds, err := boundsOf(seq, opts)
+	if err != nil {
+		return score{}, err
+	}
+	excluded, err := excludedTracks(seq, opts)
+	if err != nil {
+		return score{}, err
+	}
+
+	return score{
+		music:    music,
+		seq:      seq.without(excluded),
+		opts:     opts,
+		bnds:     bnds,
+		excluded: excluded,
+	}, nil
+}
+
+// planScore distributes the tracks of the score among the musicians
+func planScore(s score, musicians []*member) (data.PlayPlan, error) {
+	roster := make([]data.Musician, len(musicians))
+	for i := range musicians {
+		roster[i] = musicians[i].musician
+	}
+	p, err := plan(s.seq.usage, roster, s.opts.Mapping)
+	if err != nil {
+		return data.PlayPlan{}, err
+	}
+
+	p.Skipped = slices.DeleteFunc(p.Skipped, func(track int) bool {
+		return slices.Contains(s.excluded, track)
+	})
+	p.Excluded = s.excluded
+	p.Start, p.End = s.bnds.start, s.bnds.end
+	return p, nil
+}
+
+// perform returns the performance of the score by the section following
+// the plan, with a part for each musician. The musicians without lane
+// stand by to replace those leaving.
+func (b *baton) perform(s score, id data.ID, section *data.Section, musicians []*member, p data.PlayPlan, iso isolation) (*performance, []*part) {
+	perf := newPerformance(s.music, s.seq, s.opts.Interpretation)
+	perf.id = id
+	perf.clock = b.clock
+	perf.section = section
+	perf.isolation = iso
+	parts := make([]*part, len(musicians))
+	for i := range musicians {
+		parts[i] = newPart(musicians[i])
+		perf.addPart(parts[i])
+	}
+	perf.follow(p)
+	return perf, parts
+}
+
+// Cue reads the music to play when the performance ends, replacing the
+// music cued before. The music is played by the section of the
+// performance.
+func (b *baton) Cue(id data.ID, music string, r io.Reader, opts data.PlayOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if opts.DryRun {
+		return data.ErrInvalidPlayOptions
+	}
+	b.mu.Lock()
+	_, err := b.find(id)
+	b.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	s, err := b.readScore(music, r, opts)
+	if err != nil {
+		return err
+	}
+
+	// The performance may have ended while the music was read
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	perf, err := b.find(id)
+	if err != nil {
+		return err
+	}
+	perf.cued = &s
+	return nil
+}
+
+// Uncue forgets the music cued after the performance
+func (b *baton) Uncue(id data.ID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if perf, err := b.find(id); err == nil {
+		perf.cued = nil
+	}
+}
+
+// hasCue tells whether a music is cued after the performance
+func (b *baton) hasCue(perf *performance) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return perf.cued != nil
+}
+
+// staging is a performance ready to be started
+type staging struct {
+	score score
+	perf  *performance
+	parts []*part
+	// at is when the music starts
+	at time.Time
+}
+
+// start publishes the start of the performance and plays it
+func (b *baton) start(st staging) {
+	b.publishPlayback(data.EventPlaybackStarted, st.perf)
+
+	go b.play(st.score, st.parts, st.perf, st.at)
+	go b.tickPosition(st.perf, st.score.seq.bars)
+}
+
+// segue stages the music cued after the performance, if any, to start
+// right after it ends at the given time. The new performance keeps the id
+// and the section of the one it follows. It returns false when there was
+// no music cued or it could not be played with the musicians available.
+func (b *baton) segue(perf *performance, end time.Time) (staging, bool) {
+	b.mu.Lock()
+	s := perf.cued
+	perf.cued = nil
+	musicians := b.available(perf.section)
+	b.mu.Unlock()
+
+	if s == nil {
+		return staging{}, false
+	}
+
+	log := b.log.With("music", s.music)
+	if len(musicians) < s.opts.MinMusicians {
+		log.With("registered", len(musicians)).Warn("not enough musicians to play the music cued")
+		return staging{}, false
+	}
+	p, err := planScore(*s, musicians)
+	if err != nil {
+		log.With("error", err).Warn("planning the music cued")
+		return staging{}, false
+	}
+
+	// The music cued takes over the channels of the music it follows
+	b.mu.Lock()
+	b.free(perf.id)
+	iso, err := b.isolation(perf.id, s.seq, p, musicians)
+	if err == nil {
+		b.reserve(perf.id, iso)
+	}
+	b.mu.Unlock()
+	if err != nil {
+		log.With("error", err).Warn("isolating the channels of the music cued")
+		return staging{}, false
+	}
+
+	next, parts := b.perform(*s, perf.id, perf.section, musicians, p, iso)
+
+	// The musicians keep their mix, the tracks are those of another music
+	perf.mu.Lock()
+	maps.Copy(next.mixer.musicians, perf.mixer.musicians)
+	perf.mu.Unlock()
+
+	// A music cued too late to follow without gap starts at once
+	at := end
+	if now := b.clock.Now(); at.Before(now) {
+		at = now
+	}
+
+	log.Info("Playing music cued")
+	return staging{score: *s, perf: next, parts: parts, at: at}, true
+}
@@ -0,0 +1,99 @@
+package baton
+
+import (
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/broadcast"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+func TestCue(t *testing.T) {
+	events := broadcast.New[data.Event]()
+	sub := events.Subscribe(64)
+	b := New(logging.Base(), config.Playback{
+		LookAhead:    typ.Duration(20 * time.Millisecond),
+		MaxClockSkew: typ.Duration(time.Second),
+	}, events).(*baton)
+	cli := &recordingClient{}
+	addMusician(b, systemClock{}, cli)
+
+	// Nothing can be cued before a music is played
+	assert.ErrorIs(t, b.Cue(data.ID{}, "second.mid", loopMusic(t), data.DefaultPlayOptions), data.ErrNoMusicPlaying)
+
+	// The first note of the melody, four times faster
+	first := data.DefaultPlayOptions
+	first.Interpretation.Tempo = 4
+	first.Exclude = []int{2}
+	first.End = &data.Position{Bar: 1, Beat: 2}
+	p, err := b.Play("first.mid", loopMusic(t), first)
+	require.NoError(t, err)
+
+	// Then its second note
+	second := first
+	second.Start = &data.Position{Bar: 1, Beat: 2}
+	second.End = &data.Position{Bar: 1, Beat: 4}
+	assert.ErrorIs(t, b.Cue(p.Performance, "second.mid", loopMusic(t), data.PlayOptions{}), data.ErrInvalidTempo)
+	assert.ErrorIs(t, b.Cue(data.GenId(), "second.mid", loopMusic(t), second), data.ErrPerformanceNotFound)
+	require.NoError(t, b.Cue(p.Performance, "second.mid", loopMusic(t), second))
+
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, 2*time.Second, 5*time.Millisecond)
+	// The second music goes on with the performance of the first
+	assert.Equal(t, "second.mid", status(t, b).Music)
+	assert.True(t, status(t, b).Completed)
+	assert.Equal(t, p.Performance, status(t, b).Performance)
+
+	// The second music starts when the first ends, with the sound of its
+	// channel, each part silencing the notes it cut
+	assert.Equal(t, []midi.Message{
+		midi.ProgramChange(0, 5),
+		midi.NoteOn(0, 60, 100),
+		midi.NoteOff(0, 60),
+		midi.ControlChange(0, ccAllNotesOff, 0),
+		midi.ControlChange(0, ccAllSoundOff, 0),
+		midi.ProgramChange(0, 5),
+		midi.NoteOn(0, 62, 100),
+		midi.NoteOff(0, 62),
+		midi.ControlChange(0, ccAllNotesOff, 0),
+		midi.ControlChange(0, ccAllSoundOff, 0),
+	}, cli.messages())
+	cli.mu.Lock()
+	notes := cli.notes
+	cli.mu.Unlock()
+	assert.InDelta(t, 250*time.Millisecond, notes[6].At.Sub(notes[1].At), float64(time.Millisecond))
+
+	// A stopped music does not play the music cued
+	stopped, err := b.Play("first.mid", loopMusic(t), data.DefaultPlayOptions)
+	require.NoError(t, err)
+	require.NoError(t, b.Cue(stopped.Performance, "second.mid", loopMusic(t), second))
+	require.NoError(t, b.Stop(stopped.Performance))
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "first.mid", status(t, b).Music)
+
+	sub.Close()
+	var playback []data.PlaybackEvent
+	for ev := range sub.C() {
+		if p, ok := ev.Data.(data.PlaybackEvent); ok {
+			p.Duration = 0
+			playback = append(playback, p)
+		}
+	}
+	assert.Equal(t, []data.PlaybackEvent{
+		{Performance: p.Performance, Music: "first.mid"},
+		{Performance: p.Performance, Music: "first.mid", Completed: true, Next: "second.mid"},
+		{Performance: p.Performance, Music: "second.mid"},
+		{Performance: p.Performance, Music: "second.mid", Completed: true},
+		{Performance: stopped.Performance, Music: "first.mid"},
+		{Performance: stopped.Performance, Music: "first.mid"},
+	}, playback)
+}
@@ -0,0 +1,104 @@
+package baton
+
+import (
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+// timeline maps the positions in the music to the time they are played,
+// the music moving at tempo from pos played at at
+type timeline struct {
+	at    time.Time
+	pos   time.Duration
+	tempo float64
+}
+
+// time returns the time the position of the music is played
+func (t timeline) time(pos time.Duration) time.Time {
+	return t.at.Add(time.Duration(float64(pos-t.pos) / t.tempo))
+}
+
+// position returns the position of the music played at a time
+func (t timeline) position(at time.Time) time.Duration {
+	return t.pos + time.Duration(float64(at.Sub(t.at))*t.tempo)
+}
+
+// shift delays the rest of the music, after a pause
+func (t timeline) shift(d time.Duration) timeline {
+	t.at = t.at.Add(d)
+	return t
+}
+
+// withTempo changes the tempo from the time at, the music played before
+// is unchanged
+func (t timeline) withTempo(at time.Time, tempo float64) timeline {
+	return timeline{at: at, pos: t.position(at), tempo: tempo}
+}
+
+// transposer shifts the notes by a number of semitones. The end of a note
+// is shifted as its start was, so that changing the transposition while
+// notes sound leaves none of them stuck.
+type transposer struct {
+	semitones int
+	// sounding are the keys sent for the notes started and not ended yet,
+	// by their key in the music
+	sounding map[voice][]uint8
+}
+
+func newTransposer(semitones int) *transposer {
+	return &transposer{
+		semitones: semitones,
+		sounding:  make(map[voice][]uint8),
+	}
+}
+
+// transpose returns the message shifted, or false when the note falls out
+// of the MIDI keys and must not be played. The notes of the percussion
+// channel are drums, they are never shifted.
+func (t *transposer) transpose(track int, msg []byte) ([]byte, bool) {
+	var channel, key, velocity uint8
+	m := midi.Message(msg)
+	start := m.GetNoteStart(&channel, &key, &velocity)
+	end := !start && m.GetNoteEnd(&channel, &key)
+	touch := !start && !end && m.GetPolyAfterTouch(&channel, &key, &velocity)
+	if !start && !end && !touch || channel == data.PercussionChannel {
+		return msg, true
+	}
+
+	v := voice{track: track, channel: channel, key: key}
+	keys := t.sounding[v]
+	var shifted uint8
+	switch {
+	case start:
+		k := int(key) + t.semitones
+		if k < 0 || k > 127 {
+			return nil, false
+		}
+		shifted = uint8(k)
+		t.sounding[v] = append(keys, shifted)
+	case len(keys) == 0:
+		// The start of the note was not played
+		return nil, false
+	case end:
+		shifted = keys[0]
+		if len(keys) == 1 {
+			delete(t.sounding, v)
+		} else {
+			t.sounding[v] = keys[1:]
+		}
+	default:
+		shifted = keys[len(keys)-1]
+	}
+
+	out := make([]byte, len(msg))
+	copy(out, msg)
+	out[1] = shifted
+	return out, true
+}
+
+// reset forgets the sounding notes once they were silenced
+func (t *transposer) reset() {
+	clear(t.sounding)
+}
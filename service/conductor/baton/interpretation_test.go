@@ -0,0 +1,145 @@
+package baton
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+func TestTimeline(t *testing.T) {
+	start := time.Now()
+	tl := timeline{at: start, tempo: 2}
+	assert.Equal(t, start.Add(time.Second), tl.time(2*time.Second))
+	assert.Equal(t, 2*time.Second, tl.position(start.Add(time.Second)))
+
+	// Slowing down after a second of music leaves the first second as is
+	tl = tl.withTempo(start.Add(time.Second), 0.5)
+	assert.Equal(t, start.Add(time.Second), tl.time(2*time.Second))
+	assert.Equal(t, start.Add(3*time.Second), tl.time(3*time.Second))
+
+	tl = tl.shift(time.Second)
+	assert.Equal(t, start.Add(4*time.Second), tl.time(3*time.Second))
+}
+
+func TestTransposer(t *testing.T) {
+	tr := newTransposer(2)
+
+	on, ok := tr.transpose(0, midi.NoteOn(0, 60, 100))
+	require.True(t, ok)
+	assert.Equal(t, []byte(midi.NoteOn(0, 62, 100)), on)
+
+	// The note ends as it started even when the transposition changed
+	tr.semitones = -1
+	touch, ok := tr.transpose(0, midi.PolyAfterTouch(0, 60, 50))
+	require.True(t, ok)
+	assert.Equal(t, []byte(midi.PolyAfterTouch(0, 62, 50)), touch)
+	off, ok := tr.transpose(0, midi.NoteOn(0, 60, 0))
+	require.True(t, ok)
+	assert.Equal(t, []byte(midi.NoteOn(0, 62, 0)), off)
+
+	// The same key started twice ends in order
+	_, _ = tr.transpose(1, midi.NoteOn(1, 64, 100))
+	tr.semitones = 3
+	_, _ = tr.transpose(1, midi.NoteOn(1, 64, 100))
+	off, _ = tr.transpose(1, midi.NoteOff(1, 64))
+	assert.Equal(t, []byte(midi.NoteOff(1, 63)), off)
+	off, _ = tr.transpose(1, midi.NoteOff(1, 64))
+	assert.Equal(t, []byte(midi.NoteOff(1, 67)), off)
+	assert.Empty(t, tr.sounding)
+
+	// Notes out of the MIDI keys are not played, nor their end
+	_, ok = tr.transpose(0, midi.NoteOn(0, 126, 100))
+	assert.False(t, ok)
+	_, ok = tr.transpose(0, midi.NoteOff(0, 126))
+	assert.False(t, ok)
+
+	// Drums and other messages are left as they are
+	drum, ok := tr.transpose(0, midi.NoteOn(data.PercussionChannel, 36, 100))
+	require.True(t, ok)
+	assert.Equal(t, []byte(midi.NoteOn(data.PercussionChannel, 36, 100)), drum)
+	program, ok := tr.transpose(0, midi.ProgramChange(0, 5))
+	require.True(t, ok)
+	assert.Equal(t, []byte(midi.ProgramChange(0, 5)), program)
+}
+
+func TestPlayInterpretation(t *testing.T) {
+	b := New(logging.Base(), config.Playback{
+		LookAhead:    typ.Duration(10 * time.Millisecond),
+		MaxClockSkew: typ.Duration(time.Second),
+	}, nil).(*baton)
+
+	assert.ErrorIs(t, b.SetTempo(data.ID{}, 2), data.ErrNoMusicPlaying)
+	assert.ErrorIs(t, b.SetTempo(data.ID{}, 10), data.ErrInvalidTempo)
+	assert.ErrorIs(t, b.Transpose(data.ID{}, 30), data.ErrInvalidTranspose)
+	assert.Equal(t, data.DefaultInterpretation, status(t, b).Interpretation)
+
+	cli := &recordingClient{}
+	addMusician(b, systemClock{}, cli)
+
+	// A note of 400ms then a note of 800ms
+	file := smf.New()
+	file.TimeFormat = smf.MetricTicks(960)
+	var tr smf.Track
+	tr.Add(0, smf.MetaTempo(150))
+	tr.Add(0, midi.NoteOn(0, 60, 100))
+	tr.Add(960, midi.NoteOff(0, 60))
+	tr.Add(0, midi.NoteOn(0, 64, 100))
+	tr.Add(1920, midi.NoteOff(0, 64))
+	tr.Close(0)
+	require.NoError(t, file.Add(tr))
+	var buf bytes.Buffer
+	_, err := file.WriteTo(&buf)
+	require.NoError(t, err)
+
+	_, err = b.Play("test.mid", &buf, data.PlayOptions{Loops: 1})
+	assert.ErrorIs(t, err, data.ErrInvalidTempo)
+
+	// Played twice faster and a tone higher
+	opts := data.DefaultPlayOptions
+	opts.Interpretation = data.Interpretation{Tempo: 2, Transpose: 2}
+	_, err = b.Play("test.mid", &buf, opts)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(cli.messages()) == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, data.Interpretation{Tempo: 2, Transpose: 2}, status(t, b).Interpretation)
+
+	// The second note is transposed again, the first one ends as it
+	// started
+	require.NoError(t, b.Transpose(data.ID{}, -4))
+	assert.Eventually(t, func() bool {
+		return len(cli.messages()) == 3
+	}, time.Second, 5*time.Millisecond)
+
+	// The second note is played at half of its tempo
+	require.NoError(t, b.SetTempo(data.ID{}, 0.5))
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, 3*time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, []midi.Message{
+		midi.NoteOn(0, 62, 100),
+		midi.NoteOff(0, 62),
+		midi.NoteOn(0, 60, 100),
+		midi.NoteOff(0, 60),
+	}, cli.messages())
+
+	cli.mu.Lock()
+	notes := cli.notes
+	cli.mu.Unlock()
+	assert.InDelta(t, 200*time.Millisecond, notes[1].At.Sub(notes[0].At), float64(20*time.Millisecond))
+	assert.InDelta(t, 1600*time.Millisecond, notes[3].At.Sub(notes[2].At), float64(100*time.Millisecond))
+
+	st := status(t, b)
+	assert.True(t, st.Completed)
+	assert.Equal(t, data.Interpretation{Tempo: 0.5, Transpose: -4}, st.Interpretation)
+}
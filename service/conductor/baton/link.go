@@ -0,0 +1,319 @@
+package baton
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"crossjoin.com/gorxestra/daemon/musiciand/api/client/v1"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+// streamRetryInterval is how long a link waits before trying to open a
+// new stream after the previous one failed
+const streamRetryInterval = 30 * time.Second
+
+var errLinkClosed = errors.New("musician link closed")
+
+// link delivers notes to a musician. It keeps a note stream open with
+// the musician and falls back to the REST API when streaming fails.
+type link struct {
+	log      logging.Logger
+	musician data.Musician
+	// clock is the baton's, the notes silenced are due from its time
+	clock Clock
+
+	mu          sync.Mutex
+	cli         client.ClientDaemon
+	stream      client.NoteStream
+	streamRetry time.Time
+	seq         uint64
+	closed      bool
+	// sounding are the notes sent to the musician and not ended yet
+	sounding map[tone]int
+	// last is when the last note sent plays on the musician
+	last time.Time
+}
+
+// tone is a note sounding on a channel of a musician
+type tone struct {
+	channel uint8
+	key     uint8
+}
+
+func newLink(log logging.Logger, clk Clock, m data.Musician) (*link, error) {
+	cli, err := client.New(m.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &link{
+		log:      log.With("musician", m.Id.Hex()),
+		musician: m,
+		clock:    clk,
+		mu:       sync.Mutex{},
+		cli:      cli,
+	}, nil
+}
+
+// send delivers a batch of notes to the musician
+func (l *link) send(batch []Note) error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return errLinkClosed
+	}
+
+	// The notes of a musician slow to sound are sent due earlier
+	var latency time.Duration
+	if l.musician.Capabilities != nil {
+		latency = l.musician.Capabilities.Latency
+	}
+
+	notes := make([]data.Note, len(batch))
+	for i := range batch {
+		l.seq++
+		notes[i] = data.Note{
+			Seq:     l.seq,
+			At:      batch[i].at.Add(-latency),
+			Message: batch[i].note,
+		}
+		l.last = maxTime(l.last, notes[i].At)
+		l.hold(notes[i].Message)
+	}
+	l.mu.Unlock()
+
+	// A note started may sound even when the delivery failed, it only
+	// ends once its end was delivered
+	if err := l.deliver(notes); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	for i := range notes {
+		l.unhold(notes[i].Message)
+	}
+	l.mu.Unlock()
+	return nil
+}
+
+// deliver sends the notes on the stream, or through the REST API when
+// the stream fails. A stream timing out fails the delivery, the musician
+// is not reading. The lock is not held while the notes are on their way,
+// the musician orders them by time and sequence number.
+func (l *link) deliver(notes []data.Note) error {
+	stream, cli := l.openStream()
+	if stream != nil {
+		err := stream.Send(notes)
+		if err == nil {
+			return nil
+		}
+
+		l.dropStream(stream)
+		if errors.Is(err, client.ErrStreamTimeout) {
+			l.log.With("error", err).Warn("note stream timed out")
+			return err
+		}
+		l.log.With("error", err).Warn("note stream failed, falling back to REST")
+	}
+
+	for i := range notes {
+		if err := cli.Play(notes[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openStream returns the stream open with the musician, opening it when
+// it is time to try again, and the client of its REST API
+func (l *link) openStream() (client.NoteStream, client.ClientDaemon) {
+	l.mu.Lock()
+	stream, cli := l.stream, l.cli
+	now := l.clock.Now()
+	opening := stream == nil && now.After(l.streamRetry)
+	if opening {
+		// The other deliveries meanwhile go through the REST API
+		l.streamRetry = now.Add(streamRetryInterval)
+	}
+	l.mu.Unlock()
+	if !opening {
+		return stream, cli
+	}
+
+	stream, err := cli.OpenStream()
+	if err != nil {
+		l.log.With("error", err).Warn("opening note stream, falling back to REST")
+		return nil, cli
+	}
+
+	// The link may have been closed or retargeted meanwhile
+	l.mu.Lock()
+	kept := !l.closed && l.stream == nil && l.cli == cli
+	if kept {
+		l.stream = stream
+		l.streamRetry = time.Time{}
+	}
+	l.mu.Unlock()
+	if !kept {
+		l.closeStream(stream)
+		return nil, cli
+	}
+	return stream, cli
+}
+
+// dropStream closes the stream failed, the link tries to open a new one
+// after a while
+func (l *link) dropStream(stream client.NoteStream) {
+	l.mu.Lock()
+	if l.stream == stream {
+		l.stream = nil
+		l.streamRetry = l.clock.Now().Add(streamRetryInterval)
+	}
+	l.mu.Unlock()
+	l.closeStream(stream)
+}
+
+func (l *link) closeStream(stream client.NoteStream) {
+	if err := stream.Close(); err != nil {
+		l.log.With("error", err).Debug("closing note stream")
+	}
+}
+
+// hold records the note started by the message
+func (l *link) hold(msg []byte) {
+	var channel, key, velocity uint8
+	if !midi.Message(msg).GetNoteStart(&channel, &key, &velocity) {
+		return
+	}
+	if l.sounding == nil {
+		l.sounding = make(map[tone]int)
+	}
+	l.sounding[tone{channel: channel, key: key}]++
+}
+
+// unhold forgets the notes ended by the message
+func (l *link) unhold(msg []byte) {
+	var channel, key, controller, value uint8
+	m := midi.Message(msg)
+	switch {
+	case m.GetNoteEnd(&channel, &key):
+		t := tone{channel: channel, key: key}
+		if l.sounding[t] <= 1 {
+			delete(l.sounding, t)
+		} else {
+			l.sounding[t]--
+		}
+	case m.GetControlChange(&channel, &controller, &value) &&
+		(controller == ccAllNotesOff || controller == ccAllSoundOff):
+		maps.DeleteFunc(l.sounding, func(t tone, _ int) bool {
+			return t.channel == channel
+		})
+	}
+}
+
+// silence ends the notes sent to the musician and not ended yet, then
+// turns all notes and sound off on their channels, or on every channel
+// when all is set. The messages play after the notes already sent.
+func (l *link) silence(all bool) error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return errLinkClosed
+	}
+
+	tones := slices.SortedFunc(maps.Keys(l.sounding), func(a, b tone) int {
+		return cmp.Or(cmp.Compare(a.channel, b.channel), cmp.Compare(a.key, b.key))
+	})
+	var channels [16]bool
+	msgs := make([][]byte, 0, len(tones))
+	for _, t := range tones {
+		msgs = append(msgs, midi.NoteOff(t.channel, t.key))
+		channels[t.channel] = true
+	}
+	for channel := range channels {
+		if all || channels[channel] {
+			msgs = append(msgs,
+				midi.ControlChange(uint8(channel), ccAllNotesOff, 0),
+				midi.ControlChange(uint8(channel), ccAllSoundOff, 0))
+		}
+	}
+	if len(msgs) == 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	at := maxTime(l.clock.Now(), l.last)
+	notes := make([]data.Note, len(msgs))
+	for i := range msgs {
+		l.seq++
+		notes[i] = data.Note{Seq: l.seq, At: at, Message: msgs[i]}
+	}
+	l.mu.Unlock()
+
+	if err := l.deliver(notes); err != nil {
+		return err
+	}
+
+	// The notes started meanwhile keep sounding
+	l.mu.Lock()
+	for i := range notes {
+		l.unhold(notes[i].Message)
+	}
+	l.mu.Unlock()
+	return nil
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// retarget sends the next notes to the musician as it registered again,
+// the stream is reopened when its address changed
+func (l *link) retarget(m data.Musician) error {
+	l.mu.Lock()
+	if m.Address == l.musician.Address {
+		l.musician = m
+		l.mu.Unlock()
+		return nil
+	}
+
+	cli, err := client.New(m.Address)
+	if err != nil {
+		l.mu.Unlock()
+		return err
+	}
+
+	stream := l.stream
+	l.stream = nil
+	l.musician = m
+	l.cli = cli
+	l.streamRetry = time.Time{}
+	l.mu.Unlock()
+
+	if stream != nil {
+		l.closeStream(stream)
+	}
+	return nil
+}
+
+// close ends the note stream with the musician
+func (l *link) close() {
+	l.mu.Lock()
+	l.closed = true
+	stream := l.stream
+	l.stream = nil
+	l.mu.Unlock()
+
+	if stream != nil {
+		l.closeStream(stream)
+	}
+}
@@ -0,0 +1,100 @@
+package baton
+
+import (
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/daemon/musiciand/api/client/v1"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+// stalledStream is a note stream of a musician not reading it, its writes
+// time out once released
+type stalledStream struct {
+	sending chan struct{}
+	release chan struct{}
+	closed  chan struct{}
+}
+
+func (s *stalledStream) Send([]data.Note) error {
+	close(s.sending)
+	<-s.release
+	return client.ErrStreamTimeout
+}
+
+func (s *stalledStream) Close() error {
+	close(s.closed)
+	return nil
+}
+
+// streamingClient is a musician reached over a note stream
+type streamingClient struct {
+	recordingClient
+	stream client.NoteStream
+}
+
+func (c *streamingClient) OpenStream() (client.NoteStream, error) {
+	return c.stream, nil
+}
+
+func TestLinkStreamTimeout(t *testing.T) {
+	stream := &stalledStream{
+		sending: make(chan struct{}),
+		release: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+	cli := &streamingClient{stream: stream}
+	m := data.Musician{Id: data.GenId(), Address: "http://streaming"}
+	l := &link{log: logging.Base(), clock: systemClock{}, musician: m, cli: cli}
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- l.send([]Note{{at: time.Now(), note: midi.NoteOn(0, 60, 100)}})
+	}()
+
+	// The link is not locked while the notes are on their way
+	<-stream.sending
+	require.True(t, l.mu.TryLock())
+	l.mu.Unlock()
+
+	// The timeout fails the delivery, without falling back to REST, and
+	// closes the stream
+	close(stream.release)
+	assert.ErrorIs(t, <-sent, client.ErrStreamTimeout)
+	<-stream.closed
+	assert.Nil(t, l.stream)
+	assert.Empty(t, cli.messages())
+
+	// The note started is kept to be silenced later
+	assert.Len(t, l.sounding, 1)
+}
+
+func TestLinkSilenceClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := newManualClock(start)
+	cli := &recordingClient{}
+	m := data.Musician{Id: data.GenId(), Address: "http://recording"}
+	l := &link{log: logging.Base(), clock: clk, musician: m, cli: cli}
+
+	// The silence plays at the time of the baton, after the notes sent
+	require.NoError(t, l.send([]Note{{at: start.Add(-time.Second), note: midi.NoteOn(0, 60, 100)}}))
+	require.NoError(t, l.silence(false))
+	clk.Advance(time.Minute)
+	require.NoError(t, l.send([]Note{{at: start.Add(30 * time.Second), note: midi.NoteOn(0, 62, 100)}}))
+	require.NoError(t, l.silence(false))
+
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	var at []time.Duration
+	for _, n := range cli.notes {
+		at = append(at, n.At.Sub(start))
+	}
+	assert.Equal(t, []time.Duration{
+		-time.Second, 0, 0, 0,
+		30 * time.Second, time.Minute, time.Minute, time.Minute,
+	}, at)
+}
@@ -0,0 +1,239 @@
+package baton
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+
+	"crossjoin.com/gorxestra/data"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+// Default sound of a channel whose music never sets it
+const (
+	defaultVolume = 100
+	defaultPan    = 64
+)
+
+// mixer holds the mixes of the musicians and tracks of a performance, the
+// default mixes being left out
+type mixer struct {
+	musicians map[data.ID]data.Mix
+	tracks    map[int]data.Mix
+}
+
+func newMixer() mixer {
+	return mixer{
+		musicians: make(map[data.ID]data.Mix),
+		tracks:    make(map[int]data.Mix),
+	}
+}
+
+// neutral tells whether every note sounds as written
+func (m mixer) neutral() bool {
+	return len(m.musicians) == 0 && len(m.tracks) == 0
+}
+
+// soloing tells whether a musician or a track is soloed
+func (m mixer) soloing() bool {
+	for _, mix := range m.musicians {
+		if mix.Solo {
+			return true
+		}
+	}
+	for _, mix := range m.tracks {
+		if mix.Solo {
+			return true
+		}
+	}
+	return false
+}
+
+// of returns the mix of the notes of a track sent to a musician: the
+// gains multiply, the pan of the track comes before the one of the musician
+func (m mixer) of(id data.ID, track int) data.Mix {
+	mm, ok := m.musicians[id]
+	if !ok {
+		mm = data.DefaultMix
+	}
+	tm, ok := m.tracks[track]
+	if !ok {
+		tm = data.DefaultMix
+	}
+
+	return data.Mix{
+		Gain: mm.Gain * tm.Gain,
+		Pan:  cmp.Or(tm.Pan, mm.Pan),
+		Mute: mm.Mute || tm.Mute,
+		Solo: mm.Solo || tm.Solo,
+	}
+}
+
+// set changes the mix of the target
+func (m mixer) set(target data.MixTarget, change data.MixChange) {
+	if target.Musician != nil {
+		setMix(m.musicians, *target.Musician, change)
+		return
+	}
+	setMix(m.tracks, *target.Track, change)
+}
+
+func setMix[K comparable](mixes map[K]data.Mix, key K, change data.MixChange) {
+	mix, ok := mixes[key]
+	if !ok {
+		mix = data.DefaultMix
+	}
+	mix = change.Apply(mix)
+	if mix.IsDefault() {
+		delete(mixes, key)
+		return
+	}
+	mixes[key] = mix
+}
+
+// state returns the mixes, ordered by musician and track
+func (m mixer) state() data.Mixer {
+	var st data.Mixer
+	for _, id := range slices.SortedFunc(maps.Keys(m.musicians), func(a, b data.ID) int {
+		return cmp.Compare(a.Hex(), b.Hex())
+	}) {
+		st.Musicians = append(st.Musicians, data.MusicianMix{Musician: id, Mix: m.musicians[id]})
+	}
+	for _, track := range slices.Sorted(maps.Keys(m.tracks)) {
+		st.Tracks = append(st.Tracks, data.TrackMix{Track: track, Mix: m.tracks[track]})
+	}
+	return st
+}
+
+// mixMessage returns the message as the mix sounds it, or false when the
+// message is a note start silenced. The ends of the notes always pass so
+// that none is left sounding.
+func mixMessage(mix data.Mix, soloing bool, msg []byte) ([]byte, bool) {
+	var channel, key, value uint8
+	m := midi.Message(msg)
+	switch {
+	case m.GetNoteStart(&channel, &key, &value):
+		if mix.Mute || (soloing && !mix.Solo) {
+			return nil, false
+		}
+		velocity := scale(value, mix.Gain)
+		if velocity == 0 {
+			return nil, false
+		}
+		return midi.NoteOn(channel, key, velocity), true
+	case m.GetControlChange(&channel, &key, &value):
+		switch {
+		case key == ccVolume:
+			return midi.ControlChange(channel, ccVolume, scale(value, mix.Gain)), true
+		case key == ccPan && mix.Pan != nil:
+			return midi.ControlChange(channel, ccPan, *mix.Pan), true
+		}
+	}
+	return msg, true
+}
+
+// scale multiplies a MIDI value by the gain, within the MIDI bounds
+func scale(value uint8, gain float64) uint8 {
+	return uint8(min(math.Round(float64(value)*gain), 127))
+}
+
+// Mix changes the mix of a musician or a track of the performance. The
+// notes sent from then on follow it, the volume and pan of the channels
+// are sent again at once.
+func (b *baton) Mix(id data.ID, target data.MixTarget, change data.MixChange) (data.Mixer, error) {
+	if err := target.Validate(); err != nil {
+		return data.Mixer{}, err
+	}
+	if err := change.Validate(); err != nil {
+		return data.Mixer{}, err
+	}
+
+	b.mu.Lock()
+	perf, err := b.find(id)
+	b.mu.Unlock()
+	if err != nil {
+		return data.Mixer{}, err
+	}
+	return perf.setMix(target, change)
+}
+
+// mix applies the mixer to the notes sent to the musician and returns
+// them, the note starts silenced left out
+func (p *performance) mix(id data.ID, notes []Note) []Note {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.mixer.neutral() {
+		return notes
+	}
+
+	soloing := p.mixer.soloing()
+	mixed := notes[:0]
+	for _, n := range notes {
+		msg, ok := mixMessage(p.mixer.of(id, n.index), soloing, n.note)
+		if !ok {
+			continue
+		}
+		n.note = msg
+		mixed = append(mixed, n)
+	}
+	return mixed
+}
+
+// setMix changes the mix of a musician or a track of the performance and
+// sends the volume and pan of the lanes it changes to their musicians
+func (p *performance) setMix(target data.MixTarget, change data.MixChange) (data.Mixer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if target.Track != nil && *target.Track >= len(p.usage) {
+		return data.Mixer{}, data.ErrInvalidMix
+	}
+	if target.Musician != nil {
+		if _, ok := p.parts[*target.Musician]; !ok {
+			return data.Mixer{}, data.ErrMusicianNotFound
+		}
+	}
+
+	p.mixer.set(target, change)
+	if p.finished {
+		return p.mixer.state(), nil
+	}
+	for _, l := range p.lanes {
+		pt, ok := p.routes[l]
+		if !ok {
+			continue
+		}
+		if (target.Musician != nil && pt.musician.Id == *target.Musician) ||
+			(target.Track != nil && l.track == *target.Track) {
+			p.queue(pt, l, p.levels(l))
+		}
+	}
+	return p.mixer.state(), nil
+}
+
+// levels returns the messages setting the volume and pan of the channels
+// of a lane, as the music last set them. Must be called with the lock
+// held.
+func (p *performance) levels(l lane) [][]byte {
+	channels := []int{l.channel}
+	if l.channel == allChannels && l.track < len(p.usage) {
+		channels = usedChannels(p.usage[l.track])
+	}
+
+	var msgs [][]byte
+	for _, channel := range channels {
+		var cc channelControls
+		if set, ok := p.controls[l.track]; ok {
+			cc = set[channel]
+		}
+		if cc.volume == nil {
+			cc.volume = midi.ControlChange(uint8(channel), ccVolume, defaultVolume)
+		}
+		if cc.pan == nil {
+			cc.pan = midi.ControlChange(uint8(channel), ccPan, defaultPan)
+		}
+		msgs = append(msgs, cc.volume, cc.pan)
+	}
+	return msgs
+}
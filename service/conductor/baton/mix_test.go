@@ -0,0 +1,104 @@
+package baton
+
+import (
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+func TestMixMessage(t *testing.T) {
+	pan := uint8(20)
+	quiet := data.Mix{Gain: 0.5, Pan: &pan}
+
+	msg, ok := mixMessage(quiet, false, midi.NoteOn(0, 60, 100))
+	assert.True(t, ok)
+	assert.Equal(t, []byte(midi.NoteOn(0, 60, 50)), msg)
+	msg, _ = mixMessage(quiet, false, midi.ControlChange(0, ccVolume, 101))
+	assert.Equal(t, []byte(midi.ControlChange(0, ccVolume, 51)), msg)
+	msg, _ = mixMessage(quiet, false, midi.ControlChange(0, ccPan, 64))
+	assert.Equal(t, []byte(midi.ControlChange(0, ccPan, 20)), msg)
+	msg, _ = mixMessage(data.Mix{Gain: 2}, false, midi.NoteOn(0, 60, 100))
+	assert.Equal(t, []byte(midi.NoteOn(0, 60, 127)), msg)
+
+	// The starts of the notes silenced are left out, not their ends
+	for _, mix := range []data.Mix{{Gain: 0}, {Gain: 1, Mute: true}} {
+		_, ok = mixMessage(mix, false, midi.NoteOn(0, 60, 100))
+		assert.False(t, ok)
+	}
+	_, ok = mixMessage(data.DefaultMix, true, midi.NoteOn(0, 60, 100))
+	assert.False(t, ok)
+	msg, ok = mixMessage(data.Mix{Gain: 1, Mute: true}, true, midi.NoteOff(0, 60))
+	assert.True(t, ok)
+	assert.Equal(t, []byte(midi.NoteOff(0, 60)), msg)
+}
+
+func TestMix(t *testing.T) {
+	b := New(logging.Base(), config.Playback{
+		LookAhead:     typ.Duration(10 * time.Millisecond),
+		MaxClockSkew:  typ.Duration(time.Second),
+		MusiciansWait: typ.Duration(50 * time.Millisecond),
+	}, nil).(*baton)
+
+	cli := &recordingClient{}
+	m := addMusician(b, systemClock{}, cli)
+
+	opts := data.DefaultPlayOptions
+	opts.Interpretation.Tempo = 4
+	p, err := b.Play("test.mid", loopMusic(t), opts)
+	require.NoError(t, err)
+
+	gain, mute := 0.5, true
+	bass := 2
+	_, err = b.Mix(p.Performance, data.MixTarget{}, data.MixChange{Gain: &gain})
+	assert.ErrorIs(t, err, data.ErrInvalidMix)
+	tooLoud := data.MaxGain + 1.0
+	_, err = b.Mix(p.Performance, data.MixTarget{Track: &bass}, data.MixChange{Gain: &tooLoud})
+	assert.ErrorIs(t, err, data.ErrInvalidMix)
+	unknown := data.GenId()
+	_, err = b.Mix(p.Performance, data.MixTarget{Musician: &unknown}, data.MixChange{Gain: &gain})
+	assert.ErrorIs(t, err, data.ErrMusicianNotFound)
+
+	// The musician plays softer, without the bass
+	_, err = b.Mix(p.Performance, data.MixTarget{Track: &bass}, data.MixChange{Mute: &mute})
+	require.NoError(t, err)
+	mixer, err := b.Mix(p.Performance, data.MixTarget{Musician: &m.Id}, data.MixChange{Gain: &gain})
+	require.NoError(t, err)
+	assert.Equal(t, data.Mixer{
+		Musicians: []data.MusicianMix{{Musician: m.Id, Mix: data.Mix{Gain: 0.5}}},
+		Tracks:    []data.TrackMix{{Track: 2, Mix: data.Mix{Gain: 1, Mute: true}}},
+	}, mixer)
+	assert.Equal(t, mixer, status(t, b).Mixer)
+
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, 2*time.Second, 5*time.Millisecond)
+
+	msgs := cli.messages()
+	assert.Contains(t, msgs, midi.ControlChange(0, ccVolume, 50))
+	assert.Contains(t, msgs, midi.ControlChange(1, ccVolume, 50))
+	assert.Contains(t, msgs, midi.NoteOn(0, 62, 50))
+	assert.Contains(t, msgs, midi.NoteOn(0, 64, 50))
+	assert.NotContains(t, msgs, midi.NoteOn(1, 40, 100))
+	assert.NotContains(t, msgs, midi.NoteOn(1, 40, 50))
+
+	_, err = b.Mix(p.Performance, data.MixTarget{Track: &bass}, data.MixChange{Mute: &mute})
+	assert.ErrorIs(t, err, data.ErrPerformanceNotFound)
+}
+
+func TestMixerReset(t *testing.T) {
+	mx := newMixer()
+	mute, track := true, 1
+	mx.set(data.MixTarget{Track: &track}, data.MixChange{Mute: &mute})
+	assert.False(t, mx.neutral())
+
+	// A reset brings the default mix back
+	mx.set(data.MixTarget{Track: &track}, data.MixChange{Reset: true})
+	assert.True(t, mx.neutral())
+}
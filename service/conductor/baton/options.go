@@ -0,0 +1,141 @@
+package baton
+
+import (
+	"slices"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+)
+
+// musiciansPollInterval is how often a music waiting for musicians checks
+// whether they registered
+const musiciansPollInterval = 100 * time.Millisecond
+
+// bounds is the part of the music played and the number of times it is
+// played
+type bounds struct {
+	start time.Duration
+	end   time.Duration
+	loops int
+}
+
+// boundsOf resolves the start and end of the options in the music
+func boundsOf(seq sequence, opts data.PlayOptions) (bounds, error) {
+	b := bounds{end: seq.duration(), loops: opts.Loops}
+	if opts.Start != nil {
+		start, err := seq.at(*opts.Start)
+		if err != nil {
+			return bounds{}, err
+		}
+		b.start = start
+	}
+	if opts.End != nil {
+		end, err := seq.at(*opts.End)
+		if err != nil {
+			return bounds{}, err
+		}
+		b.end = end
+	}
+
+	if b.start >= b.end {
+		return bounds{}, data.ErrInvalidPosition
+	}
+	return b, nil
+}
+
+// last returns the index following the last event played
+func (b bounds) last(seq sequence) int {
+	if b.end >= seq.duration() {
+		return len(seq.events)
+	}
+	return seq.seek(b.end)
+}
+
+// excludedTracks returns the tracks with notes the options leave out
+func excludedTracks(seq sequence, opts data.PlayOptions) ([]int, error) {
+	for _, track := range slices.Concat(opts.Tracks, opts.Exclude) {
+		if track < 0 || track >= seq.tracks {
+			return nil, data.ErrInvalidPlayOptions
+		}
+	}
+
+	var excluded []int
+	for track, u := range seq.usage {
+		if u.events == 0 {
+			continue
+		}
+		if slices.Contains(opts.Exclude, track) ||
+			(len(opts.Tracks) > 0 && !slices.Contains(opts.Tracks, track)) {
+			excluded = append(excluded, track)
+		}
+	}
+
+	// The mapping cannot play a track left out
+	for _, a := range opts.Mapping {
+		if slices.Contains(excluded, a.Track) {
+			return nil, data.ErrInvalidAssignment
+		}
+	}
+	return excluded, nil
+}
+
+// chase sends the sound settings of the channels set before pos at the
+// given time, so that the music starting at pos sounds as if it was
+// played from its beginning
+func chase(perf *performance, seq sequence, pos time.Duration, at time.Time) {
+	for _, ev := range chased(perf.isolation, seq, pos) {
+		perf.dispatch(Note{
+			index: ev.track,
+			at:    at,
+			note:  ev.msg,
+		})
+	}
+}
+
+// chased returns the messages setting the sound of the channels as the
+// events before pos left it. The parts of isolated channels relying on
+// the default program set it first.
+func chased(iso isolation, seq sequence, pos time.Duration) []event {
+	evs := slices.Clone(iso.programs)
+
+	c := make(controls)
+	for _, ev := range seq.events[:seq.seek(pos)] {
+		c.update(ev.track, ev.msg)
+	}
+
+	for track := 0; track < seq.tracks; track++ {
+		for _, msg := range c.state(lane{track: track, channel: allChannels}) {
+			evs = append(evs, event{track: track, msg: msg})
+		}
+	}
+	return evs
+}
+
+// awaitMusicians waits for the number of musicians of the section to be
+// available and picks them for the performance starting. It gives up
+// after the configured wait.
+func (b *baton) awaitMusicians(id data.ID, section *data.Section, count int) ([]*member, error) {
+	deadline := b.clock.Now().Add(b.cfg.MusiciansWait.Duration())
+	for {
+		b.mu.Lock()
+		musicians := b.available(section)
+		enough := len(musicians) >= count
+		if enough {
+			b.pick(id, musicians)
+		}
+		b.mu.Unlock()
+
+		if enough {
+			return musicians, nil
+		}
+		if b.clock.Now().After(deadline) {
+			return nil, data.ErrNotEnoughMusicians
+		}
+
+		b.log.
+			With("registered", len(musicians)).
+			With("expected", count).
+			Debug("waiting for musicians")
+		sleep(b.clock, musiciansPollInterval)
+	}
+}
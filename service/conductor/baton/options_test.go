@@ -0,0 +1,205 @@
+package baton
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+// writeMusic returns a SMF of the tracks at 960 ticks per quarter note
+func writeMusic(t *testing.T, tracks ...smf.Track) *bytes.Buffer {
+	t.Helper()
+	file := smf.New()
+	file.TimeFormat = smf.MetricTicks(960)
+	for _, tr := range tracks {
+		require.NoError(t, file.Add(tr))
+	}
+	var buf bytes.Buffer
+	_, err := file.WriteTo(&buf)
+	require.NoError(t, err)
+	return &buf
+}
+
+func TestSequenceAt(t *testing.T) {
+	// A bar of 3/4 then bars of 6/8, at 60 bpm
+	var tr smf.Track
+	tr.Add(0, smf.MetaTempo(60))
+	tr.Add(0, smf.MetaMeter(3, 4))
+	tr.Add(3*960, smf.MetaMeter(6, 8))
+	tr.Close(3 * 960)
+	seq, err := readSequence(writeMusic(t, tr))
+	require.NoError(t, err)
+
+	for _, c := range []struct {
+		pos  data.Position
+		want time.Duration
+	}{
+		{pos: data.Position{Time: 1500 * time.Millisecond}, want: 1500 * time.Millisecond},
+		{pos: data.Position{Bar: 1, Beat: 1}, want: 0},
+		{pos: data.Position{Bar: 1, Beat: 3}, want: 2 * time.Second},
+		{pos: data.Position{Bar: 2, Beat: 1}, want: 3 * time.Second},
+		{pos: data.Position{Bar: 2, Beat: 4}, want: 4500 * time.Millisecond},
+	} {
+		at, err := seq.at(c.pos)
+		require.NoError(t, err, c.pos)
+		assert.Equal(t, c.want, at, c.pos)
+	}
+
+	for _, pos := range []data.Position{
+		{Time: time.Hour},
+		{Bar: 3, Beat: 1},
+		{Bar: 1, Beat: 4},
+		{Bar: 2, Beat: 0},
+	} {
+		_, err := seq.at(pos)
+		assert.ErrorIs(t, err, data.ErrInvalidPosition, pos)
+	}
+}
+
+// loopMusic is a tempo track, a track on channel 0 and a track on
+// channel 1, at 60 bpm
+func loopMusic(t *testing.T) *bytes.Buffer {
+	var tempo, melody, bass smf.Track
+	tempo.Add(0, smf.MetaTempo(60))
+	tempo.Close(0)
+	melody.Add(0, midi.ProgramChange(0, 5))
+	melody.Add(0, midi.NoteOn(0, 60, 100))
+	melody.Add(960, midi.NoteOff(0, 60))
+	melody.Add(0, midi.NoteOn(0, 62, 100))
+	melody.Add(2*960, midi.NoteOff(0, 62))
+	melody.Add(0, midi.NoteOn(0, 64, 100))
+	melody.Add(960, midi.NoteOff(0, 64))
+	melody.Close(0)
+	bass.Add(1440, midi.NoteOn(1, 40, 100))
+	bass.Add(960, midi.NoteOff(1, 40))
+	bass.Close(0)
+	return writeMusic(t, tempo, melody, bass)
+}
+
+func TestPlayOptions(t *testing.T) {
+	b := New(logging.Base(), config.Playback{
+		MaxClockSkew:  typ.Duration(time.Second),
+		MusiciansWait: typ.Duration(50 * time.Millisecond),
+	}, nil).(*baton)
+	m := addMusician(b, systemClock{}, &recordingClient{})
+
+	opts := data.DefaultPlayOptions
+	opts.DryRun = true
+	opts.Exclude = []int{2}
+	opts.Start = &data.Position{Bar: 1, Beat: 2}
+	opts.End = &data.Position{Time: 2500 * time.Millisecond}
+	p, err := b.Play("test.mid", loopMusic(t), opts)
+	require.NoError(t, err)
+	require.NotNil(t, p.Schedule)
+	p.Schedule = nil
+	assert.Equal(t, data.PlayPlan{
+		Tracks:   []data.TrackAssignment{{Track: 1, Musician: &m.Id}},
+		Skipped:  []int{0},
+		Excluded: []int{2},
+		Start:    time.Second,
+		End:      2500 * time.Millisecond,
+	}, p)
+
+	// A dry run leaves the baton free
+	assert.True(t, idle(b))
+	assert.Equal(t, data.PlaybackIdle, status(t, b).State)
+
+	// Only selecting the bass leaves the melody out
+	opts = data.DefaultPlayOptions
+	opts.DryRun = true
+	opts.Tracks = []int{2}
+	p, err = b.Play("test.mid", loopMusic(t), opts)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, p.Excluded)
+	assert.Equal(t, 4*time.Second, p.End)
+
+	for _, c := range []struct {
+		change func(o *data.PlayOptions)
+		err    error
+	}{
+		{change: func(o *data.PlayOptions) { o.Loops = 0 }, err: data.ErrInvalidPlayOptions},
+		{change: func(o *data.PlayOptions) { o.MinMusicians = -1 }, err: data.ErrInvalidPlayOptions},
+		{change: func(o *data.PlayOptions) { o.Tracks = []int{3} }, err: data.ErrInvalidPlayOptions},
+		{change: func(o *data.PlayOptions) { o.Start = &data.Position{Bar: 9, Beat: 1} }, err: data.ErrInvalidPosition},
+		{change: func(o *data.PlayOptions) {
+			o.Start = &data.Position{Time: 2 * time.Second}
+			o.End = &data.Position{Time: time.Second}
+		}, err: data.ErrInvalidPosition},
+		{change: func(o *data.PlayOptions) {
+			o.Exclude = []int{1}
+			o.Mapping = []data.TrackAssignment{{Track: 1, Musician: &m.Id}}
+		}, err: data.ErrInvalidAssignment},
+		{change: func(o *data.PlayOptions) { o.MinMusicians = 2 }, err: data.ErrNotEnoughMusicians},
+	} {
+		opts := data.DefaultPlayOptions
+		c.change(&opts)
+		_, err := b.Play("test.mid", loopMusic(t), opts)
+		assert.ErrorIs(t, err, c.err)
+		assert.True(t, idle(b))
+	}
+}
+
+func TestPlayLoops(t *testing.T) {
+	b := New(logging.Base(), config.Playback{
+		LookAhead:     typ.Duration(10 * time.Millisecond),
+		MaxClockSkew:  typ.Duration(time.Second),
+		MusiciansWait: typ.Duration(time.Second),
+	}, nil).(*baton)
+
+	// The music waits for its musician
+	cli := &recordingClient{}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		addMusician(b, systemClock{}, cli)
+	}()
+
+	// The second note of the melody is played twice, cut after 1.5s, four
+	// times faster
+	opts := data.DefaultPlayOptions
+	opts.Interpretation.Tempo = 4
+	opts.Exclude = []int{2}
+	opts.Start = &data.Position{Bar: 1, Beat: 2}
+	opts.End = &data.Position{Time: 2500 * time.Millisecond}
+	opts.Loops = 2
+	opts.MinMusicians = 1
+	_, err := b.Play("test.mid", loopMusic(t), opts)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.True(t, status(t, b).Completed)
+
+	// The program set before the start is sent on every loop
+	assert.Equal(t, []midi.Message{
+		midi.ProgramChange(0, 5),
+		midi.NoteOn(0, 62, 100),
+		midi.NoteOff(0, 62),
+		midi.ControlChange(0, ccAllNotesOff, 0),
+		midi.ControlChange(0, ccAllSoundOff, 0),
+		midi.ProgramChange(0, 5),
+		midi.NoteOn(0, 62, 100),
+		midi.NoteOff(0, 62),
+		midi.ControlChange(0, ccAllNotesOff, 0),
+		midi.ControlChange(0, ccAllSoundOff, 0),
+	}, cli.messages())
+
+	cli.mu.Lock()
+	notes := cli.notes
+	cli.mu.Unlock()
+	start := notes[1].At
+	for i, want := range map[int]time.Duration{2: 375, 3: 375, 5: 375, 6: 375, 7: 750} {
+		assert.InDelta(t, want*time.Millisecond, notes[i].At.Sub(start), float64(20*time.Millisecond), i)
+	}
+}
@@ -0,0 +1,537 @@
+package baton
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+// partBufferSize is the number of notes that can wait to be sent to a
+// musician, so that a slow musician does not hold back the others
+const partBufferSize = 1024
+
+// partQueueWait is how long the notes wait for room in the queue of a
+// musician behind before being dropped, so that a musician not answering
+// does not hold back the performance
+const partQueueWait = time.Second
+
+// part is the slice of a performance handled by a single musician
+type part struct {
+	musician data.Musician
+	link     *link
+	ch       chan Note
+	quit     chan struct{}
+	// stalled is set once a note was dropped for lack of room, the next
+	// notes are dropped at once until the queue has room again
+	stalled atomic.Bool
+}
+
+func newPart(m *member) *part {
+	return &part{
+		musician: m.musician,
+		link:     m.link,
+		ch:       make(chan Note, partBufferSize),
+		quit:     make(chan struct{}),
+	}
+}
+
+// performance holds the routing between lanes and musicians of the
+// music currently being played
+type performance struct {
+	// id addresses the performance, it is kept by the music cued
+	// following it
+	id data.ID
+	// section is the section playing, nil for the whole orchestra
+	section *data.Section
+	paused  atomic.Bool
+	// cued is the music played once the performance ends, guarded by the
+	// baton lock
+	cued *score
+	// isolation are the output channels of the parts, when the channels
+	// are isolated
+	isolation isolation
+
+	mu     sync.Mutex
+	parts  map[data.ID]*part
+	routes map[lane]*part
+	// lanes are the lanes of the plan, in order
+	lanes []lane
+	// usage is the usage of the tracks, telling what the musicians taking
+	// lanes over must be able to do
+	usage []trackUsage
+	// controls is the sound of the channels so far, replayed to the
+	// musicians taking lanes over
+	controls controls
+	// mixer is the mix of the musicians and tracks
+	mixer mixer
+	// wg waits for the goroutines sending the notes of the parts
+	wg sync.WaitGroup
+	// finished is set once the parts stop receiving notes
+	finished bool
+	// finishing is closed once finished, the parts send the notes waiting and
+	// end. Their queues are never closed as a note redelivered may still
+	// be on its way to them.
+	finishing chan struct{}
+
+	// music is the name of the music
+	music string
+	// length is the duration of the music
+	length time.Duration
+	// control receives the transport commands
+	control chan transport
+	// done is closed when the performance ends
+	done chan struct{}
+	// clock tells the time the music is at
+	clock Clock
+
+	// timeline tells when the positions of the music are played, it moves
+	// with pauses, seeks and tempo changes
+	timeline timeline
+	// interpretation is how the music is being played
+	interpretation data.Interpretation
+	ended          bool
+	completed      bool
+	elapsed        time.Duration
+
+	dropped atomic.Uint64
+	failed  atomic.Uint64
+}
+
+func newPerformance(music string, seq sequence, interp data.Interpretation) *performance {
+	return &performance{
+		mu:             sync.Mutex{},
+		parts:          make(map[data.ID]*part),
+		routes:         make(map[lane]*part),
+		usage:          seq.usage,
+		controls:       make(controls),
+		mixer:          newMixer(),
+		music:          music,
+		length:         seq.duration(),
+		control:        make(chan transport),
+		done:           make(chan struct{}),
+		finishing:      make(chan struct{}),
+		clock:          systemClock{},
+		interpretation: interp,
+	}
+}
+
+// sectionName returns the name of the section playing, empty for the
+// whole orchestra
+func (p *performance) sectionName() string {
+	if p.section == nil {
+		return ""
+	}
+	return p.section.Name
+}
+
+// plays tells whether the musician has a part in the performance
+func (p *performance) plays(id data.ID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.parts[id]
+	return ok
+}
+
+func (p *performance) addPart(pt *part) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.parts[pt.musician.Id] = pt
+}
+
+// follow routes the lanes of the plan to the parts of their musicians
+func (p *performance) follow(plan data.PlayPlan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lanes = make([]lane, len(plan.Tracks))
+	for i, a := range plan.Tracks {
+		l := laneOf(a)
+		p.lanes[i] = l
+		if a.Musician == nil {
+			continue
+		}
+		if pt, ok := p.parts[*a.Musician]; ok {
+			p.routes[l] = pt
+		}
+	}
+}
+
+// assign routes a lane to a part
+func (p *performance) assign(l lane, pt *part) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !slices.Contains(p.lanes, l) {
+		p.lanes = append(p.lanes, l)
+	}
+	p.routes[l] = pt
+}
+
+// route returns the part currently responsible for a message of the
+// track, or nil when nobody plays it. Channel messages go to the part of
+// their channel when the track is split, the other messages of a split
+// track go to the part of its lowest channel.
+func (p *performance) route(track int, msg []byte) *part {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.routeLocked(track, msg)
+}
+
+// routeLocked is route with the lock held
+func (p *performance) routeLocked(track int, msg []byte) *part {
+	if pt, ok := p.routes[lane{track: track, channel: allChannels}]; ok {
+		return pt
+	}
+
+	var channel uint8
+	if midi.Message(msg).GetChannel(&channel) {
+		return p.routes[lane{track: track, channel: int(channel)}]
+	}
+
+	for channel := 0; channel < 16; channel++ {
+		if pt, ok := p.routes[lane{track: track, channel: channel}]; ok {
+			return pt
+		}
+	}
+	return nil
+}
+
+// dispatch records the sound settings carried by the note and hands it
+// to the musician playing its lane
+func (p *performance) dispatch(note Note) {
+	p.mu.Lock()
+	p.controls.update(note.index, note.note)
+	p.mu.Unlock()
+
+	p.deliver(note)
+}
+
+// deliver hands the note to the musician playing its lane. Notes of lanes
+// without musician are dropped, so are those of a musician whose queue
+// stays full, counted as failed, and those redelivered once the
+// performance finished.
+func (p *performance) deliver(note Note) {
+	for {
+		// Routing and queuing together keep the note from reaching a part
+		// removed in between, or finished
+		p.mu.Lock()
+		pt := p.routeLocked(note.index, note.note)
+		if pt == nil || p.finished {
+			p.mu.Unlock()
+			p.dropped.Add(1)
+			return
+		}
+		select {
+		case pt.ch <- note:
+			p.mu.Unlock()
+			pt.stalled.Store(false)
+			return
+		default:
+		}
+		p.mu.Unlock()
+		if pt.stalled.Load() {
+			p.failed.Add(1)
+			return
+		}
+
+		// The musician is behind, wait for room in its queue
+		if p.await(pt, note) {
+			return
+		}
+	}
+}
+
+// await waits for room in the queue of the part for the note. It returns
+// false when the musician left, the note going to the new owner of its
+// track.
+func (p *performance) await(pt *part, note Note) bool {
+	wait := p.clock.NewTimer(partQueueWait)
+	defer wait.Stop()
+
+	select {
+	case pt.ch <- note:
+		return true
+	case <-pt.quit:
+		return false
+	case <-p.finishing:
+		p.dropped.Add(1)
+		return true
+	case <-wait.C():
+		pt.stalled.Store(true)
+		p.failed.Add(1)
+		return true
+	}
+}
+
+// redeliver hands the notes still waiting in a removed part to the new
+// musicians of their lanes
+func (p *performance) redeliver(pt *part) {
+	for {
+		select {
+		case note := <-pt.ch:
+			p.deliver(note)
+		default:
+			return
+		}
+	}
+}
+
+// handover is a move of lanes from one part to another during the
+// performance. from is nil when the lanes had no musician, to is nil when
+// no musician could take them.
+type handover struct {
+	perf  *performance
+	from  *part
+	to    *part
+	lanes []lane
+}
+
+// remove stops the part of the musician and hands its lanes over to an
+// idle musician. When there is no idle musician the lanes are left
+// without musician until one joins. It returns false when the musician
+// has no part in the performance.
+func (p *performance) remove(id data.ID) (handover, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	removed, ok := p.parts[id]
+	if !ok {
+		return handover{}, false
+	}
+
+	delete(p.parts, id)
+	close(removed.quit)
+
+	h := handover{perf: p, from: removed}
+	for _, l := range p.lanes {
+		if p.routes[l] == removed {
+			h.lanes = append(h.lanes, l)
+		}
+	}
+
+	h.to = p.idlePart(h.lanes)
+	for _, l := range h.lanes {
+		if h.to == nil {
+			delete(p.routes, l)
+			continue
+		}
+		p.routes[l] = h.to
+	}
+
+	if h.to != nil {
+		p.replay(h.to, h.lanes)
+	}
+	return h, true
+}
+
+// join adds the part of a musician registered during the performance. It
+// takes the lanes left without musician, or stands by. The caller must
+// start sending the notes of the part. It returns false when the musician
+// already has a part or the performance is finishing.
+func (p *performance) join(pt *part) (handover, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.parts[pt.musician.Id]; ok || p.finished {
+		return handover{}, false
+	}
+
+	p.parts[pt.musician.Id] = pt
+	p.wg.Add(1)
+
+	// The musician takes the lanes it is able to play
+	h := handover{perf: p, to: pt}
+	var taken []needs
+	for _, l := range p.lanes {
+		if _, ok := p.routes[l]; ok {
+			continue
+		}
+		n := p.needs(l)
+		if !canPlayAll(pt.musician.Capabilities, append(taken, n)) {
+			continue
+		}
+		taken = append(taken, n)
+		h.lanes = append(h.lanes, l)
+		p.routes[l] = pt
+	}
+
+	p.replay(pt, h.lanes)
+	return h, true
+}
+
+// replay queues the sound settings of the lanes to the part taking them
+// over, ahead of their next notes. Must be called with the lock held.
+func (p *performance) replay(pt *part, lanes []lane) {
+	for _, l := range lanes {
+		p.queue(pt, l, p.controls.state(l))
+		// The mix applies to the channels whose sound the music never set
+		if !p.mixer.neutral() {
+			p.queue(pt, l, p.levels(l))
+		}
+	}
+}
+
+// queue sends messages of a lane to the part ahead of its next notes,
+// they are dropped when the part is behind. Must be called with the lock
+// held.
+func (p *performance) queue(pt *part, l lane, msgs [][]byte) {
+	now := p.clock.Now()
+	for _, msg := range msgs {
+		select {
+		case pt.ch <- Note{index: l.track, at: now, note: msg}:
+		default:
+			p.dropped.Add(1)
+		}
+	}
+}
+
+// refresh sends the sound of its lanes again to a musician registering
+// again, which may have lost it
+func (p *performance) refresh(id data.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pt, ok := p.parts[id]
+	if !ok || p.finished {
+		return
+	}
+	var lanes []lane
+	for _, l := range p.lanes {
+		if p.routes[l] == pt {
+			lanes = append(lanes, l)
+		}
+	}
+	p.replay(pt, lanes)
+}
+
+// idlePart returns a part without any lane assigned able to play the
+// lanes. Must be called with the lock held.
+func (p *performance) idlePart(lanes []lane) *part {
+	busy := make(map[*part]bool, len(p.routes))
+	for _, pt := range p.routes {
+		busy[pt] = true
+	}
+
+	needs := make([]needs, len(lanes))
+	for i, l := range lanes {
+		needs[i] = p.needs(l)
+	}
+
+	for _, pt := range p.parts {
+		if !busy[pt] && canPlayAll(pt.musician.Capabilities, needs) {
+			return pt
+		}
+	}
+
+	return nil
+}
+
+// needs returns what playing a lane requires, nothing when the usage of
+// its track is unknown. The channels are those played once isolated.
+func (p *performance) needs(l lane) needs {
+	if l.track >= len(p.usage) {
+		return needs{}
+	}
+	n := needsOf(p.usage[l.track], l)
+	n.channels = p.isolation.outputs(l.track, n.channels)
+	return n
+}
+
+// finish lets every part send its remaining notes and end. It must be
+// called once no more notes are dispatched. The routing is kept to report
+// the status of the performance.
+func (p *performance) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finished = true
+	close(p.finishing)
+	clear(p.parts)
+}
+
+// setTimeline records when the positions of the music are played
+func (p *performance) setTimeline(t timeline) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timeline = t
+	p.interpretation.Tempo = t.tempo
+}
+
+// setTranspose records the transposition of the notes
+func (p *performance) setTranspose(semitones int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.interpretation.Transpose = semitones
+}
+
+// end records the end of the performance
+func (p *performance) end(completed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.elapsed = p.elapsedLocked()
+	p.ended = true
+	p.completed = completed
+}
+
+// elapsedLocked returns how far the music is. Must be called with the
+// lock held.
+func (p *performance) elapsedLocked() time.Duration {
+	if p.ended {
+		return p.elapsed
+	}
+	if p.timeline.at.IsZero() {
+		return 0
+	}
+	return min(max(p.timeline.position(p.clock.Now()), 0), p.length)
+}
+
+// position returns the time since the music started, which is negative
+// until its first note sounds. It returns false once the performance ended.
+func (p *performance) position() (time.Duration, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ended || p.timeline.at.IsZero() {
+		return 0, false
+	}
+	return p.timeline.position(p.clock.Now()), true
+}
+
+// status reports the progress of the performance
+func (p *performance) status() data.PlaybackStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	st := data.PlaybackStatus{
+		Performance:    p.id,
+		Section:        p.sectionName(),
+		Music:          p.music,
+		State:          data.PlaybackPlaying,
+		Completed:      p.completed,
+		Elapsed:        p.elapsedLocked(),
+		Duration:       p.length,
+		Tracks:         make([]data.TrackAssignment, 0, len(p.lanes)),
+		DroppedNotes:   p.dropped.Load(),
+		FailedNotes:    p.failed.Load(),
+		Interpretation: p.interpretation,
+		Mixer:          p.mixer.state(),
+	}
+	switch {
+	case p.ended:
+		st.State = data.PlaybackStopped
+	case p.paused.Load():
+		st.State = data.PlaybackPaused
+	}
+
+	for _, l := range p.lanes {
+		var musician *data.ID
+		if pt, ok := p.routes[l]; ok {
+			id := pt.musician.Id
+			musician = &id
+		}
+		st.Tracks = append(st.Tracks, assignment(l, musician))
+	}
+
+	return st
+}
@@ -0,0 +1,247 @@
+package baton
+
+import (
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+func TestPerformanceRemove(t *testing.T) {
+	perf := newPerformance("test.mid", sequence{tracks: 2}, data.DefaultInterpretation)
+
+	a := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://a"}})
+	b := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://b"}})
+	idle := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://idle"}})
+
+	note := midi.NoteOn(0, 60, 100)
+
+	perf.addPart(a)
+	perf.addPart(b)
+	perf.addPart(idle)
+	perf.assign(lane{track: 0, channel: allChannels}, a)
+	perf.assign(lane{track: 1, channel: allChannels}, b)
+
+	// The track of a removed musician moves to the idle musician
+	h, ok := perf.remove(a.musician.Id)
+	assert.True(t, ok)
+	assert.Equal(t, handover{perf: perf, from: a, to: idle, lanes: []lane{{track: 0, channel: allChannels}}}, h)
+	assert.Equal(t, idle, perf.route(0, note))
+	assert.Equal(t, b, perf.route(1, note))
+
+	select {
+	case <-a.quit:
+	default:
+		t.Fatal("part of the removed musician was not stopped")
+	}
+
+	// Without idle musicians the track is dropped
+	h, ok = perf.remove(b.musician.Id)
+	assert.True(t, ok)
+	assert.Nil(t, h.to)
+	assert.Nil(t, perf.route(1, note))
+
+	_, ok = perf.remove(data.GenId())
+	assert.False(t, ok)
+}
+
+func TestPerformanceHandoverReplaysControls(t *testing.T) {
+	perf := newPerformance("test.mid", sequence{tracks: 2}, data.DefaultInterpretation)
+
+	gone := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://gone"}})
+	perf.addPart(gone)
+	perf.assign(lane{track: 0, channel: allChannels}, gone)
+	perf.assign(lane{track: 1, channel: allChannels}, gone)
+
+	for _, msg := range []midi.Message{
+		midi.ProgramChange(0, 5),
+		midi.ControlChange(0, ccVolume, 100),
+		midi.ControlChange(0, ccPan, 20),
+		midi.ControlChange(0, 64, 127), // sustain is not replayed
+		midi.ProgramChange(0, 6),       // the last program wins
+		midi.Pitchbend(1, 200),
+		midi.NoteOn(0, 60, 100),
+	} {
+		perf.dispatch(Note{index: 0, note: msg})
+	}
+	perf.dispatch(Note{index: 1, note: midi.ProgramChange(2, 40)})
+	assert.Len(t, gone.ch, 8)
+
+	// The lanes wait without musician, the next one to join takes them
+	// and receives their sound first
+	h, ok := perf.remove(gone.musician.Id)
+	require.True(t, ok)
+	assert.Nil(t, h.to)
+	assert.Nil(t, perf.route(0, midi.NoteOn(0, 60, 100)))
+
+	standby := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://standby"}})
+	h, ok = perf.join(standby)
+	require.True(t, ok)
+	defer perf.wg.Done()
+	assert.Equal(t, []lane{{track: 0, channel: allChannels}, {track: 1, channel: allChannels}}, h.lanes)
+
+	perf.redeliver(gone)
+	var msgs []midi.Message
+	for len(standby.ch) > 0 {
+		msgs = append(msgs, (<-standby.ch).note)
+	}
+	assert.Equal(t, []midi.Message{
+		midi.ProgramChange(0, 6),
+		midi.ControlChange(0, ccVolume, 100),
+		midi.ControlChange(0, ccPan, 20),
+		midi.Pitchbend(1, 200),
+		midi.ProgramChange(2, 40),
+		// The notes waiting for the removed musician follow
+		midi.ProgramChange(0, 5),
+		midi.ControlChange(0, ccVolume, 100),
+		midi.ControlChange(0, ccPan, 20),
+		midi.ControlChange(0, 64, 127),
+		midi.ProgramChange(0, 6),
+		midi.Pitchbend(1, 200),
+		midi.NoteOn(0, 60, 100),
+		midi.ProgramChange(2, 40),
+	}, msgs)
+
+	_, ok = perf.join(standby)
+	assert.False(t, ok)
+}
+
+func TestControlsSplitLane(t *testing.T) {
+	c := make(controls)
+	c.update(0, midi.ProgramChange(1, 10))
+	c.update(0, midi.ProgramChange(9, 0))
+
+	assert.Equal(t, [][]byte{midi.ProgramChange(9, 0)}, c.state(lane{track: 0, channel: 9}))
+	assert.Len(t, c.state(lane{track: 0, channel: allChannels}), 2)
+	assert.Empty(t, c.state(lane{track: 1, channel: allChannels}))
+}
+
+func TestPerformanceRouteSplitTrack(t *testing.T) {
+	perf := newPerformance("test.mid", sequence{tracks: 1}, data.DefaultInterpretation)
+
+	low := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://low"}})
+	high := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://high"}})
+	perf.addPart(low)
+	perf.addPart(high)
+	perf.assign(lane{track: 0, channel: 2}, low)
+	perf.assign(lane{track: 0, channel: 9}, high)
+
+	assert.Equal(t, low, perf.route(0, midi.NoteOn(2, 60, 100)))
+	assert.Equal(t, high, perf.route(0, midi.NoteOn(9, 36, 100)))
+	assert.Nil(t, perf.route(0, midi.NoteOn(5, 60, 100)))
+	assert.Nil(t, perf.route(1, midi.NoteOn(2, 60, 100)))
+
+	// Messages without channel go to the lowest channel of the track
+	assert.Equal(t, low, perf.route(0, midi.SysEx([]byte{0x7e, 0x7f, 0x09, 0x01})))
+
+	st := perf.status()
+	assert.Equal(t, []data.TrackAssignment{
+		{Track: 0, Channel: ptr(uint8(2)), Musician: &low.musician.Id},
+		{Track: 0, Channel: ptr(uint8(9)), Musician: &high.musician.Id},
+	}, st.Tracks)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestPerformanceStandbyCapabilities(t *testing.T) {
+	drums := usageOf(map[int]int{data.PercussionChannel: 10})
+	perf := newPerformance("test.mid", sequence{tracks: 1, usage: []trackUsage{drums}}, data.DefaultInterpretation)
+
+	drummer := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://drummer"}})
+	pianist := newPart(&member{musician: data.Musician{
+		Id:           data.GenId(),
+		Address:      "http://pianist",
+		Capabilities: &data.Capabilities{},
+	}})
+	perf.addPart(drummer)
+	perf.addPart(pianist)
+	perf.assign(lane{track: 0, channel: allChannels}, drummer)
+
+	// The idle pianist does not play drums, the track waits for a drummer
+	h, ok := perf.remove(drummer.musician.Id)
+	require.True(t, ok)
+	assert.Nil(t, h.to)
+
+	h, ok = perf.join(newPart(&member{musician: data.Musician{
+		Id:           data.GenId(),
+		Address:      "http://guitarist",
+		Capabilities: &data.Capabilities{Channels: []uint8{0}},
+	}}))
+	require.True(t, ok)
+	defer perf.wg.Done()
+	assert.Empty(t, h.lanes)
+
+	percussionist := newPart(&member{musician: data.Musician{
+		Id:           data.GenId(),
+		Address:      "http://percussionist",
+		Capabilities: &data.Capabilities{Percussion: true},
+	}})
+	h, ok = perf.join(percussionist)
+	require.True(t, ok)
+	defer perf.wg.Done()
+	assert.Equal(t, []lane{{track: 0, channel: allChannels}}, h.lanes)
+	assert.Equal(t, percussionist, perf.route(0, midi.NoteOn(data.PercussionChannel, 36, 100)))
+}
+
+func TestPerformanceStalledPart(t *testing.T) {
+	clk := newManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	perf := newPerformance("test.mid", sequence{tracks: 1}, data.DefaultInterpretation)
+	perf.clock = clk
+	a := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://a"}})
+	perf.addPart(a)
+	perf.assign(lane{track: 0, channel: allChannels}, a)
+
+	note := Note{index: 0, note: midi.NoteOn(0, 60, 100)}
+	for range partBufferSize {
+		perf.deliver(note)
+	}
+
+	// The note waits for room a while, then is dropped
+	delivered := make(chan struct{})
+	armed := clk.timesArmed()
+	go func() {
+		perf.deliver(note)
+		close(delivered)
+	}()
+	clk.awaitArmed(armed + 1)
+	clk.Advance(partQueueWait)
+	<-delivered
+	assert.Equal(t, uint64(1), perf.failed.Load())
+
+	// The next notes are dropped at once until the musician catches up
+	perf.deliver(note)
+	assert.Equal(t, uint64(2), perf.failed.Load())
+	<-a.ch
+	perf.deliver(note)
+	assert.Equal(t, uint64(2), perf.failed.Load())
+	assert.Len(t, a.ch, partBufferSize)
+	assert.False(t, a.stalled.Load())
+}
+
+// TestPerformanceRedeliverFinished checks that the notes left in a part
+// removed are dropped when redelivered after the performance finished
+func TestPerformanceRedeliverFinished(t *testing.T) {
+	perf := newPerformance("test.mid", sequence{tracks: 1}, data.DefaultInterpretation)
+	a := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://a"}})
+	idle := newPart(&member{musician: data.Musician{Id: data.GenId(), Address: "http://idle"}})
+	perf.addPart(a)
+	perf.addPart(idle)
+	perf.assign(lane{track: 0, channel: allChannels}, a)
+
+	perf.deliver(Note{index: 0, note: midi.NoteOn(0, 60, 100)})
+	_, ok := perf.remove(a.musician.Id)
+	require.True(t, ok)
+	perf.finish()
+
+	assert.NotPanics(t, func() {
+		perf.redeliver(a)
+	})
+	assert.Empty(t, a.ch)
+	assert.Empty(t, idle.ch)
+	assert.Equal(t, uint64(1), perf.dropped.Load())
+}
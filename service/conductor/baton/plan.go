@@ -0,0 +1,314 @@
+package baton
+
+import (
+	"cmp"
+	"slices"
+
+	"crossjoin.com/gorxestra/data"
+)
+
+// allChannels is the channel of a lane holding a whole track
+const allChannels = -1
+
+// lane is a track, or one MIDI channel of a track, played by a single
+// musician
+type lane struct {
+	track   int
+	channel int
+}
+
+func laneOf(a data.TrackAssignment) lane {
+	if a.Channel == nil {
+		return lane{track: a.Track, channel: allChannels}
+	}
+	return lane{track: a.Track, channel: int(*a.Channel)}
+}
+
+// plan distributes the tracks with notes among the musicians. Tracks
+// without notes are skipped. When there are more tracks than musicians
+// the tracks are merged, the busiest tracks going first to the least
+// busy musicians. When there are more musicians than tracks the busiest
+// tracks using several MIDI channels are split by channel while there
+// are idle musicians to play them.
+//
+// A track only goes to a musician whose capabilities allow playing it. A
+// track no musician can play whole is split by channel, a track nobody
+// can play is left without musician.
+//
+// A non empty mapping is followed as given, the tracks it does not
+// mention are not played.
+func plan(usage []trackUsage, musicians []data.Musician, mapping []data.TrackAssignment) (data.PlayPlan, error) {
+	var p data.PlayPlan
+	for track, u := range usage {
+		if u.events == 0 {
+			p.Skipped = append(p.Skipped, track)
+		}
+	}
+
+	if len(mapping) > 0 {
+		tracks, err := followMapping(usage, musicians, mapping)
+		if err != nil {
+			return data.PlayPlan{}, err
+		}
+		p.Tracks = tracks
+		return p, nil
+	}
+
+	lanes := splitLanes(usage, musicians)
+	owners := distribute(lanes, musicians)
+
+	p.Tracks = make([]data.TrackAssignment, len(lanes))
+	for i, l := range lanes {
+		p.Tracks[i] = assignment(l.lane, nil)
+		if owners[i] >= 0 {
+			id := musicians[owners[i]].Id
+			p.Tracks[i].Musician = &id
+		}
+	}
+	return p, nil
+}
+
+// weightedLane is a lane with the number of events it holds
+type weightedLane struct {
+	lane
+	events int
+	needs  needs
+}
+
+// splitLanes returns the lanes of the tracks with notes. The tracks no
+// musician can play whole are split by channel, then the busiest tracks
+// while there are musicians to play them.
+func splitLanes(usage []trackUsage, musicians []data.Musician) []weightedLane {
+	lanes := make([]weightedLane, 0, len(usage))
+	for track, u := range usage {
+		if u.events > 0 {
+			l := lane{track: track, channel: allChannels}
+			lanes = append(lanes, weightedLane{
+				lane:   l,
+				events: u.events,
+				needs:  needsOf(u, l),
+			})
+		}
+	}
+
+	split := make(map[int]bool)
+	idle := len(musicians) - len(lanes)
+	for _, l := range lanes {
+		channels := usedChannels(usage[l.track])
+		if len(channels) > 1 && len(musicians) > 0 && !playable(l.needs, musicians) {
+			split[l.track] = true
+			idle -= len(channels) - 1
+		}
+	}
+
+	candidates := slices.Clone(lanes)
+	slices.SortStableFunc(candidates, func(a, b weightedLane) int {
+		return cmp.Compare(b.events, a.events)
+	})
+
+	for _, c := range candidates {
+		channels := usedChannels(usage[c.track])
+		if split[c.track] || len(channels) < 2 || len(channels)-1 > idle {
+			continue
+		}
+		split[c.track] = true
+		idle -= len(channels) - 1
+	}
+
+	out := make([]weightedLane, 0, len(musicians))
+	for _, l := range lanes {
+		if !split[l.track] {
+			out = append(out, l)
+			continue
+		}
+		for _, channel := range usedChannels(usage[l.track]) {
+			split := lane{track: l.track, channel: channel}
+			out = append(out, weightedLane{
+				lane:   split,
+				events: usage[l.track].channels[channel],
+				needs:  needsOf(usage[l.track], split),
+			})
+		}
+	}
+	return out
+}
+
+// playable tells whether one of the musicians is able to play a lane
+func playable(n needs, musicians []data.Musician) bool {
+	return slices.ContainsFunc(musicians, func(m data.Musician) bool {
+		return canPlay(m.Capabilities, n)
+	})
+}
+
+// distribute returns the index of the musician playing each lane, or -1
+// when no musician is able to play it. Each lane gets its own musician
+// when possible, otherwise the lanes are balanced among the musicians.
+func distribute(lanes []weightedLane, musicians []data.Musician) []int {
+	if len(lanes) <= len(musicians) {
+		if owners, ok := match(lanes, musicians); ok {
+			return owners
+		}
+	}
+	return balance(lanes, musicians)
+}
+
+// match gives each lane a musician of its own able to play it, the lanes
+// taking the first free musicians in order. It returns false when there
+// is no such assignment.
+func match(lanes []weightedLane, musicians []data.Musician) ([]int, bool) {
+	owners := make([]int, len(lanes))
+	played := make([]int, len(musicians)) // lane played by each musician
+	for i := range played {
+		played[i] = -1
+	}
+
+	// augment finds a musician for the lane, moving the lanes already
+	// assigned to other musicians able to play them if needed
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for m := range musicians {
+			if seen[m] || !canPlay(musicians[m].Capabilities, lanes[i].needs) {
+				continue
+			}
+			seen[m] = true
+			if played[m] < 0 || augment(played[m], seen) {
+				played[m] = i
+				owners[i] = m
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := range lanes {
+		free := -1
+		for m := range musicians {
+			if played[m] < 0 && canPlay(musicians[m].Capabilities, lanes[i].needs) {
+				free = m
+				break
+			}
+		}
+
+		if free >= 0 {
+			played[free] = i
+			owners[i] = free
+			continue
+		}
+		if !augment(i, make([]bool, len(musicians))) {
+			return nil, false
+		}
+	}
+	return owners, true
+}
+
+// balance hands the busiest lanes first to the least busy musicians able
+// to play them, preferring the musicians with enough polyphony left
+func balance(lanes []weightedLane, musicians []data.Musician) []int {
+	owners := make([]int, len(lanes))
+	order := make([]int, len(lanes))
+	for i := range order {
+		order[i] = i
+		owners[i] = -1
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(lanes[b].events, lanes[a].events)
+	})
+
+	load := make([]int, len(musicians))
+	voices := make([]int, len(musicians))
+	for _, i := range order {
+		n := lanes[i].needs
+		least, leastFitting := -1, -1
+		for m := range musicians {
+			c := musicians[m].Capabilities
+			if !canPlay(c, n) {
+				continue
+			}
+			if least < 0 || load[m] < load[least] {
+				least = m
+			}
+			fits := c == nil || c.Polyphony == 0 || voices[m]+n.polyphony <= c.Polyphony
+			if fits && (leastFitting < 0 || load[m] < load[leastFitting]) {
+				leastFitting = m
+			}
+		}
+
+		owner := leastFitting
+		if owner < 0 {
+			owner = least
+		}
+		if owner < 0 {
+			continue
+		}
+		owners[i] = owner
+		load[owner] += lanes[i].events
+		voices[owner] += n.polyphony
+	}
+	return owners
+}
+
+// followMapping validates an explicit mapping and returns it ordered by
+// track and channel
+func followMapping(usage []trackUsage, musicians []data.Musician, mapping []data.TrackAssignment) ([]data.TrackAssignment, error) {
+	seen := make(map[lane]bool, len(mapping))
+	tracks := make([]data.TrackAssignment, 0, len(mapping))
+	for _, a := range mapping {
+		if a.Track < 0 || a.Track >= len(usage) || a.Musician == nil {
+			return nil, data.ErrInvalidAssignment
+		}
+		if a.Channel != nil && *a.Channel > 15 {
+			return nil, data.ErrInvalidAssignment
+		}
+		idx := slices.IndexFunc(musicians, func(m data.Musician) bool {
+			return m.Id == *a.Musician
+		})
+		if idx < 0 {
+			return nil, data.ErrMusicianNotFound
+		}
+
+		// A track is played whole or split by channel, not both
+		l := laneOf(a)
+		if !canPlay(musicians[idx].Capabilities, needsOf(usage[l.track], l)) {
+			return nil, data.ErrMusicianNotCapable
+		}
+		whole := lane{track: l.track, channel: allChannels}
+		if seen[l] || (l.channel != allChannels && seen[whole]) {
+			return nil, data.ErrInvalidAssignment
+		}
+		if l.channel == allChannels && slices.ContainsFunc(tracks, func(t data.TrackAssignment) bool {
+			return t.Track == l.track
+		}) {
+			return nil, data.ErrInvalidAssignment
+		}
+		seen[l] = true
+
+		id := *a.Musician
+		tracks = append(tracks, assignment(l, &id))
+	}
+
+	slices.SortFunc(tracks, func(a, b data.TrackAssignment) int {
+		la, lb := laneOf(a), laneOf(b)
+		return cmp.Or(cmp.Compare(la.track, lb.track), cmp.Compare(la.channel, lb.channel))
+	})
+	return tracks, nil
+}
+
+// usedChannels returns the MIDI channels used by a track
+func usedChannels(u trackUsage) []int {
+	var channels []int
+	for channel, n := range u.channels {
+		if n > 0 {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+func assignment(l lane, musician *data.ID) data.TrackAssignment {
+	a := data.TrackAssignment{Track: l.track, Musician: musician}
+	if l.channel != allChannels {
+		channel := uint8(l.channel)
+		a.Channel = &channel
+	}
+	return a
+}
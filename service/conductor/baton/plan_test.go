@@ -0,0 +1,220 @@
+package baton
+
+import (
+	"testing"
+
+	"crossjoin.com/gorxestra/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// usageOf builds the usage of a track from the number of events of each
+// of its channels
+func usageOf(channels map[int]int) trackUsage {
+	var u trackUsage
+	for channel, n := range channels {
+		u.channels[channel] = n
+		u.events += n
+	}
+	return u
+}
+
+func genMusicians(n int) []data.Musician {
+	ms := make([]data.Musician, n)
+	for i := range ms {
+		ms[i] = data.Musician{Id: data.GenId()}
+	}
+	return ms
+}
+
+func TestPlanSkipsTracksWithoutNotes(t *testing.T) {
+	usage := []trackUsage{
+		{}, // tempo track
+		usageOf(map[int]int{0: 10}),
+		usageOf(map[int]int{1: 10}),
+	}
+	ms := genMusicians(3)
+
+	p, err := plan(usage, ms, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, p.Skipped)
+	assert.Equal(t, []data.TrackAssignment{
+		{Track: 1, Musician: &ms[0].Id},
+		{Track: 2, Musician: &ms[1].Id},
+	}, p.Tracks)
+}
+
+func TestPlanMergesTracks(t *testing.T) {
+	usage := []trackUsage{
+		usageOf(map[int]int{0: 100}),
+		usageOf(map[int]int{1: 60}),
+		usageOf(map[int]int{2: 50}),
+		usageOf(map[int]int{3: 10}),
+	}
+	ms := genMusicians(2)
+
+	p, err := plan(usage, ms, nil)
+	require.NoError(t, err)
+
+	// The busiest track plays alone, the others share the second musician
+	assert.Equal(t, []data.TrackAssignment{
+		{Track: 0, Musician: &ms[0].Id},
+		{Track: 1, Musician: &ms[1].Id},
+		{Track: 2, Musician: &ms[1].Id},
+		{Track: 3, Musician: &ms[0].Id},
+	}, p.Tracks)
+}
+
+func TestPlanSplitsTracksByChannel(t *testing.T) {
+	usage := []trackUsage{
+		{},
+		usageOf(map[int]int{0: 10}),
+		usageOf(map[int]int{1: 30, 9: 40}),
+		usageOf(map[int]int{2: 5, 3: 5, 4: 5}),
+	}
+
+	// One idle musician, only the busiest track is split
+	ms := genMusicians(4)
+	p, err := plan(usage, ms, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []data.TrackAssignment{
+		{Track: 1, Musician: &ms[0].Id},
+		{Track: 2, Channel: ptr(uint8(1)), Musician: &ms[1].Id},
+		{Track: 2, Channel: ptr(uint8(9)), Musician: &ms[2].Id},
+		{Track: 3, Musician: &ms[3].Id},
+	}, p.Tracks)
+
+	// Enough musicians to split every track, the last one stands by
+	ms = genMusicians(7)
+	p, err = plan(usage, ms, nil)
+	require.NoError(t, err)
+	require.Len(t, p.Tracks, 6)
+	for i, a := range p.Tracks {
+		assert.Equal(t, &ms[i].Id, a.Musician)
+	}
+	assert.Nil(t, p.Tracks[0].Channel)
+	assert.Equal(t, ptr(uint8(4)), p.Tracks[5].Channel)
+}
+
+func TestPlanWithoutMusicians(t *testing.T) {
+	usage := []trackUsage{{}, usageOf(map[int]int{0: 10})}
+
+	p, err := plan(usage, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []data.TrackAssignment{{Track: 1}}, p.Tracks)
+	assert.Equal(t, []int{0}, p.Skipped)
+}
+
+func TestPlanFollowsMapping(t *testing.T) {
+	usage := []trackUsage{
+		{},
+		usageOf(map[int]int{0: 10}),
+		usageOf(map[int]int{1: 30, 9: 40}),
+	}
+	ms := genMusicians(2)
+
+	p, err := plan(usage, ms, []data.TrackAssignment{
+		{Track: 2, Channel: ptr(uint8(9)), Musician: &ms[0].Id},
+		{Track: 2, Channel: ptr(uint8(1)), Musician: &ms[1].Id},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []data.TrackAssignment{
+		{Track: 2, Channel: ptr(uint8(1)), Musician: &ms[1].Id},
+		{Track: 2, Channel: ptr(uint8(9)), Musician: &ms[0].Id},
+	}, p.Tracks)
+	assert.Equal(t, []int{0}, p.Skipped)
+
+	unknown := data.GenId()
+	for name, mapping := range map[string][]data.TrackAssignment{
+		"track out of range": {{Track: 3, Musician: &ms[0].Id}},
+		"missing musician":   {{Track: 1}},
+		"invalid channel":    {{Track: 1, Channel: ptr(uint8(16)), Musician: &ms[0].Id}},
+		"track twice":        {{Track: 1, Musician: &ms[0].Id}, {Track: 1, Musician: &ms[1].Id}},
+		"whole then channel": {{Track: 2, Musician: &ms[0].Id}, {Track: 2, Channel: ptr(uint8(1)), Musician: &ms[1].Id}},
+		"channel then whole": {{Track: 2, Channel: ptr(uint8(1)), Musician: &ms[1].Id}, {Track: 2, Musician: &ms[0].Id}},
+	} {
+		_, err := plan(usage, ms, mapping)
+		assert.ErrorIs(t, err, data.ErrInvalidAssignment, name)
+	}
+
+	_, err = plan(usage, ms, []data.TrackAssignment{{Track: 1, Musician: &unknown}})
+	assert.ErrorIs(t, err, data.ErrMusicianNotFound)
+}
+
+func TestPlanHonorsCapabilities(t *testing.T) {
+	piano := usageOf(map[int]int{0: 10})
+	piano.polyphony, piano.channelPolyphony[0] = 4, 4
+	drums := usageOf(map[int]int{data.PercussionChannel: 20})
+
+	// The only musician playing drums is freed for them
+	anything := data.Musician{Id: data.GenId()}
+	melodic := data.Musician{Id: data.GenId(), Capabilities: &data.Capabilities{}}
+	p, err := plan([]trackUsage{piano, drums}, []data.Musician{anything, melodic}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []data.TrackAssignment{
+		{Track: 0, Musician: &melodic.Id},
+		{Track: 1, Musician: &anything.Id},
+	}, p.Tracks)
+
+	// Nobody plays the drums
+	p, err = plan([]trackUsage{piano, drums}, []data.Musician{melodic}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []data.TrackAssignment{
+		{Track: 0, Musician: &melodic.Id},
+		{Track: 1},
+	}, p.Tracks)
+
+	// A single track nobody plays whole is split for the musicians able
+	// to play its channels
+	band := usageOf(map[int]int{0: 10, data.PercussionChannel: 20})
+	drummer := data.Musician{Id: data.GenId(), Capabilities: &data.Capabilities{
+		Channels:   []uint8{data.PercussionChannel},
+		Percussion: true,
+	}}
+	p, err = plan([]trackUsage{band}, []data.Musician{drummer, melodic}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []data.TrackAssignment{
+		{Track: 0, Channel: ptr(uint8(0)), Musician: &melodic.Id},
+		{Track: 0, Channel: ptr(uint8(data.PercussionChannel)), Musician: &drummer.Id},
+	}, p.Tracks)
+
+	_, err = plan([]trackUsage{band}, []data.Musician{drummer, melodic}, []data.TrackAssignment{
+		{Track: 0, Musician: &melodic.Id},
+	})
+	assert.ErrorIs(t, err, data.ErrMusicianNotCapable)
+}
+
+func TestPlanHonorsProgramsAndPolyphony(t *testing.T) {
+	strings := usageOf(map[int]int{0: 30})
+	strings.programs[0] = []uint8{48}
+	organ := usageOf(map[int]int{1: 20})
+	organ.programs[1] = []uint8{16}
+	organ.polyphony, organ.channelPolyphony[1] = 6, 6
+	bass := usageOf(map[int]int{2: 10})
+	bass.polyphony, bass.channelPolyphony[2] = 1, 1
+
+	// The keyboard plays the organ family with 6 voices, the synth plays
+	// anything with 8 voices
+	keyboard := data.Musician{Id: data.GenId(), Capabilities: &data.Capabilities{
+		Programs:  []uint8{16, 17, 18, 19, 20, 21, 22, 23},
+		Polyphony: 6,
+	}}
+	synth := data.Musician{Id: data.GenId(), Capabilities: &data.Capabilities{Polyphony: 8}}
+
+	// The strings only go to the synth, the organ then takes every voice of
+	// the keyboard and the bass goes to the busier synth which has voices
+	// left
+	p, err := plan([]trackUsage{strings, organ, bass}, []data.Musician{keyboard, synth}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []data.TrackAssignment{
+		{Track: 0, Musician: &synth.Id},
+		{Track: 1, Musician: &keyboard.Id},
+		{Track: 2, Musician: &synth.Id},
+	}, p.Tracks)
+
+	// Too many voices for the synth
+	organ.polyphony = 9
+	p, err = plan([]trackUsage{organ}, []data.Musician{synth}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []data.TrackAssignment{{Track: 0}}, p.Tracks)
+}
@@ -0,0 +1,121 @@
+package baton
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+// gauge measures the load of the messages sent to a part
+type gauge struct {
+	load     data.Load
+	sounding voices
+	// starts are the times of the notes started within the last second
+	starts []time.Duration
+}
+
+func newGauge() *gauge {
+	return &gauge{sounding: make(voices)}
+}
+
+// record counts a message of the track sent at a time from the start of
+// the performance
+func (m *gauge) record(at time.Duration, track int, msg []byte) {
+	if m.load.Events == 0 {
+		m.load.First = at
+	}
+	m.load.Events++
+	m.load.Last = at
+
+	m.sounding.update(track, msg)
+	m.load.Polyphony = max(m.load.Polyphony, len(m.sounding))
+
+	var channel, key, velocity uint8
+	if !midi.Message(msg).GetNoteStart(&channel, &key, &velocity) {
+		return
+	}
+	m.load.Notes++
+	m.starts = append(m.starts, at)
+	gone := 0
+	for m.starts[gone] <= at-time.Second {
+		gone++
+	}
+	m.starts = m.starts[gone:]
+	m.load.PeakRate = max(m.load.PeakRate, len(m.starts))
+}
+
+// rehearse plays the score to the musicians following the plan against
+// a virtual clock, as fast as possible, and returns what each of them is
+// asked to play. The notes are routed, transposed, chased and silenced as
+// a performance would.
+func (b *baton) rehearse(s score, musicians []*member, p data.PlayPlan, iso isolation) data.Schedule {
+	perf, _ := b.perform(s, data.ID{}, nil, musicians, p, iso)
+	seq, bnds := s.seq, s.bnds
+
+	gauges := make(map[data.ID]*gauge, len(musicians))
+	for _, m := range musicians {
+		gauges[m.musician.Id] = newGauge()
+	}
+	unassigned := make(map[int]*gauge)
+	send := func(at time.Duration, ev event) {
+		var m *gauge
+		if pt := perf.route(ev.track, ev.msg); pt != nil {
+			m = gauges[pt.musician.Id]
+		} else {
+			if _, ok := unassigned[ev.track]; !ok {
+				unassigned[ev.track] = newGauge()
+			}
+			m = unassigned[ev.track]
+		}
+		m.record(at, ev.track, ev.msg)
+	}
+
+	var origin time.Time
+	tl := timeline{at: origin, pos: bnds.start, tempo: s.opts.Interpretation.Tempo}
+	tr := newTransposer(s.opts.Interpretation.Transpose)
+	sounding := make(voices)
+	last := bnds.last(seq)
+	for range bnds.loops {
+		start := tl.time(bnds.start).Sub(origin)
+		for _, ev := range chased(iso, seq, bnds.start) {
+			send(start, ev)
+		}
+
+		for _, ev := range seq.events[seq.seek(bnds.start):last] {
+			msg, ok := tr.transpose(ev.track, ev.msg)
+			if !ok {
+				continue
+			}
+			sounding.update(ev.track, msg)
+			send(tl.time(ev.at).Sub(origin), event{track: ev.track, at: ev.at, msg: msg})
+		}
+
+		// The notes still sounding end with the part played
+		end := tl.time(bnds.end).Sub(origin)
+		for _, ev := range sounding.release() {
+			send(end, ev)
+		}
+		tr.reset()
+		tl = timeline{at: tl.time(bnds.end), pos: bnds.start, tempo: tl.tempo}
+	}
+
+	schedule := data.Schedule{
+		Musicians: make([]data.MusicianSchedule, len(musicians)),
+		Duration:  tl.at.Sub(origin),
+	}
+	for i, m := range musicians {
+		schedule.Musicians[i] = data.MusicianSchedule{
+			Musician: m.musician.Id,
+			Load:     gauges[m.musician.Id].load,
+		}
+	}
+	for _, track := range slices.Sorted(maps.Keys(unassigned)) {
+		if load := unassigned[track].load; load.Notes > 0 {
+			schedule.Unassigned = append(schedule.Unassigned, data.TrackSchedule{Track: track, Load: load})
+		}
+	}
+	return schedule
+}
@@ -0,0 +1,88 @@
+package baton
+
+import (
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+func TestGauge(t *testing.T) {
+	g := newGauge()
+	// A chord, a note held over it, then a note once the chord ended
+	for _, key := range []uint8{60, 64, 67} {
+		g.record(0, 1, midi.NoteOn(0, key, 100))
+	}
+	g.record(500*time.Millisecond, 1, midi.NoteOn(0, 72, 100))
+	for _, key := range []uint8{60, 64, 67} {
+		g.record(time.Second, 1, midi.NoteOff(0, key))
+	}
+	g.record(1200*time.Millisecond, 1, midi.NoteOn(0, 74, 100))
+
+	// The chord leaves the second starting with it
+	assert.Equal(t, data.Load{
+		Events:    8,
+		Notes:     5,
+		PeakRate:  4,
+		Polyphony: 4,
+		First:     0,
+		Last:      1200 * time.Millisecond,
+	}, g.load)
+}
+
+func TestPlaySchedule(t *testing.T) {
+	b := New(logging.Base(), config.Playback{}, nil).(*baton)
+	var roster []data.Musician
+	for range 2 {
+		roster = append(roster, addMusician(b, systemClock{}, &recordingClient{}))
+	}
+
+	// The melody is played twice, twice faster, by the first musician
+	// only. The second stands by and nobody plays the bass.
+	opts := data.DefaultPlayOptions
+	opts.DryRun = true
+	opts.Interpretation.Tempo = 2
+	opts.Loops = 2
+	opts.Mapping = []data.TrackAssignment{{Track: 1, Musician: &roster[0].Id}}
+	p, err := b.Play("test.mid", loopMusic(t), opts)
+	require.NoError(t, err)
+	require.NotNil(t, p.Schedule)
+	assert.Equal(t, data.Schedule{
+		Musicians: []data.MusicianSchedule{
+			{
+				Musician: roster[0].Id,
+				Load: data.Load{
+					Events:    14,
+					Notes:     6,
+					PeakRate:  2,
+					Polyphony: 1,
+					First:     0,
+					Last:      4 * time.Second,
+				},
+			},
+			{Musician: roster[1].Id},
+		},
+		Unassigned: []data.TrackSchedule{{
+			Track: 2,
+			Load: data.Load{
+				Events:    4,
+				Notes:     2,
+				PeakRate:  1,
+				Polyphony: 1,
+				First:     750 * time.Millisecond,
+				Last:      3250 * time.Millisecond,
+			},
+		}},
+		Duration: 4 * time.Second,
+	}, *p.Schedule)
+
+	// Nothing is sent to the musicians
+	for _, m := range b.musicians {
+		assert.Empty(t, m.link.cli.(*recordingClient).messages())
+	}
+}
@@ -0,0 +1,176 @@
+package baton
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"crossjoin.com/gorxestra/data"
+)
+
+// maxHistory is the number of performances ended kept to report their
+// status
+const maxHistory = 16
+
+func (b *baton) SetSection(s data.Section) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sections[s.Name] = s
+	return nil
+}
+
+func (b *baton) RemoveSection(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.sections[name]; !ok {
+		return data.ErrSectionNotFound
+	}
+	delete(b.sections, name)
+	return nil
+}
+
+func (b *baton) Sections() []data.Section {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sections := make([]data.Section, 0, len(b.sections))
+	for _, s := range b.sections {
+		sections = append(sections, s)
+	}
+	slices.SortFunc(sections, func(a, b data.Section) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return sections
+}
+
+// section returns the section of the name, nil for the whole orchestra.
+// Must be called with the lock held.
+func (b *baton) section(name string) (*data.Section, error) {
+	if name == "" {
+		return nil, nil
+	}
+	s, ok := b.sections[name]
+	if !ok {
+		return nil, data.ErrSectionNotFound
+	}
+	return &s, nil
+}
+
+// claim reserves a section for a performance. The whole orchestra, named
+// "", plays alone while the sections play alongside each other. Must be
+// called with the lock held.
+func (b *baton) claim(name string) error {
+	if b.claimed[""] || b.claimed[name] || (name == "" && len(b.claimed) > 0) {
+		return data.MusicAlreadyBeingPlayed
+	}
+	b.claimed[name] = true
+	return nil
+}
+
+// release frees the section claimed
+func (b *baton) release(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.claimed, name)
+}
+
+// includes tells whether a musician belongs to a section, every musician
+// belongs to the whole orchestra
+func includes(section *data.Section, m data.Musician) bool {
+	return section == nil || section.Includes(m)
+}
+
+// available returns the musicians of the section not playing in another
+// performance nor picked by one starting. Must be called with the lock
+// held.
+func (b *baton) available(section *data.Section) []*member {
+	var musicians []*member
+	for _, m := range b.musicians {
+		if !includes(section, m.musician) {
+			continue
+		}
+		if _, ok := b.picked[m.musician.Id]; ok {
+			continue
+		}
+		busy := slices.ContainsFunc(b.perfs, func(perf *performance) bool {
+			return perf.plays(m.musician.Id)
+		})
+		if !busy {
+			musicians = append(musicians, m)
+		}
+	}
+	return musicians
+}
+
+// pick keeps the musicians for the performance starting, until it plays
+// or fails. Must be called with the lock held.
+func (b *baton) pick(id data.ID, musicians []*member) {
+	for _, m := range musicians {
+		b.picked[m.musician.Id] = id
+	}
+}
+
+// unpick gives back the musicians picked by the performance. Must be
+// called with the lock held.
+func (b *baton) unpick(id data.ID) {
+	maps.DeleteFunc(b.picked, func(_, perf data.ID) bool {
+		return perf == id
+	})
+}
+
+// find returns the performance being played with the id, the zero id
+// addressing the performance started last. Must be called with the lock
+// held.
+func (b *baton) find(id data.ID) (*performance, error) {
+	if id == (data.ID{}) {
+		if len(b.perfs) == 0 {
+			return nil, data.ErrNoMusicPlaying
+		}
+		return b.perfs[len(b.perfs)-1], nil
+	}
+
+	idx := slices.IndexFunc(b.perfs, func(perf *performance) bool {
+		return perf.id == id
+	})
+	if idx < 0 {
+		return nil, data.ErrPerformanceNotFound
+	}
+	return b.perfs[idx], nil
+}
+
+// retire moves an ended performance to the history, replaced by the
+// performance following it if any. Must be called with the lock held.
+func (b *baton) retire(perf, next *performance) {
+	idx := slices.Index(b.perfs, perf)
+	switch {
+	case idx < 0:
+	case next != nil:
+		b.perfs[idx] = next
+	default:
+		b.perfs = slices.Delete(b.perfs, idx, idx+1)
+		delete(b.claimed, perf.sectionName())
+		b.free(perf.id)
+	}
+
+	b.history = append(b.history, perf)
+	if len(b.history) > maxHistory {
+		b.history = slices.Delete(b.history, 0, len(b.history)-maxHistory)
+	}
+}
+
+func (b *baton) Performances() []data.PlaybackStatus {
+	b.mu.Lock()
+	perfs := slices.Clone(b.perfs)
+	b.mu.Unlock()
+
+	statuses := make([]data.PlaybackStatus, len(perfs))
+	for i, perf := range perfs {
+		statuses[i] = perf.status()
+	}
+	return statuses
+}
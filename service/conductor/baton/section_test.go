@@ -0,0 +1,145 @@
+package baton
+
+import (
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSections(t *testing.T) {
+	b := New(logging.Base(), config.Playback{
+		MaxClockSkew:  typ.Duration(time.Second),
+		MusiciansWait: typ.Duration(50 * time.Millisecond),
+	}, nil).(*baton)
+
+	violin := addCapableMusician(b, systemClock{}, &recordingClient{}, &data.Capabilities{Labels: []string{"strings"}})
+	trumpet := addCapableMusician(b, systemClock{}, &recordingClient{}, &data.Capabilities{Labels: []string{"brass"}})
+
+	assert.ErrorIs(t, b.SetSection(data.Section{Name: "strings"}), data.ErrInvalidSection)
+	require.NoError(t, b.SetSection(data.Section{Name: "strings", Labels: []string{"strings"}}))
+	require.NoError(t, b.SetSection(data.Section{Name: "brass", Musicians: []data.ID{trumpet.Id}}))
+	assert.Equal(t, []string{"brass", "strings"}, []string{b.Sections()[0].Name, b.Sections()[1].Name})
+
+	opts := data.DefaultPlayOptions
+	opts.Section = "woodwinds"
+	_, err := b.Play("strings.mid", loopMusic(t), opts)
+	assert.ErrorIs(t, err, data.ErrSectionNotFound)
+
+	// The sections play alongside each other, each with its musicians
+	opts.Section = "strings"
+	strings, err := b.Play("strings.mid", loopMusic(t), opts)
+	require.NoError(t, err)
+	assert.Equal(t, &violin.Id, strings.Tracks[0].Musician)
+
+	_, err = b.Play("strings.mid", loopMusic(t), opts)
+	assert.ErrorIs(t, err, data.MusicAlreadyBeingPlayed)
+	_, err = b.Play("all.mid", loopMusic(t), data.DefaultPlayOptions)
+	assert.ErrorIs(t, err, data.MusicAlreadyBeingPlayed)
+
+	opts.Section = "brass"
+	brass, err := b.Play("brass.mid", loopMusic(t), opts)
+	require.NoError(t, err)
+	assert.Equal(t, &trumpet.Id, brass.Tracks[0].Musician)
+	assert.NotEqual(t, strings.Performance, brass.Performance)
+
+	perfs := b.Performances()
+	require.Len(t, perfs, 2)
+	assert.Equal(t, "strings", perfs[0].Section)
+	assert.Equal(t, "brass", perfs[1].Section)
+
+	// A musician registering joins the performance of its section
+	cello := &member{musician: data.Musician{
+		Id:           data.GenId(),
+		Capabilities: &data.Capabilities{Labels: []string{"strings"}},
+	}}
+	b.mu.Lock()
+	assert.Equal(t, strings.Performance, b.joinable(cello).id)
+	assert.Nil(t, b.joinable(b.musicians[1]))
+	b.mu.Unlock()
+
+	// Each performance has its own transport
+	require.NoError(t, b.Pause(strings.Performance))
+	st, err := b.Status(strings.Performance)
+	require.NoError(t, err)
+	assert.Equal(t, data.PlaybackPaused, st.State)
+	st, err = b.Status(brass.Performance)
+	require.NoError(t, err)
+	assert.Equal(t, data.PlaybackPlaying, st.State)
+
+	require.NoError(t, b.Stop(brass.Performance))
+	assert.Eventually(t, func() bool {
+		return len(b.Performances()) == 1
+	}, time.Second, 5*time.Millisecond)
+	st, err = b.Status(brass.Performance)
+	require.NoError(t, err)
+	assert.Equal(t, data.PlaybackStopped, st.State)
+	assert.Equal(t, strings.Performance, status(t, b).Performance)
+
+	// The section stopped plays again
+	brass, err = b.Play("brass.mid", loopMusic(t), opts)
+	require.NoError(t, err)
+
+	require.NoError(t, b.Stop(strings.Performance))
+	require.NoError(t, b.Stop(brass.Performance))
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, time.Second, 5*time.Millisecond)
+	assert.ErrorIs(t, b.Stop(brass.Performance), data.ErrPerformanceNotFound)
+	_, err = b.Status(data.GenId())
+	assert.ErrorIs(t, err, data.ErrPerformanceNotFound)
+
+	// The whole orchestra plays once the sections are done
+	all, err := b.Play("all.mid", loopMusic(t), data.DefaultPlayOptions)
+	require.NoError(t, err)
+	assert.Len(t, all.Tracks, 2)
+	require.NoError(t, b.Stop(all.Performance))
+
+	require.NoError(t, b.RemoveSection("brass"))
+	assert.ErrorIs(t, b.RemoveSection("brass"), data.ErrSectionNotFound)
+}
+
+// TestPickedMusicians checks that the musicians picked by a performance
+// starting are not picked by another on an overlapping section
+func TestPickedMusicians(t *testing.T) {
+	b := New(logging.Base(), config.Playback{
+		MaxClockSkew:  typ.Duration(time.Second),
+		MusiciansWait: typ.Duration(50 * time.Millisecond),
+	}, nil).(*baton)
+
+	m := addCapableMusician(b, systemClock{}, &recordingClient{}, &data.Capabilities{Labels: []string{"strings"}})
+	require.NoError(t, b.SetSection(data.Section{Name: "strings", Labels: []string{"strings"}}))
+	require.NoError(t, b.SetSection(data.Section{Name: "soloists", Musicians: []data.ID{m.Id}}))
+
+	// A performance of the strings picks the violin while it starts
+	starting := data.GenId()
+	strings := b.sections["strings"]
+	musicians, err := b.awaitMusicians(starting, &strings, 1)
+	require.NoError(t, err)
+	require.Len(t, musicians, 1)
+
+	opts := data.DefaultPlayOptions
+	opts.Section = "soloists"
+	opts.MinMusicians = 1
+	_, err = b.Play("solo.mid", loopMusic(t), opts)
+	assert.ErrorIs(t, err, data.ErrNotEnoughMusicians)
+
+	// The violin is free once the performance failed to start
+	b.mu.Lock()
+	b.unpick(starting)
+	b.mu.Unlock()
+	solo, err := b.Play("solo.mid", loopMusic(t), opts)
+	require.NoError(t, err)
+	assert.Equal(t, &m.Id, solo.Tracks[0].Musician)
+	assert.Empty(t, b.picked)
+
+	require.NoError(t, b.Stop(solo.Performance))
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, time.Second, 5*time.Millisecond)
+}
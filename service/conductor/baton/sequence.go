@@ -0,0 +1,155 @@
+package baton
+
+import (
+	"io"
+	"slices"
+	"sort"
+	"time"
+
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+// event is a playable MIDI message of a track positioned from the start
+// of the music
+type event struct {
+	track int
+	at    time.Duration
+	msg   []byte
+}
+
+// trackUsage counts the playable events of a track
+type trackUsage struct {
+	events int
+	// channels counts the channel messages of each MIDI channel
+	channels [16]int
+	// programs are the programs set on each MIDI channel
+	programs [16][]uint8
+	// polyphony is the largest number of notes sounding at once in the
+	// track, and in each of its channels
+	polyphony        int
+	channelPolyphony [16]int
+}
+
+// sequence is the time ordered list of events of a music
+type sequence struct {
+	tracks int
+	events []event
+	// usage is the usage of each track
+	usage []trackUsage
+	// length is the time of the last event of any kind, including the
+	// end of the tracks
+	length time.Duration
+	// bars are the start times of the bars
+	bars []time.Duration
+	// beats are the start times of the beats of each bar
+	beats [][]time.Duration
+}
+
+// readSequence reads a SMF and orders the playable events of all tracks
+// by their time
+func readSequence(r io.Reader) (sequence, error) {
+	tracks := smf.ReadTracksFrom(r)
+	if err := tracks.Error(); err != nil {
+		return sequence{}, err
+	}
+
+	seq := sequence{
+		tracks: len(tracks.SMF().Tracks),
+		events: make([]event, 0, 1024),
+		usage:  make([]trackUsage, len(tracks.SMF().Tracks)),
+	}
+
+	var meters []meter
+	var end int64
+	// The notes sounding in each track and channel give their polyphony
+	sounding := make(map[voice]bool)
+	voices := make([]int, seq.tracks)
+	channelVoices := make([][16]int, seq.tracks)
+	tracks.Do(func(ev smf.TrackEvent) {
+		at := time.Duration(ev.AbsMicroSeconds) * time.Microsecond
+		seq.length = max(seq.length, at)
+		end = max(end, ev.AbsTicks)
+
+		var num, denom uint8
+		if ev.Message.GetMetaMeter(&num, &denom) {
+			meters = append(meters, meter{tick: ev.AbsTicks, num: num, denom: denom})
+		}
+
+		if !ev.Message.IsPlayable() {
+			return
+		}
+
+		usage := &seq.usage[ev.TrackNo]
+		usage.events++
+		var channel, key, value uint8
+		if ev.Message.GetChannel(&channel) {
+			usage.channels[channel]++
+		}
+
+		switch {
+		case ev.Message.GetNoteStart(&channel, &key, &value):
+			v := voice{track: ev.TrackNo, channel: channel, key: key}
+			if !sounding[v] {
+				sounding[v] = true
+				voices[ev.TrackNo]++
+				channelVoices[ev.TrackNo][channel]++
+				usage.polyphony = max(usage.polyphony, voices[ev.TrackNo])
+				usage.channelPolyphony[channel] = max(usage.channelPolyphony[channel], channelVoices[ev.TrackNo][channel])
+			}
+		case ev.Message.GetNoteEnd(&channel, &key):
+			v := voice{track: ev.TrackNo, channel: channel, key: key}
+			if sounding[v] {
+				delete(sounding, v)
+				voices[ev.TrackNo]--
+				channelVoices[ev.TrackNo][channel]--
+			}
+		case ev.Message.GetProgramChange(&channel, &value):
+			if !slices.Contains(usage.programs[channel], value) {
+				usage.programs[channel] = append(usage.programs[channel], value)
+			}
+		}
+
+		seq.events = append(seq.events, event{
+			track: ev.TrackNo,
+			at:    at,
+			msg:   ev.Message,
+		})
+	})
+
+	sort.SliceStable(seq.events, func(i, j int) bool {
+		return seq.events[i].at < seq.events[j].at
+	})
+
+	if err := tracks.Error(); err != nil {
+		return sequence{}, err
+	}
+	seq.bars, seq.beats = barTimes(tracks.SMF(), meters, end)
+
+	return seq, nil
+}
+
+// without returns the sequence without the events of the tracks, their
+// usage is cleared
+func (s sequence) without(tracks []int) sequence {
+	if len(tracks) == 0 {
+		return s
+	}
+
+	s.usage = slices.Clone(s.usage)
+	for _, track := range tracks {
+		s.usage[track] = trackUsage{}
+	}
+	s.events = slices.DeleteFunc(slices.Clone(s.events), func(ev event) bool {
+		return slices.Contains(tracks, ev.track)
+	})
+	return s
+}
+
+// duration returns the time of the end of the music, as given by the
+// tempo map of the file
+func (s sequence) duration() time.Duration {
+	if len(s.events) == 0 {
+		return s.length
+	}
+	return max(s.length, s.events[len(s.events)-1].at)
+}
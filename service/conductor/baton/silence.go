@@ -0,0 +1,61 @@
+package baton
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// closeTimeout bounds how long closing the baton waits for the
+// performances to stop
+const closeTimeout = 2 * time.Second
+
+// silence ends the notes sounding on the musician of the link. A musician
+// out of reach keeps them, there is nothing more to do.
+func (b *baton) silence(l *link, all bool) {
+	if err := l.silence(all); err != nil && !errors.Is(err, errLinkClosed) {
+		l.log.With("error", err).Warn("silencing musician")
+	}
+}
+
+// Panic ends the notes sounding on every musician registered and turns
+// all notes and sound off on all their channels
+func (b *baton) Panic() {
+	b.mu.Lock()
+	musicians := slices.Clone(b.musicians)
+	b.mu.Unlock()
+
+	b.log.With("musicians", len(musicians)).Warn("silencing every musician")
+	var wg sync.WaitGroup
+	for _, m := range musicians {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.silence(m.link, true)
+		}()
+	}
+	wg.Wait()
+}
+
+// Close stops the performances and silences the musicians, so that no
+// note is left sounding once the conductor is gone
+func (b *baton) Close() {
+	b.mu.Lock()
+	perfs := slices.Clone(b.perfs)
+	b.mu.Unlock()
+
+	deadline := b.clock.NewTimer(closeTimeout)
+	defer deadline.Stop()
+	for _, perf := range perfs {
+		if err := b.Stop(perf.id); err != nil {
+			continue
+		}
+		select {
+		case <-perf.done:
+		case <-deadline.C():
+		}
+	}
+
+	b.Panic()
+}
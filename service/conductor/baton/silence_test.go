@@ -0,0 +1,82 @@
+package baton
+
+import (
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+func TestLinkSilence(t *testing.T) {
+	cli := &recordingClient{}
+	l := &link{log: logging.Base(), clock: systemClock{}, musician: data.Musician{Id: data.GenId()}, cli: cli}
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, l.send([]Note{
+		{at: later, note: midi.NoteOn(1, 60, 100)},
+		{at: later, note: midi.NoteOn(0, 64, 100)},
+		{at: later, note: midi.NoteOn(0, 62, 100)},
+		{at: later, note: midi.NoteOff(0, 62)},
+	}))
+
+	// The notes still sounding are ended after the last note sent, then
+	// their channels are silenced
+	require.NoError(t, l.silence(false))
+	assert.Equal(t, []midi.Message{
+		midi.NoteOff(0, 64),
+		midi.NoteOff(1, 60),
+		midi.ControlChange(0, ccAllNotesOff, 0),
+		midi.ControlChange(0, ccAllSoundOff, 0),
+		midi.ControlChange(1, ccAllNotesOff, 0),
+		midi.ControlChange(1, ccAllSoundOff, 0),
+	}, cli.messages()[4:])
+	for _, n := range cli.notes[4:] {
+		assert.Equal(t, later, n.At)
+	}
+
+	// Nothing is left sounding
+	require.NoError(t, l.silence(false))
+	assert.Len(t, cli.messages(), 10)
+
+	// Every channel is silenced on a panic
+	require.NoError(t, l.silence(true))
+	msgs := cli.messages()[10:]
+	require.Len(t, msgs, 32)
+	assert.Equal(t, midi.ControlChange(15, ccAllSoundOff, 0), msgs[31])
+
+	l.close()
+	assert.ErrorIs(t, l.silence(true), errLinkClosed)
+}
+
+func TestClose(t *testing.T) {
+	b := New(logging.Base(), config.Playback{
+		LookAhead:     typ.Duration(10 * time.Millisecond),
+		MaxClockSkew:  typ.Duration(time.Second),
+		MusiciansWait: typ.Duration(50 * time.Millisecond),
+	}, nil).(*baton)
+
+	var clis []*recordingClient
+	for range 2 {
+		cli := &recordingClient{}
+		addMusician(b, systemClock{}, cli)
+		clis = append(clis, cli)
+	}
+
+	_, err := b.Play("test.mid", loopMusic(t), data.DefaultPlayOptions)
+	require.NoError(t, err)
+
+	// The performance is stopped and every musician silenced
+	b.Close()
+	assert.True(t, idle(b))
+	for _, cli := range clis {
+		msgs := cli.messages()
+		require.GreaterOrEqual(t, len(msgs), 32)
+		assert.Equal(t, midi.ControlChange(15, ccAllSoundOff, 0), msgs[len(msgs)-1])
+	}
+}
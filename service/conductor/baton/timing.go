@@ -0,0 +1,46 @@
+package baton
+
+import "time"
+
+// Clock tells the time and waits for it. The baton schedules the notes,
+// pauses and timeouts on it, the local clock out of the tests.
+type Clock interface {
+	Now() time.Time
+	// NewTimer returns a timer sending the time on its channel once the
+	// duration elapsed
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event of a clock, as time.Timer
+type Timer interface {
+	C() <-chan time.Time
+	// Reset changes the timer to fire once the duration elapsed
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
+// systemClock is the local clock
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// sleep waits for the duration to elapse on the clock
+func sleep(c Clock, d time.Duration) {
+	t := c.NewTimer(d)
+	defer t.Stop()
+	<-t.C()
+}
@@ -0,0 +1,284 @@
+package baton
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+// manualClock is a clock moving only when the test advances it. The
+// timers due fire one at a time, in the order of their time, each once
+// the goroutine owning the one fired before reset or stopped it, so that
+// the time only moves once the baton is done with it.
+type manualClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*manualTimer
+	// armed counts the timers created and reset
+	armed int
+}
+
+type manualTimer struct {
+	clock *manualClock
+	c     chan time.Time
+	at    time.Time
+	// active is set while the timer waits for its time
+	active bool
+	// pending is set once the timer fired, until it is reset or stopped
+	pending bool
+}
+
+func newManualClock(now time.Time) *manualClock {
+	c := &manualClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) NewTimer(d time.Duration) Timer {
+	t := &manualTimer{clock: c, c: make(chan time.Time, 1)}
+	c.mu.Lock()
+	c.timers = append(c.timers, t)
+	c.mu.Unlock()
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the clock forward, firing the timers due on the way
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	end := c.now.Add(d)
+	for {
+		for slices.ContainsFunc(c.timers, func(t *manualTimer) bool { return t.pending }) {
+			c.cond.Wait()
+		}
+
+		var next *manualTimer
+		for _, t := range c.timers {
+			if t.active && !t.at.After(end) && (next == nil || t.at.Before(next.at)) {
+				next = t
+			}
+		}
+		if next == nil {
+			c.now = end
+			return
+		}
+
+		c.now = maxTime(c.now, next.at)
+		next.active, next.pending = false, true
+		next.c <- c.now
+	}
+}
+
+// awaitArmed waits for the timers to be created or reset a number of
+// times
+func (c *manualClock) awaitArmed(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.armed < n {
+		c.cond.Wait()
+	}
+}
+
+// timesArmed returns the number of times the timers were created or reset
+func (c *manualClock) timesArmed() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.armed
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *manualTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	active := t.active
+	t.drain()
+	t.at = c.now.Add(d)
+	t.active, t.pending = true, false
+	c.armed++
+	c.cond.Broadcast()
+	return active
+}
+
+func (t *manualTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	active := t.active
+	t.drain()
+	t.active, t.pending = false, false
+	c.timers = slices.DeleteFunc(c.timers, func(other *manualTimer) bool { return other == t })
+	c.cond.Broadcast()
+	return active
+}
+
+// drain drops the time sent and not received, as a timer stopped or
+// reset does
+func (t *manualTimer) drain() {
+	select {
+	case <-t.c:
+	default:
+	}
+}
+
+func TestManualClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := newManualClock(start)
+
+	// The timers fire in the order of their time, the clock at their time
+	var fired []time.Duration
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t := c.NewTimer(300 * time.Millisecond)
+		defer t.Stop()
+		for range 3 {
+			at := <-t.C()
+			fired = append(fired, at.Sub(start))
+			t.Reset(200 * time.Millisecond)
+		}
+	}()
+	c.awaitArmed(1)
+
+	c.Advance(time.Second)
+	<-done
+	assert.Equal(t, []time.Duration{300 * time.Millisecond, 500 * time.Millisecond, 700 * time.Millisecond}, fired)
+	assert.Equal(t, start.Add(time.Second), c.Now())
+
+	// A sleep lasts until the clock reaches its end
+	slept := make(chan struct{})
+	go func() {
+		sleep(c, time.Minute)
+		close(slept)
+	}()
+	c.awaitArmed(5)
+	c.Advance(59 * time.Second)
+	select {
+	case <-slept:
+		t.Fatal("woke up early")
+	default:
+	}
+	c.Advance(time.Second)
+	<-slept
+}
+
+// TestPlayClock plays a whole song on a manual clock, pausing it on the
+// way, and checks when every message is due on the musician
+func TestPlayClock(t *testing.T) {
+	raw, err := os.ReadFile("../../../files/conductor/queen.mid")
+	require.NoError(t, err)
+	seq, err := readSequence(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := newManualClock(start)
+	b := New(logging.Base(), config.Playback{}, nil).(*baton)
+	b.clock = clk
+	cli := &recordingClient{}
+	addMusician(b, clk, cli)
+
+	// The play loop creates its timer and sets it to the first note, the
+	// position ticker creates its own
+	armed := clk.timesArmed()
+	_, err = b.Play("queen.mid", bytes.NewReader(raw), data.DefaultPlayOptions)
+	require.NoError(t, err)
+	clk.awaitArmed(armed + 3)
+
+	// The pause silences the notes sounding at once, the music goes on
+	// from where it was once resumed, at the next pause poll
+	pausedAt := 10 * time.Second
+	clk.Advance(pausedAt)
+	assert.Equal(t, pausedAt, status(t, b).Elapsed)
+	armed = clk.timesArmed()
+	require.NoError(t, b.Pause(data.ID{}))
+	clk.awaitArmed(armed + 1)
+	clk.Advance(time.Second)
+	require.NoError(t, b.Resume(data.ID{}))
+	shift := time.Second + pausePollInterval
+	clk.Advance(seq.duration() + shift)
+
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, 5*time.Second, 5*time.Millisecond)
+	assert.True(t, status(t, b).Completed)
+
+	// The messages are due at their time in the music, those after the
+	// pause later by the time paused. The ends of the notes silenced by
+	// the pause are left out. The silences of the pause and of the end
+	// come in any order.
+	type due struct {
+		at  time.Duration
+		msg string
+	}
+	dueOf := func(at time.Duration, msg []byte) due {
+		return due{at: at, msg: midi.Message(msg).String()}
+	}
+	var before, after, paused, ended []due
+	sounding := make(voices)
+	tr := newTransposer(0)
+	for _, ev := range seq.events {
+		if ev.at > pausedAt {
+			continue
+		}
+		msg, _ := tr.transpose(ev.track, ev.msg)
+		sounding.update(ev.track, msg)
+		before = append(before, dueOf(ev.at, msg))
+	}
+	for _, ev := range sounding.release() {
+		paused = append(paused, dueOf(pausedAt, ev.msg))
+	}
+	tr.reset()
+	for _, ev := range seq.events {
+		if ev.at <= pausedAt {
+			continue
+		}
+		msg, ok := tr.transpose(ev.track, ev.msg)
+		if !ok {
+			continue
+		}
+		sounding.update(ev.track, msg)
+		after = append(after, dueOf(ev.at+shift, msg))
+	}
+	for _, ev := range sounding.release() {
+		ended = append(ended, dueOf(seq.duration()+shift, ev.msg))
+	}
+
+	cli.mu.Lock()
+	sent := make([]due, len(cli.notes))
+	for i, n := range cli.notes {
+		sent[i] = dueOf(n.At.Sub(start), n.Message)
+	}
+	cli.mu.Unlock()
+
+	require.Len(t, sent, len(before)+len(paused)+len(after)+len(ended))
+	assert.Equal(t, before, sent[:len(before)])
+	sent = sent[len(before):]
+	assert.ElementsMatch(t, paused, sent[:len(paused)])
+	sent = sent[len(paused):]
+	assert.Equal(t, after, sent[:len(after)])
+	assert.ElementsMatch(t, ended, sent[len(after):])
+}
@@ -1,49 +1,10 @@
 package baton
 
-type Track struct {
-	ch    chan Note
-	index int
-}
+import "time"
 
+// Note is a MIDI message of a track scheduled to sound at a given time
 type Note struct {
 	index int
+	at    time.Time
 	note  []byte
 }
-
-func (t *Track) Close() error {
-	// Not needed for our implementation
-	return nil
-}
-
-func (t *Track) IsOpen() bool {
-	return true
-}
-
-func (t *Track) Number() int {
-	// Not needed for our implementation
-	return 0
-}
-
-func (t *Track) Open() error {
-	// Not needed for our implementation
-	return nil
-}
-
-func (t *Track) Send(bs []byte) error {
-	//t.wg.Add(1)
-	t.ch <- Note{
-		index: t.index,
-		note:  bs,
-	}
-	return nil
-}
-
-func (t *Track) String() string {
-	// Not needed for our implementation
-	return "" //t.out.String()
-}
-
-func (t *Track) Underlying() interface{} {
-	// Not needed for our implementation
-	return nil
-}
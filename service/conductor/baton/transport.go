@@ -0,0 +1,134 @@
+package baton
+
+import (
+	"sort"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+// pausePollInterval is how often a paused performance checks whether it
+// was resumed
+const pausePollInterval = 100 * time.Millisecond
+
+type transportOp int
+
+const (
+	opStop transportOp = iota
+	opSeek
+	opTempo
+	opTranspose
+	// opPause wakes the performance so that it silences its notes
+	opPause
+)
+
+// transport is a command changing the course of the performance
+type transport struct {
+	op        transportOp
+	pos       time.Duration
+	tempo     float64
+	semitones int
+}
+
+// voice is a note sounding on a track
+type voice struct {
+	track   int
+	channel uint8
+	key     uint8
+}
+
+// voices keeps track of the notes sounding so that they can be silenced
+type voices map[voice]struct{}
+
+func (v voices) update(track int, msg []byte) {
+	var channel, key, velocity uint8
+	m := midi.Message(msg)
+	switch {
+	case m.GetNoteStart(&channel, &key, &velocity):
+		v[voice{track: track, channel: channel, key: key}] = struct{}{}
+	case m.GetNoteEnd(&channel, &key):
+		delete(v, voice{track: track, channel: channel, key: key})
+	}
+}
+
+// release returns a note off event for every sounding note, then turns
+// all notes and sound off on their channels, and forgets them
+func (v voices) release() []event {
+	offs := make([]event, 0, len(v))
+	channels := make(map[voice]bool)
+	for vc := range v {
+		offs = append(offs, event{
+			track: vc.track,
+			msg:   midi.NoteOff(vc.channel, vc.key),
+		})
+		channels[voice{track: vc.track, channel: vc.channel}] = true
+	}
+	for ch := range channels {
+		offs = append(offs,
+			event{track: ch.track, msg: midi.ControlChange(ch.channel, ccAllNotesOff, 0)},
+			event{track: ch.track, msg: midi.ControlChange(ch.channel, ccAllSoundOff, 0)},
+		)
+	}
+	clear(v)
+	return offs
+}
+
+// seek returns the index of the first event at or after pos
+func (s sequence) seek(pos time.Duration) int {
+	return sort.Search(len(s.events), func(i int) bool {
+		return s.events[i].at >= pos
+	})
+}
+
+// Stop ends the performance
+func (b *baton) Stop(id data.ID) error {
+	return b.control(id, transport{op: opStop})
+}
+
+// Seek moves the music of the performance to pos from its start
+func (b *baton) Seek(id data.ID, pos time.Duration) error {
+	return b.control(id, transport{op: opSeek, pos: pos})
+}
+
+// SetTempo changes the tempo factor of the performance
+func (b *baton) SetTempo(id data.ID, factor float64) error {
+	if !data.ValidTempo(factor) {
+		return data.ErrInvalidTempo
+	}
+	return b.control(id, transport{op: opTempo, tempo: factor})
+}
+
+// Transpose changes the transposition of the performance
+func (b *baton) Transpose(id data.ID, semitones int) error {
+	if !data.ValidTranspose(semitones) {
+		return data.ErrInvalidTranspose
+	}
+	return b.control(id, transport{op: opTranspose, semitones: semitones})
+}
+
+// control hands a transport command to the performance
+func (b *baton) control(id data.ID, t transport) error {
+	b.mu.Lock()
+	perf, err := b.find(id)
+	if err == nil && t.op == opStop {
+		// The music cued is not played after a stop
+		perf.cued = nil
+	}
+	b.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if t.op == opSeek && (t.pos < 0 || t.pos > perf.length) {
+		return data.ErrInvalidPosition
+	}
+
+	select {
+	case perf.control <- t:
+		return nil
+	case <-perf.done:
+		return data.ErrNoMusicPlaying
+	}
+}
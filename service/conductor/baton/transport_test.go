@@ -0,0 +1,211 @@
+package baton
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/daemon/musiciand/api/client/v1"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/broadcast"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+// recordingClient is a musician keeping the notes it is sent over REST
+type recordingClient struct {
+	client.ClientDaemon
+
+	mu    sync.Mutex
+	notes []data.Note
+}
+
+func (c *recordingClient) Play(note data.Note) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notes = append(c.notes, note)
+	return nil
+}
+
+func (c *recordingClient) Clock(originate time.Time) (data.ClockSample, error) {
+	now := time.Now()
+	return data.ClockSample{Originate: originate, Receive: now, Transmit: now}, nil
+}
+
+func (c *recordingClient) OpenStream() (client.NoteStream, error) {
+	return nil, errors.New("streaming not supported")
+}
+
+func (c *recordingClient) messages() []midi.Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	msgs := make([]midi.Message, len(c.notes))
+	for i := range c.notes {
+		msgs[i] = c.notes[i].Message
+	}
+	return msgs
+}
+
+func TestVoices(t *testing.T) {
+	v := make(voices)
+	v.update(0, midi.NoteOn(0, 60, 100))
+	v.update(0, midi.NoteOn(0, 64, 100))
+	v.update(1, midi.NoteOn(1, 60, 100))
+	v.update(0, midi.NoteOn(0, 64, 0)) // note on without velocity ends the note
+	v.update(1, midi.NoteOff(1, 60))
+
+	// The note still sounding is ended, then its channel is silenced
+	offs := v.release()
+	require.Len(t, offs, 3)
+	for _, off := range offs {
+		assert.Equal(t, 0, off.track)
+	}
+	assert.Equal(t, []byte(midi.NoteOff(0, 60)), offs[0].msg)
+	assert.Equal(t, []byte(midi.ControlChange(0, ccAllNotesOff, 0)), offs[1].msg)
+	assert.Equal(t, []byte(midi.ControlChange(0, ccAllSoundOff, 0)), offs[2].msg)
+	assert.Empty(t, v)
+}
+
+func TestSequenceSeek(t *testing.T) {
+	seq := sequence{events: []event{{at: 0}, {at: time.Second}, {at: time.Second}, {at: 2 * time.Second}}}
+	assert.Equal(t, 0, seq.seek(0))
+	assert.Equal(t, 1, seq.seek(500*time.Millisecond))
+	assert.Equal(t, 1, seq.seek(time.Second))
+	assert.Equal(t, 4, seq.seek(3*time.Second))
+}
+
+func TestBarTimes(t *testing.T) {
+	file := smf.New()
+	file.TimeFormat = smf.MetricTicks(960)
+	var tr smf.Track
+	tr.Add(0, smf.MetaTempo(120))
+	tr.Add(0, smf.MetaMeter(3, 4))
+	tr.Add(2*3*960, smf.MetaTempo(60)) // third bar
+	tr.Add(0, smf.MetaMeter(4, 4))
+	tr.Close(2 * 4 * 960)
+	require.NoError(t, file.Add(tr))
+	var buf bytes.Buffer
+	_, err := file.WriteTo(&buf)
+	require.NoError(t, err)
+
+	seq, err := readSequence(&buf)
+	require.NoError(t, err)
+
+	// Two bars of 3/4 at 120 bpm then two bars of 4/4 at 60 bpm
+	assert.Equal(t, []time.Duration{
+		0,
+		1500 * time.Millisecond,
+		3 * time.Second,
+		7 * time.Second,
+	}, seq.bars)
+	assert.Equal(t, 11*time.Second, seq.duration())
+}
+
+func TestTransport(t *testing.T) {
+	events := broadcast.New[data.Event]()
+	sub := events.Subscribe(64)
+	b := New(logging.Base(), config.Playback{
+		LookAhead:    typ.Duration(10 * time.Millisecond),
+		MaxClockSkew: typ.Duration(time.Second),
+	}, events).(*baton)
+
+	assert.ErrorIs(t, b.Stop(data.ID{}), data.ErrNoMusicPlaying)
+	assert.ErrorIs(t, b.Pause(data.ID{}), data.ErrNoMusicPlaying)
+	assert.Equal(t, data.PlaybackIdle, status(t, b).State)
+
+	cli := &recordingClient{}
+	m := addMusician(b, systemClock{}, cli)
+
+	// One note from 0s to 1s and another from 2s to 3s
+	file := smf.New()
+	file.TimeFormat = smf.MetricTicks(960)
+	var tr smf.Track
+	tr.Add(0, smf.MetaTempo(60))
+	tr.Add(0, midi.NoteOn(0, 60, 100))
+	tr.Add(960, midi.NoteOff(0, 60))
+	tr.Add(960, midi.NoteOn(0, 64, 100))
+	tr.Add(960, midi.NoteOff(0, 64))
+	tr.Close(0)
+	require.NoError(t, file.Add(tr))
+	var buf bytes.Buffer
+	_, err := file.WriteTo(&buf)
+	require.NoError(t, err)
+
+	plan, err := b.Play("test.mid", &buf, data.DefaultPlayOptions)
+	require.NoError(t, err)
+	assert.Equal(t, []data.TrackAssignment{{Track: 0, Musician: &m.Id}}, plan.Tracks)
+	assert.Eventually(t, func() bool {
+		return len(cli.messages()) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	assert.ErrorIs(t, b.Seek(data.ID{}, time.Hour), data.ErrInvalidPosition)
+
+	st := status(t, b)
+	assert.Equal(t, "test.mid", st.Music)
+	assert.Equal(t, data.PlaybackPlaying, st.State)
+	assert.Equal(t, 3*time.Second, st.Duration)
+	assert.Equal(t, []data.TrackAssignment{{Track: 0, Musician: &m.Id}}, st.Tracks)
+
+	// Pausing silences the first note
+	require.NoError(t, b.Pause(data.ID{}))
+	assert.Equal(t, data.PlaybackPaused, status(t, b).State)
+	assert.Eventually(t, func() bool {
+		return len(cli.messages()) == 4
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, b.Resume(data.ID{}))
+
+	// Seeking jumps to the second note
+	require.NoError(t, b.Seek(data.ID{}, 1500*time.Millisecond))
+	assert.Eventually(t, func() bool {
+		return len(cli.messages()) == 5
+	}, 2*time.Second, 5*time.Millisecond)
+
+	// Stopping silences the second note
+	require.NoError(t, b.Stop(data.ID{}))
+	assert.Eventually(t, func() bool {
+		return idle(b)
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, []midi.Message{
+		midi.NoteOn(0, 60, 100),
+		midi.NoteOff(0, 60),
+		midi.ControlChange(0, ccAllNotesOff, 0),
+		midi.ControlChange(0, ccAllSoundOff, 0),
+		midi.NoteOn(0, 64, 100),
+		midi.NoteOff(0, 64),
+		midi.ControlChange(0, ccAllNotesOff, 0),
+		midi.ControlChange(0, ccAllSoundOff, 0),
+	}, cli.messages())
+	assert.ErrorIs(t, b.Stop(data.ID{}), data.ErrNoMusicPlaying)
+
+	st = status(t, b)
+	assert.Equal(t, data.PlaybackStopped, st.State)
+	assert.False(t, st.Completed)
+	assert.InDelta(t, 2*time.Second, st.Elapsed, float64(200*time.Millisecond))
+
+	// The file is in 4/4 at 60 bpm, a bar lasts 4s
+	sub.Close()
+	var types []data.EventType
+	var bars []int
+	for ev := range sub.C() {
+		if pos, ok := ev.Data.(data.PositionEvent); ok {
+			bars = append(bars, pos.Bar)
+			continue
+		}
+		types = append(types, ev.Type)
+	}
+	assert.Equal(t, []int{1}, bars)
+	assert.Equal(t, []data.EventType{
+		data.EventPlaybackStarted,
+		data.EventPlaybackPaused,
+		data.EventPlaybackResumed,
+		data.EventPlaybackFinished,
+	}, types)
+}
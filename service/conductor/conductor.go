@@ -2,38 +2,72 @@ package broker
 
 import (
 	"context"
-	"os"
-	"path"
+	"io"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"crossjoin.com/gorxestra/config"
 	"crossjoin.com/gorxestra/data"
 	"crossjoin.com/gorxestra/logging"
 	"crossjoin.com/gorxestra/service/conductor/baton"
+	"crossjoin.com/gorxestra/service/conductor/library"
+	"crossjoin.com/gorxestra/util/broadcast"
 )
 
+// minExpiryInterval bounds how often the leases are checked
+const minExpiryInterval = 100 * time.Millisecond
+
 type ConductorNode struct {
 	log     logging.Logger
 	rootDir string
 
 	config config.ConductorConf
 
-	baton baton.Baton
+	baton   baton.Baton
+	library *library.Library
+	events  *broadcast.Broadcaster[data.Event]
+	// rosterMu orders the writes of the roster file
+	rosterMu sync.Mutex
+	// sectionsMu orders the writes of the sections file
+	sectionsMu sync.Mutex
+	queue      *playQueue
 
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
 func New(log logging.Logger, rootDir string, cfg config.ConductorConf) (*ConductorNode, error) {
+	musicDir := cfg.MusicDir
+	if !filepath.IsAbs(musicDir) {
+		musicDir = filepath.Join(rootDir, musicDir)
+	}
+	lib, err := library.New(log, musicDir)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
+	events := broadcast.New[data.Event]()
 	c := ConductorNode{
 		log:     log,
 		rootDir: rootDir,
 		config:  cfg,
-		baton:   baton.New(log),
+		baton:   baton.New(log, cfg.Playback, events),
+		library: lib,
+		events:  events,
+		queue:   newPlayQueue(),
 		ctx:     ctx,
 		cancel:  cancel,
 	}
 
+	if err := c.loadSections(); err != nil {
+		log.With("error", err).Warn("reading the sections, starting without any")
+	}
+	if err := c.loadQueue(); err != nil {
+		log.With("error", err).Warn("reading the queue, starting with an empty one")
+	}
+
 	return &c, nil
 }
 
@@ -41,36 +75,213 @@ func (c *ConductorNode) RegisterMusician(m data.Musician) error {
 	c.log.
 		With("id", m.Id.Hex()).
 		Info("registering musician")
-	return c.baton.RegisterMusician(m)
+	if err := c.baton.RegisterMusician(m); err != nil {
+		return err
+	}
+
+	c.persistRoster()
+	return nil
 }
 
 func (c *ConductorNode) UnregisterMusician(id data.ID) error {
 	c.log.
 		With("id", id.Hex()).
-		Info("registering musician")
-	return c.baton.UnregisterMusician(id)
+		Info("unregistering musician")
+	if err := c.baton.UnregisterMusician(id); err != nil {
+		return err
+	}
+
+	c.persistRoster()
+	return nil
+}
+
+// Heartbeat renews the registration of a musician and returns how long
+// it lasts
+func (c *ConductorNode) Heartbeat(id data.ID) (time.Duration, error) {
+	if err := c.baton.Heartbeat(id); err != nil {
+		return 0, err
+	}
+	return c.config.Roster.Lease.Duration(), nil
+}
+
+// expireMusicians removes the musicians whose lease lapsed until the node
+// stops
+func (c *ConductorNode) expireMusicians() {
+	lease := c.config.Roster.Lease.Duration()
+	interval := max(lease/4, minExpiryInterval)
+	clk := c.baton.Clock()
+	timer := clk.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case now := <-timer.C():
+			if expired := c.baton.Expire(now.Add(-lease)); len(expired) > 0 {
+				c.persistRoster()
+			}
+			timer.Reset(interval)
+		}
+	}
 }
 
-func (c *ConductorNode) PlayMusic(name string) error {
-	path := path.Join(c.rootDir, name)
+func (c *ConductorNode) PlayMusic(name string, opts data.PlayOptions) (data.PlayPlan, error) {
+	if err := opts.Validate(); err != nil {
+		return data.PlayPlan{}, err
+	}
 
-	f, err := os.Open(path)
+	f, err := c.library.Open(name)
 	if err != nil {
+		return data.PlayPlan{}, err
+	}
+	defer f.Close()
+
+	return c.baton.Play(name, f, opts)
+}
+
+func (c *ConductorNode) AddMusic(name string, r io.Reader) (data.MusicInfo, error) {
+	info, err := c.library.Add(name, r)
+	if err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	c.log.
+		With("name", name).
+		With("tracks", info.Tracks).
+		With("duration", info.Duration).
+		Info("music added")
+	return info, nil
+}
+
+func (c *ConductorNode) ListMusic() ([]data.MusicInfo, error) {
+	return c.library.List()
+}
+
+func (c *ConductorNode) MusicInfo(name string) (data.MusicInfo, error) {
+	return c.library.Info(name)
+}
+
+func (c *ConductorNode) RemoveMusic(name string) error {
+	if err := c.library.Remove(name); err != nil {
 		return err
 	}
 
-	c.baton.Play(f)
+	c.log.
+		With("name", name).
+		Info("music removed")
+	return nil
+}
+
+// The transport methods address a performance by its id, the zero id
+// addressing the performance started last
+
+func (c *ConductorNode) StopMusic(id data.ID) error {
+	return c.baton.Stop(id)
+}
+
+func (c *ConductorNode) PauseMusic(id data.ID) error {
+	return c.baton.Pause(id)
+}
+
+func (c *ConductorNode) ResumeMusic(id data.ID) error {
+	return c.baton.Resume(id)
+}
+
+// MixMusic changes the mix of a musician or a track of the performance
+func (c *ConductorNode) MixMusic(id data.ID, target data.MixTarget, change data.MixChange) (data.Mixer, error) {
+	mixer, err := c.baton.Mix(id, target, change)
+	if err != nil {
+		return data.Mixer{}, err
+	}
+
+	c.log.
+		With("performance", id.Hex()).
+		Info("performance mixed")
+	return mixer, nil
+}
+
+// Panic turns all notes and sound off on every musician registered
+func (c *ConductorNode) Panic() error {
+	c.baton.Panic()
+	return nil
+}
+
+func (c *ConductorNode) SeekMusic(id data.ID, pos time.Duration) error {
+	c.log.
+		With("position", pos).
+		Info("seeking music")
+	return c.baton.Seek(id, pos)
+}
+
+// InterpretMusic changes the tempo factor and the transposition of the
+// performance, nil leaves them as they are
+func (c *ConductorNode) InterpretMusic(id data.ID, tempo *float64, transpose *int) error {
+	if tempo != nil && !data.ValidTempo(*tempo) {
+		return data.ErrInvalidTempo
+	}
+	if transpose != nil && !data.ValidTranspose(*transpose) {
+		return data.ErrInvalidTranspose
+	}
 
+	if tempo != nil {
+		c.log.
+			With("tempo", *tempo).
+			Info("changing tempo")
+		if err := c.baton.SetTempo(id, *tempo); err != nil {
+			return err
+		}
+	}
+	if transpose != nil {
+		c.log.
+			With("semitones", *transpose).
+			Info("transposing music")
+		if err := c.baton.Transpose(id, *transpose); err != nil {
+			return err
+		}
+	}
 	return nil
+}
 
+func (c *ConductorNode) MusicStatus(id data.ID) (data.PlaybackStatus, error) {
+	return c.baton.Status(id)
+}
+
+// Performances returns the status of the performances being played
+func (c *ConductorNode) Performances() ([]data.PlaybackStatus, error) {
+	return c.baton.Performances(), nil
+}
+
+// Subscribe returns a subscription to the events of the conductor
+func (c *ConductorNode) Subscribe(buffer int) *broadcast.Subscription[data.Event] {
+	return c.events.Subscribe(buffer)
+}
+
+// Clock answers the clock exchanges of the musicians, the conductor clock
+// is the reference of the performance
+func (c *ConductorNode) Clock(originate time.Time) (data.ClockSample, error) {
+	now := time.Now()
+	return data.ClockSample{
+		Originate: originate,
+		Receive:   now,
+		Transmit:  now,
+	}, nil
 }
 
 func (c *ConductorNode) Start() error {
+	go c.expireMusicians()
+	go c.runQueue(c.events.Subscribe(queueEventsBuffer))
 	return nil
 }
 
 func (broker *ConductorNode) Stop() error {
-	return nil
+	broker.cancel()
+
+	// No note is left sounding on the musicians
+	broker.baton.Close()
+
+	// Keep the last time the musicians were seen for the next run
+	return broker.saveRoster()
 }
 
 func (broker *ConductorNode) Config() config.ConductorConf {
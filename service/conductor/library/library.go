@@ -0,0 +1,236 @@
+// Package library stores the musics the conductor can play
+package library
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+// uploadPattern is the pattern of the temporary files of the uploads, the
+// leading dot keeps them out of the library
+const uploadPattern = ".upload-*"
+
+// Library is a directory of Standard MIDI Files. Every file added is
+// validated so that only playable musics are stored.
+type Library struct {
+	log logging.Logger
+	dir string
+
+	// mu serializes the changes to the directory
+	mu sync.Mutex
+}
+
+// New opens the library stored in dir, creating the directory when
+// missing
+func New(log logging.Logger, dir string) (*Library, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &Library{
+		log: log,
+		dir: dir,
+		mu:  sync.Mutex{},
+	}, nil
+}
+
+// ValidName tells whether name can be used for a music of the library.
+// Names are plain file names with a .mid or .midi extension, they can not
+// point outside of the library.
+func ValidName(name string) bool {
+	if name == "" || strings.HasPrefix(name, ".") {
+		return false
+	}
+	if strings.ContainsAny(name, "/\\\x00") || !filepath.IsLocal(name) {
+		return false
+	}
+
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".mid" || ext == ".midi"
+}
+
+// Add validates the SMF read from r and stores it as name
+func (l *Library) Add(name string, r io.Reader) (data.MusicInfo, error) {
+	if !ValidName(name) {
+		return data.MusicInfo{}, data.ErrInvalidMusicName
+	}
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	info, err := inspect(raw)
+	if err != nil {
+		l.log.
+			With("name", name).
+			With("error", err).
+			Warn("rejecting invalid music")
+		return data.MusicInfo{}, data.ErrInvalidMusic
+	}
+
+	tmp, err := os.CreateTemp(l.dir, uploadPattern)
+	if err != nil {
+		return data.MusicInfo{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return data.MusicInfo{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	path := l.path(name)
+	if _, err := os.Stat(path); err == nil {
+		return data.MusicInfo{}, data.ErrMusicExists
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	info.Name = name
+	info.Size = stat.Size()
+	info.ModifiedAt = stat.ModTime()
+	return info, nil
+}
+
+// List returns the musics of the library sorted by name. Files that are
+// not valid musics are skipped.
+func (l *Library) List() ([]data.MusicInfo, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	musics := make([]data.MusicInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !ValidName(entry.Name()) {
+			continue
+		}
+
+		info, err := l.Info(entry.Name())
+		if err != nil {
+			l.log.
+				With("name", entry.Name()).
+				With("error", err).
+				Warn("skipping music")
+			continue
+		}
+		musics = append(musics, info)
+	}
+
+	slices.SortFunc(musics, func(a, b data.MusicInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return musics, nil
+}
+
+// Info returns the description of the music name
+func (l *Library) Info(name string) (data.MusicInfo, error) {
+	f, err := l.Open(name)
+	if err != nil {
+		return data.MusicInfo{}, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	info, err := inspect(raw)
+	if err != nil {
+		return data.MusicInfo{}, data.ErrInvalidMusic
+	}
+
+	info.Name = name
+	info.Size = stat.Size()
+	info.ModifiedAt = stat.ModTime()
+	return info, nil
+}
+
+// Open opens the music name for reading
+func (l *Library) Open(name string) (*os.File, error) {
+	if !ValidName(name) {
+		return nil, data.ErrInvalidMusicName
+	}
+
+	f, err := os.Open(l.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, data.ErrMusicNotFound
+	}
+	return f, err
+}
+
+// Remove deletes the music name
+func (l *Library) Remove(name string) error {
+	if !ValidName(name) {
+		return data.ErrInvalidMusicName
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err := os.Remove(l.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return data.ErrMusicNotFound
+	}
+	return err
+}
+
+func (l *Library) path(name string) string {
+	return filepath.Join(l.dir, name)
+}
+
+// inspect parses a SMF and describes it. The duration is computed the
+// same way the baton does, from the tempo map up to the last event.
+func inspect(raw []byte) (data.MusicInfo, error) {
+	tracks := smf.ReadTracksFrom(bytes.NewReader(raw))
+	if err := tracks.Error(); err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	var length int64
+	tracks.Do(func(ev smf.TrackEvent) {
+		length = max(length, ev.AbsMicroSeconds)
+	})
+	if err := tracks.Error(); err != nil {
+		return data.MusicInfo{}, err
+	}
+
+	s := tracks.SMF()
+	return data.MusicInfo{
+		Format:   s.Format(),
+		Tracks:   len(s.Tracks),
+		Duration: time.Duration(length) * time.Microsecond,
+		Tempo:    s.TempoChanges().TempoAt(0),
+	}, nil
+}
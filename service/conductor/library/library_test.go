@@ -0,0 +1,135 @@
+package library
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+// testMusic is two tracks of two seconds at 60 BPM
+func testMusic(t *testing.T) []byte {
+	t.Helper()
+
+	s := smf.New()
+	s.TimeFormat = smf.MetricTicks(960)
+
+	var t0, t1 smf.Track
+	t0.Add(0, smf.MetaTempo(60))
+	t0.Add(0, midi.NoteOn(0, 60, 100))
+	t0.Add(1920, midi.NoteOff(0, 60))
+	t0.Close(0)
+	t1.Add(960, midi.NoteOn(1, 64, 100))
+	t1.Add(480, midi.NoteOff(1, 64))
+	t1.Close(0)
+	require.NoError(t, s.Add(t0))
+	require.NoError(t, s.Add(t1))
+
+	var buf bytes.Buffer
+	_, err := s.WriteTo(&buf)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestValidName(t *testing.T) {
+	for _, name := range []string{"queen.mid", "Bohemian Rhapsody.MIDI", "a..b.mid"} {
+		assert.True(t, ValidName(name), name)
+	}
+
+	for _, name := range []string{
+		"", ".mid", ".hidden.mid", "..", "../queen.mid", "music/queen.mid",
+		`..\queen.mid`, "/etc/queen.mid", "queen.txt", "queen", "status",
+		"queen\x00.mid",
+	} {
+		assert.False(t, ValidName(name), name)
+	}
+}
+
+func TestLibrary(t *testing.T) {
+	dir := t.TempDir()
+	lib, err := New(logging.Base(), filepath.Join(dir, "music"))
+	require.NoError(t, err)
+
+	raw := testMusic(t)
+	info, err := lib.Add("song.mid", bytes.NewReader(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "song.mid", info.Name)
+	assert.Equal(t, int64(len(raw)), info.Size)
+	assert.Equal(t, uint16(1), info.Format)
+	assert.Equal(t, 2, info.Tracks)
+	assert.Equal(t, 2*time.Second, info.Duration)
+	assert.InDelta(t, 60, info.Tempo, 0.01)
+
+	_, err = lib.Add("song.mid", bytes.NewReader(raw))
+	assert.ErrorIs(t, err, data.ErrMusicExists)
+
+	got, err := lib.Info("song.mid")
+	require.NoError(t, err)
+	assert.Equal(t, info.Duration, got.Duration)
+	assert.Equal(t, info.Tracks, got.Tracks)
+
+	// Files that are not musics are kept out of the listing
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "music", "notes.txt"), []byte("x"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "music", "broken.mid"), []byte("x"), 0o644))
+	_, err = lib.Add("other.midi", bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	musics, err := lib.List()
+	require.NoError(t, err)
+	require.Len(t, musics, 2)
+	assert.Equal(t, "other.midi", musics[0].Name)
+	assert.Equal(t, "song.mid", musics[1].Name)
+
+	f, err := lib.Open("song.mid")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, lib.Remove("song.mid"))
+	assert.ErrorIs(t, lib.Remove("song.mid"), data.ErrMusicNotFound)
+	_, err = lib.Info("song.mid")
+	assert.ErrorIs(t, err, data.ErrMusicNotFound)
+}
+
+func TestLibraryRejectsInvalidMusic(t *testing.T) {
+	dir := t.TempDir()
+	lib, err := New(logging.Base(), dir)
+	require.NoError(t, err)
+
+	_, err = lib.Add("song.mid", strings.NewReader("not a midi file"))
+	assert.ErrorIs(t, err, data.ErrInvalidMusic)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLibraryRejectsTraversal(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "music")
+	lib, err := New(logging.Base(), dir)
+	require.NoError(t, err)
+
+	secret := filepath.Join(root, "secret.mid")
+	require.NoError(t, os.WriteFile(secret, testMusic(t), 0o644))
+
+	_, err = lib.Add("../escaped.mid", bytes.NewReader(testMusic(t)))
+	assert.ErrorIs(t, err, data.ErrInvalidMusicName)
+	assert.NoFileExists(t, filepath.Join(root, "escaped.mid"))
+
+	_, err = lib.Open("../secret.mid")
+	assert.ErrorIs(t, err, data.ErrInvalidMusicName)
+	_, err = lib.Info("../secret.mid")
+	assert.ErrorIs(t, err, data.ErrInvalidMusicName)
+
+	assert.ErrorIs(t, lib.Remove("../secret.mid"), data.ErrInvalidMusicName)
+	assert.FileExists(t, secret)
+}
@@ -0,0 +1,649 @@
+package broker
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/util/broadcast"
+)
+
+const (
+	// queueFileName is the file of the data directory holding the queue
+	queueFileName = "queue.json"
+	queuePattern  = ".queue-*"
+
+	// queueEventsBuffer is the number of events the queue lets wait while
+	// it starts a music
+	queueEventsBuffer = 64
+)
+
+// playQueue is the musics played one after the other. Its methods must be
+// called with the lock held.
+type playQueue struct {
+	mu      sync.Mutex
+	current *data.QueueItem
+	// perf is the performance playing the current item, zero while it
+	// starts
+	perf  data.ID
+	items []data.QueueItem
+	modes data.QueueModes
+	held  bool
+	// skipping is set while the current item is stopped to play the next,
+	// or is to be stopped once started
+	skipping bool
+	// wake tells the queue it may have a music to start
+	wake chan struct{}
+}
+
+func newPlayQueue() *playQueue {
+	return &playQueue{
+		mu:    sync.Mutex{},
+		modes: data.DefaultQueueModes,
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+func (q *playQueue) snapshot() data.Queue {
+	queue := data.Queue{
+		Items: slices.Clone(q.items),
+		Modes: q.modes,
+		Held:  q.held,
+	}
+	if q.current != nil {
+		current := *q.current
+		queue.Current = &current
+	}
+	return queue
+}
+
+// add appends an item, or inserts it at a random place when shuffling
+func (q *playQueue) add(item data.QueueItem) {
+	at := len(q.items)
+	if q.modes.Shuffle {
+		at = rand.IntN(len(q.items) + 1)
+	}
+	q.items = slices.Insert(q.items, at, item)
+}
+
+func (q *playQueue) index(id data.ID) int {
+	return slices.IndexFunc(q.items, func(item data.QueueItem) bool {
+		return item.Id == id
+	})
+}
+
+func (q *playQueue) remove(id data.ID) error {
+	idx := q.index(id)
+	if idx < 0 {
+		return data.ErrQueueItemNotFound
+	}
+	q.items = slices.Delete(q.items, idx, idx+1)
+	return nil
+}
+
+// move places an item at an index of the queue, the indexes out of the
+// queue moving it to its ends
+func (q *playQueue) move(id data.ID, to int) error {
+	idx := q.index(id)
+	if idx < 0 {
+		return data.ErrQueueItemNotFound
+	}
+	item := q.items[idx]
+	q.items = slices.Delete(q.items, idx, idx+1)
+	q.items = slices.Insert(q.items, min(max(to, 0), len(q.items)), item)
+	return nil
+}
+
+// setModes changes the modes, turning shuffle on shuffles the items
+func (q *playQueue) setModes(modes data.QueueModes) {
+	if modes.Shuffle && !q.modes.Shuffle {
+		rand.Shuffle(len(q.items), func(i, j int) {
+			q.items[i], q.items[j] = q.items[j], q.items[i]
+		})
+	}
+	q.modes = modes
+}
+
+// take makes the first item the current one
+func (q *playQueue) take() *data.QueueItem {
+	if len(q.items) == 0 {
+		return nil
+	}
+	item := q.items[0]
+	q.items = q.items[1:]
+	q.current = &item
+	return q.current
+}
+
+// started tells whether the performance of the current item is known.
+// The zero id addresses the performance started last for the baton, which
+// may not be the queue's.
+func (q *playQueue) started() bool {
+	return q.perf != (data.ID{})
+}
+
+// upcoming returns the item played once the current one completes, nil
+// when the queue ends
+func (q *playQueue) upcoming() *data.QueueItem {
+	switch {
+	case q.current == nil:
+		return nil
+	case q.modes.Repeat == data.RepeatOne:
+		return q.current
+	case len(q.items) > 0:
+		return &q.items[0]
+	case q.modes.Repeat == data.RepeatAll:
+		return q.current
+	}
+	return nil
+}
+
+// finish ends the current item. A completed or skipped item is repeated
+// as the mode says, a stopped one waits at the head of the queue which is
+// held.
+func (q *playQueue) finish(completed bool) {
+	if q.current == nil {
+		return
+	}
+	item := *q.current
+	q.current = nil
+
+	switch {
+	case !completed && !q.skipping:
+		q.items = slices.Insert(q.items, 0, item)
+		q.held = true
+	case q.modes.Repeat == data.RepeatOne && !q.skipping:
+		q.items = slices.Insert(q.items, 0, item)
+	case q.modes.Repeat == data.RepeatAll:
+		q.items = append(q.items, item)
+	}
+	q.skipping = false
+}
+
+type queueFile struct {
+	Current *queueEntry  `json:"current,omitempty"`
+	Items   []queueEntry `json:"items"`
+	Repeat  string       `json:"repeat"`
+	Shuffle bool         `json:"shuffle"`
+	Gapless bool         `json:"gapless"`
+}
+
+type queueEntry struct {
+	Id      string       `json:"id"`
+	Music   string       `json:"music"`
+	Options queueOptions `json:"options"`
+}
+
+type queueOptions struct {
+	Mapping      []queueAssignment `json:"mapping,omitempty"`
+	Tempo        float64           `json:"tempo"`
+	Transpose    int               `json:"transpose"`
+	Start        *queuePosition    `json:"start,omitempty"`
+	End          *queuePosition    `json:"end,omitempty"`
+	Tracks       []int             `json:"tracks,omitempty"`
+	Exclude      []int             `json:"exclude,omitempty"`
+	Loops        int               `json:"loops"`
+	MinMusicians int               `json:"minMusicians"`
+	Section      string            `json:"section,omitempty"`
+}
+
+type queueAssignment struct {
+	Track    int    `json:"track"`
+	Channel  *uint8 `json:"channel,omitempty"`
+	Musician string `json:"musician,omitempty"`
+}
+
+type queuePosition struct {
+	Time time.Duration `json:"time"`
+	Bar  int           `json:"bar"`
+	Beat int           `json:"beat"`
+}
+
+func queuePositionOf(p *data.Position) *queuePosition {
+	if p == nil {
+		return nil
+	}
+	return &queuePosition{Time: p.Time, Bar: p.Bar, Beat: p.Beat}
+}
+
+func (p *queuePosition) position() *data.Position {
+	if p == nil {
+		return nil
+	}
+	return &data.Position{Time: p.Time, Bar: p.Bar, Beat: p.Beat}
+}
+
+func queueEntryOf(item data.QueueItem) queueEntry {
+	o := item.Options
+	e := queueEntry{
+		Id:    item.Id.Hex(),
+		Music: item.Music,
+		Options: queueOptions{
+			Tempo:        o.Interpretation.Tempo,
+			Transpose:    o.Interpretation.Transpose,
+			Start:        queuePositionOf(o.Start),
+			End:          queuePositionOf(o.End),
+			Tracks:       o.Tracks,
+			Exclude:      o.Exclude,
+			Loops:        o.Loops,
+			MinMusicians: o.MinMusicians,
+			Section:      o.Section,
+		},
+	}
+	for _, a := range o.Mapping {
+		qa := queueAssignment{Track: a.Track, Channel: a.Channel}
+		if a.Musician != nil {
+			qa.Musician = a.Musician.Hex()
+		}
+		e.Options.Mapping = append(e.Options.Mapping, qa)
+	}
+	return e
+}
+
+func (e queueEntry) item() (data.QueueItem, error) {
+	id, err := data.IdFromHex(e.Id)
+	if err != nil {
+		return data.QueueItem{}, err
+	}
+
+	o := e.Options
+	item := data.QueueItem{
+		Id:    id,
+		Music: e.Music,
+		Options: data.PlayOptions{
+			Interpretation: data.Interpretation{
+				Tempo:     o.Tempo,
+				Transpose: o.Transpose,
+			},
+			Start:        o.Start.position(),
+			End:          o.End.position(),
+			Tracks:       o.Tracks,
+			Exclude:      o.Exclude,
+			Loops:        o.Loops,
+			MinMusicians: o.MinMusicians,
+			Section:      o.Section,
+		},
+	}
+	for _, qa := range o.Mapping {
+		a := data.TrackAssignment{Track: qa.Track, Channel: qa.Channel}
+		if qa.Musician != "" {
+			musician, err := data.IdFromHex(qa.Musician)
+			if err != nil {
+				return data.QueueItem{}, err
+			}
+			a.Musician = &musician
+		}
+		item.Options.Mapping = append(item.Options.Mapping, a)
+	}
+	return item, item.Options.Validate()
+}
+
+// saveQueue writes the queue to the queue file. Must be called with the
+// queue lock held.
+func (c *ConductorNode) saveQueue() {
+	q := c.queue
+	file := queueFile{
+		Items:   make([]queueEntry, len(q.items)),
+		Repeat:  string(q.modes.Repeat),
+		Shuffle: q.modes.Shuffle,
+		Gapless: q.modes.Gapless,
+	}
+	if q.current != nil {
+		current := queueEntryOf(*q.current)
+		file.Current = &current
+	}
+	for i, item := range q.items {
+		file.Items[i] = queueEntryOf(item)
+	}
+
+	// A failure only costs the queue after a restart
+	if err := c.writeState(queueFileName, queuePattern, file); err != nil {
+		c.log.With("error", err).Warn("saving the queue")
+	}
+}
+
+// loadQueue reads the queue file of a previous run. The item being played
+// goes back to the head of the queue, which is held until started again.
+func (c *ConductorNode) loadQueue() error {
+	raw, err := os.ReadFile(filepath.Join(c.rootDir, queueFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var file queueFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return err
+	}
+
+	q := c.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entries := file.Items
+	if file.Current != nil {
+		entries = slices.Insert(entries, 0, *file.Current)
+	}
+	for _, e := range entries {
+		item, err := e.item()
+		if err != nil {
+			c.log.With("id", e.Id).Warn("skipping invalid item of the queue")
+			continue
+		}
+		q.items = append(q.items, item)
+	}
+
+	q.modes = data.QueueModes{
+		Repeat:  data.RepeatMode(file.Repeat),
+		Shuffle: file.Shuffle,
+		Gapless: file.Gapless,
+	}
+	if !q.modes.Repeat.Valid() {
+		q.modes.Repeat = data.RepeatOff
+	}
+	q.held = len(q.items) > 0
+	return nil
+}
+
+// Queue returns the queue of musics
+func (c *ConductorNode) Queue() (data.Queue, error) {
+	c.queue.mu.Lock()
+	defer c.queue.mu.Unlock()
+	return c.queue.snapshot(), nil
+}
+
+// QueueMusic adds a music of the library to the queue, to be played with
+// the options
+func (c *ConductorNode) QueueMusic(name string, opts data.PlayOptions) (data.QueueItem, error) {
+	if err := opts.Validate(); err != nil {
+		return data.QueueItem{}, err
+	}
+	if opts.DryRun {
+		return data.QueueItem{}, data.ErrInvalidPlayOptions
+	}
+	if _, err := c.library.Info(name); err != nil {
+		return data.QueueItem{}, err
+	}
+
+	item := data.QueueItem{Id: data.GenId(), Music: name, Options: opts}
+	c.log.
+		With("id", item.Id.Hex()).
+		With("music", name).
+		Info("queueing music")
+
+	c.changeQueue(func(q *playQueue) error {
+		q.add(item)
+		return nil
+	})
+	return item, nil
+}
+
+// RemoveQueueItem removes an item waiting in the queue
+func (c *ConductorNode) RemoveQueueItem(id data.ID) error {
+	return c.changeQueue(func(q *playQueue) error {
+		return q.remove(id)
+	})
+}
+
+// MoveQueueItem moves an item waiting in the queue to an index
+func (c *ConductorNode) MoveQueueItem(id data.ID, to int) error {
+	return c.changeQueue(func(q *playQueue) error {
+		return q.move(id, to)
+	})
+}
+
+// ClearQueue removes the items waiting in the queue, the current one is
+// played to its end
+func (c *ConductorNode) ClearQueue() error {
+	return c.changeQueue(func(q *playQueue) error {
+		q.items = nil
+		return nil
+	})
+}
+
+// SetQueueModes changes how the queue moves from an item to the next,
+// nil leaves a mode as it is
+func (c *ConductorNode) SetQueueModes(repeat *data.RepeatMode, shuffle, gapless *bool) (data.Queue, error) {
+	if repeat != nil && !repeat.Valid() {
+		return data.Queue{}, data.ErrInvalidQueueModes
+	}
+
+	var queue data.Queue
+	err := c.changeQueue(func(q *playQueue) error {
+		modes := q.modes
+		if repeat != nil {
+			modes.Repeat = *repeat
+		}
+		if shuffle != nil {
+			modes.Shuffle = *shuffle
+		}
+		if gapless != nil {
+			modes.Gapless = *gapless
+		}
+		q.setModes(modes)
+		queue = q.snapshot()
+		return nil
+	})
+	return queue, err
+}
+
+// PlayQueue starts the queue held
+func (c *ConductorNode) PlayQueue() error {
+	return c.changeQueue(func(q *playQueue) error {
+		if q.current == nil && len(q.items) == 0 {
+			return data.ErrQueueItemNotFound
+		}
+		q.held = false
+		return nil
+	})
+}
+
+// SkipQueue plays the next item of the queue, dropping the current one
+// or, when the queue is not playing, the first one
+func (c *ConductorNode) SkipQueue() error {
+	q := c.queue
+	q.mu.Lock()
+	if q.current == nil {
+		defer q.mu.Unlock()
+		if len(q.items) == 0 {
+			return data.ErrQueueItemNotFound
+		}
+		q.items = q.items[1:]
+		q.held = false
+		c.saveQueue()
+		c.wakeQueue()
+		return nil
+	}
+
+	// The queue moves on once the music stopped, a music still starting
+	// is stopped once started
+	q.skipping = true
+	q.held = false
+	perf, started := q.perf, q.started()
+	q.mu.Unlock()
+	if !started {
+		return nil
+	}
+	return c.baton.Stop(perf)
+}
+
+// changeQueue applies a change to the queue then saves it and follows it
+func (c *ConductorNode) changeQueue(change func(q *playQueue) error) error {
+	c.queue.mu.Lock()
+	defer c.queue.mu.Unlock()
+
+	if err := change(c.queue); err != nil {
+		return err
+	}
+	c.saveQueue()
+	c.cueQueue()
+	c.wakeQueue()
+	return nil
+}
+
+// wakeQueue tells the queue it may have a music to start
+func (c *ConductorNode) wakeQueue() {
+	select {
+	case c.queue.wake <- struct{}{}:
+	default:
+	}
+}
+
+// cueQueue cues the upcoming item when the queue plays without gap. Must
+// be called with the queue lock held. A music still starting is cued once
+// started.
+func (c *ConductorNode) cueQueue() {
+	q := c.queue
+	if q.current == nil || !q.started() {
+		return
+	}
+
+	next := q.upcoming()
+	if !q.modes.Gapless || next == nil {
+		c.baton.Uncue(q.perf)
+		return
+	}
+
+	f, err := c.library.Open(next.Music)
+	if err == nil {
+		defer f.Close()
+		err = c.baton.Cue(q.perf, next.Music, f, next.Options)
+	}
+	if err != nil {
+		c.log.
+			With("music", next.Music).
+			With("error", err).
+			Warn("cueing the next music of the queue")
+		c.baton.Uncue(q.perf)
+	}
+}
+
+// runQueue plays the queue until the node stops
+func (c *ConductorNode) runQueue(sub *broadcast.Subscription[data.Event]) {
+	defer sub.Close()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case ev, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			if p, ok := ev.Data.(data.PlaybackEvent); ok && ev.Type == data.EventPlaybackFinished {
+				c.finishQueueItem(p)
+			}
+		case <-c.queue.wake:
+		}
+
+		// The end of the current item may have been missed
+		if sub.TakeDropped() > 0 {
+			c.queue.mu.Lock()
+			perf, started := c.queue.perf, c.queue.started()
+			c.queue.mu.Unlock()
+			if started {
+				if st, err := c.baton.Status(perf); err == nil && st.State == data.PlaybackStopped {
+					c.finishQueueItem(data.PlaybackEvent{
+						Performance: perf,
+						Music:       st.Music,
+						Completed:   st.Completed,
+					})
+				}
+			}
+		}
+
+		c.playQueueItem()
+	}
+}
+
+// finishQueueItem moves the queue on once the music of its current item
+// finished. The other performances are ignored.
+func (c *ConductorNode) finishQueueItem(ev data.PlaybackEvent) {
+	q := c.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.current == nil || !q.started() || q.perf != ev.Performance {
+		return
+	}
+	q.finish(ev.Completed || q.skipping)
+	if ev.Next == "" {
+		q.perf = data.ID{}
+	}
+
+	// The baton already plays the item cued
+	if ev.Next != "" {
+		if item := q.take(); item == nil || item.Music != ev.Next {
+			c.log.With("music", ev.Next).Warn("music cued is not the next of the queue")
+		}
+	}
+
+	c.saveQueue()
+	c.cueQueue()
+}
+
+// playQueueItem starts the first item of the queue when it is not held
+// and nothing is being played
+func (c *ConductorNode) playQueueItem() {
+	q := c.queue
+	q.mu.Lock()
+	if q.current != nil || q.held {
+		q.mu.Unlock()
+		return
+	}
+	item := q.take()
+	q.mu.Unlock()
+	if item == nil {
+		return
+	}
+
+	// The music may wait for its musicians, the queue can change meanwhile
+	plan, err := c.PlayMusic(item.Music, item.Options)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	log := c.log.
+		With("id", item.Id.Hex()).
+		With("music", item.Music)
+	if err != nil && q.skipping {
+		// Skipped while starting, the failure does not matter
+		q.finish(false)
+		c.saveQueue()
+		return
+	}
+	if err != nil {
+		q.current = nil
+		q.items = slices.Insert(q.items, 0, *item)
+
+		// The queue plays once the music being played finished, other
+		// errors wait to be dealt with
+		if !errors.Is(err, data.MusicAlreadyBeingPlayed) {
+			log.With("error", err).Warn("playing the queue, holding it")
+			q.held = true
+		}
+		c.saveQueue()
+		return
+	}
+
+	log.Info("playing the queue")
+	q.perf = plan.Performance
+	c.saveQueue()
+	if q.skipping {
+		// Skipped while starting, the queue moves on once it stopped
+		if err := c.baton.Stop(q.perf); err != nil && !errors.Is(err, data.ErrNoMusicPlaying) {
+			log.With("error", err).Warn("skipping the queue")
+		}
+		return
+	}
+	c.cueQueue()
+}
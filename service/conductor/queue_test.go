@@ -0,0 +1,204 @@
+package broker
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+// addTestMusic adds a music of a note to the library of the node
+func addTestMusic(t *testing.T, node *ConductorNode, name string) {
+	t.Helper()
+
+	s := smf.New()
+	s.TimeFormat = smf.MetricTicks(960)
+	var tr smf.Track
+	tr.Add(0, midi.NoteOn(0, 60, 100))
+	tr.Add(960, midi.NoteOff(0, 60))
+	tr.Close(0)
+	require.NoError(t, s.Add(tr))
+
+	var buf bytes.Buffer
+	_, err := s.WriteTo(&buf)
+	require.NoError(t, err)
+	_, err = node.AddMusic(name, &buf)
+	require.NoError(t, err)
+}
+
+func musics(items []data.QueueItem) []string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Music
+	}
+	return names
+}
+
+func TestPlayQueue(t *testing.T) {
+	q := newPlayQueue()
+	for _, music := range []string{"a.mid", "b.mid", "c.mid"} {
+		q.add(data.QueueItem{Id: data.GenId(), Music: music})
+	}
+
+	// Reordering and removing
+	c := q.items[2]
+	require.NoError(t, q.move(c.Id, 0))
+	assert.Equal(t, []string{"c.mid", "a.mid", "b.mid"}, musics(q.items))
+	require.NoError(t, q.move(c.Id, 10))
+	assert.Equal(t, []string{"a.mid", "b.mid", "c.mid"}, musics(q.items))
+	require.NoError(t, q.remove(c.Id))
+	assert.ErrorIs(t, q.remove(c.Id), data.ErrQueueItemNotFound)
+	assert.ErrorIs(t, q.move(c.Id, 0), data.ErrQueueItemNotFound)
+
+	// Without repeat the items played are dropped
+	assert.Nil(t, q.upcoming())
+	assert.Equal(t, "a.mid", q.take().Music)
+	assert.Equal(t, "b.mid", q.upcoming().Music)
+	q.finish(true)
+	assert.Equal(t, []string{"b.mid"}, musics(q.items))
+
+	// Repeat one plays the item again until it is skipped
+	q.modes.Repeat = data.RepeatOne
+	b := q.take()
+	assert.Equal(t, b, q.upcoming())
+	q.finish(true)
+	assert.Equal(t, []string{"b.mid"}, musics(q.items))
+	q.take()
+	q.skipping = true
+	q.finish(false)
+	assert.Empty(t, q.items)
+	assert.False(t, q.skipping)
+
+	// Repeat all puts the items played back at the end
+	q.modes.Repeat = data.RepeatAll
+	q.add(data.QueueItem{Id: data.GenId(), Music: "a.mid"})
+	q.add(data.QueueItem{Id: data.GenId(), Music: "b.mid"})
+	q.take()
+	q.finish(true)
+	assert.Equal(t, []string{"b.mid", "a.mid"}, musics(q.items))
+	q.take()
+	q.skipping = true
+	q.finish(false)
+	assert.Equal(t, []string{"a.mid", "b.mid"}, musics(q.items))
+
+	// A stopped item waits at the head of the queue, which is held
+	q.take()
+	q.finish(false)
+	assert.Equal(t, []string{"a.mid", "b.mid"}, musics(q.items))
+	assert.True(t, q.held)
+	assert.Nil(t, q.current)
+
+	// Shuffling keeps the items
+	q.setModes(data.QueueModes{Repeat: data.RepeatOff, Shuffle: true})
+	q.add(data.QueueItem{Id: data.GenId(), Music: "c.mid"})
+	assert.ElementsMatch(t, []string{"a.mid", "b.mid", "c.mid"}, musics(q.items))
+}
+
+func TestQueuePersistence(t *testing.T) {
+	dir := t.TempDir()
+	node, err := New(logging.Base(), dir, testConfig())
+	require.NoError(t, err)
+	addTestMusic(t, node, "a.mid")
+	addTestMusic(t, node, "b.mid")
+
+	_, err = node.QueueMusic("missing.mid", data.DefaultPlayOptions)
+	assert.ErrorIs(t, err, data.ErrMusicNotFound)
+	_, err = node.QueueMusic("a.mid", data.PlayOptions{})
+	assert.ErrorIs(t, err, data.ErrInvalidTempo)
+
+	opts := data.DefaultPlayOptions
+	opts.Interpretation.Transpose = 2
+	opts.Start = &data.Position{Bar: 1, Beat: 2}
+	opts.Mapping = []data.TrackAssignment{{Track: 0, Musician: &data.HighestId}}
+	a, err := node.QueueMusic("a.mid", opts)
+	require.NoError(t, err)
+	b, err := node.QueueMusic("b.mid", data.DefaultPlayOptions)
+	require.NoError(t, err)
+
+	repeat := data.RepeatAll
+	gapless := true
+	queue, err := node.SetQueueModes(&repeat, nil, &gapless)
+	require.NoError(t, err)
+	assert.Equal(t, data.QueueModes{Repeat: data.RepeatAll, Gapless: true}, queue.Modes)
+	invalid := data.RepeatMode("twice")
+	_, err = node.SetQueueModes(&invalid, nil, nil)
+	assert.ErrorIs(t, err, data.ErrInvalidQueueModes)
+
+	// The item being played is saved as the current one
+	node.queue.mu.Lock()
+	node.queue.take()
+	node.saveQueue()
+	node.queue.mu.Unlock()
+	require.NoError(t, node.Stop())
+
+	// After a restart it waits at the head of the queue, which is held
+	restarted, err := New(logging.Base(), dir, testConfig())
+	require.NoError(t, err)
+	queue, err = restarted.Queue()
+	require.NoError(t, err)
+	assert.Equal(t, data.Queue{
+		Items: []data.QueueItem{a, b},
+		Modes: data.QueueModes{Repeat: data.RepeatAll, Gapless: true},
+		Held:  true,
+	}, queue)
+
+	require.NoError(t, restarted.MoveQueueItem(b.Id, 0))
+	require.NoError(t, restarted.RemoveQueueItem(a.Id))
+	queue, err = restarted.Queue()
+	require.NoError(t, err)
+	assert.Equal(t, []data.QueueItem{b}, queue.Items)
+
+	require.NoError(t, restarted.ClearQueue())
+	assert.ErrorIs(t, restarted.PlayQueue(), data.ErrQueueItemNotFound)
+	assert.ErrorIs(t, restarted.SkipQueue(), data.ErrQueueItemNotFound)
+
+	// No temporary file is left behind
+	matches, err := filepath.Glob(filepath.Join(dir, queuePattern))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestLoadCorruptQueue(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, queueFileName), []byte("{"), 0o600))
+
+	node, err := New(logging.Base(), dir, testConfig())
+	require.NoError(t, err)
+	queue, err := node.Queue()
+	require.NoError(t, err)
+	assert.Equal(t, data.Queue{Modes: data.DefaultQueueModes}, queue)
+}
+
+func TestSkipStartingQueue(t *testing.T) {
+	node, err := New(logging.Base(), t.TempDir(), testConfig())
+	require.NoError(t, err)
+	addTestMusic(t, node, "a.mid")
+	addTestMusic(t, node, "b.mid")
+	_, err = node.QueueMusic("a.mid", data.DefaultPlayOptions)
+	require.NoError(t, err)
+	_, err = node.QueueMusic("b.mid", data.DefaultPlayOptions)
+	require.NoError(t, err)
+
+	// While the current item starts its performance is unknown, the skip
+	// waits for it rather than stopping the performance started last
+	node.queue.mu.Lock()
+	node.queue.take()
+	node.queue.mu.Unlock()
+	require.NoError(t, node.SkipQueue())
+	node.queue.mu.Lock()
+	assert.True(t, node.queue.skipping)
+	assert.False(t, node.queue.started())
+
+	// A start failing once skipped drops the item
+	node.queue.finish(false)
+	assert.Equal(t, []string{"b.mid"}, musics(node.queue.items))
+	assert.False(t, node.queue.held)
+	node.queue.mu.Unlock()
+}
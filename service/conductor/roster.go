@@ -0,0 +1,213 @@
+package broker
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"crossjoin.com/gorxestra/daemon/musiciand/api/client/v1"
+	"crossjoin.com/gorxestra/data"
+)
+
+const (
+	// rosterFileName is the file of the data directory holding the
+	// musicians registered in the conductor
+	rosterFileName = "roster.json"
+	rosterPattern  = ".roster-*"
+)
+
+type rosterFile struct {
+	Musicians []rosterEntry `json:"musicians"`
+}
+
+type rosterEntry struct {
+	Id           string              `json:"id"`
+	Address      string              `json:"address"`
+	Capabilities *rosterCapabilities `json:"capabilities,omitempty"`
+	RegisteredAt time.Time           `json:"registeredAt"`
+	LastSeen     time.Time           `json:"lastSeen"`
+}
+
+type rosterCapabilities struct {
+	Channels   []int         `json:"channels"`
+	Programs   []int         `json:"programs"`
+	Polyphony  int           `json:"polyphony"`
+	Percussion bool          `json:"percussion"`
+	Latency    time.Duration `json:"latency"`
+	Labels     []string      `json:"labels"`
+}
+
+func rosterCapabilitiesOf(c *data.Capabilities) *rosterCapabilities {
+	if c == nil {
+		return nil
+	}
+
+	rc := &rosterCapabilities{
+		Polyphony:  c.Polyphony,
+		Percussion: c.Percussion,
+		Latency:    c.Latency,
+		Labels:     c.Labels,
+	}
+	for _, channel := range c.Channels {
+		rc.Channels = append(rc.Channels, int(channel))
+	}
+	for _, program := range c.Programs {
+		rc.Programs = append(rc.Programs, int(program))
+	}
+	return rc
+}
+
+func (rc *rosterCapabilities) capabilities() *data.Capabilities {
+	if rc == nil {
+		return nil
+	}
+
+	c := &data.Capabilities{
+		Polyphony:  rc.Polyphony,
+		Percussion: rc.Percussion,
+		Latency:    rc.Latency,
+		Labels:     rc.Labels,
+	}
+	for _, channel := range rc.Channels {
+		c.Channels = append(c.Channels, uint8(channel))
+	}
+	for _, program := range rc.Programs {
+		c.Programs = append(c.Programs, uint8(program))
+	}
+	return c
+}
+
+// saveRoster writes the registered musicians to the roster file
+func (c *ConductorNode) saveRoster() error {
+	c.rosterMu.Lock()
+	defer c.rosterMu.Unlock()
+
+	roster := c.baton.Roster()
+	file := rosterFile{Musicians: make([]rosterEntry, len(roster))}
+	for i, r := range roster {
+		file.Musicians[i] = rosterEntry{
+			Id:           r.Musician.Id.Hex(),
+			Address:      r.Musician.Address,
+			Capabilities: rosterCapabilitiesOf(r.Musician.Capabilities),
+			RegisteredAt: r.RegisteredAt,
+			LastSeen:     r.LastSeen,
+		}
+	}
+
+	return c.writeState(rosterFileName, rosterPattern, file)
+}
+
+// persistRoster saves the roster after it changed, a failure only costs
+// the musicians a new registration after a restart
+func (c *ConductorNode) persistRoster() {
+	if err := c.saveRoster(); err != nil {
+		c.log.With("error", err).Warn("saving the roster")
+	}
+}
+
+// loadRoster reads the roster file, it returns an empty roster when there
+// is none
+func (c *ConductorNode) loadRoster() ([]data.Registration, error) {
+	raw, err := os.ReadFile(filepath.Join(c.rootDir, rosterFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file rosterFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, err
+	}
+
+	roster := make([]data.Registration, 0, len(file.Musicians))
+	for _, e := range file.Musicians {
+		id, err := data.IdFromHex(e.Id)
+		if err != nil {
+			c.log.With("id", e.Id).Warn("skipping invalid musician of the roster")
+			continue
+		}
+		roster = append(roster, data.Registration{
+			Musician: data.Musician{
+				Id:           id,
+				Address:      e.Address,
+				Capabilities: e.Capabilities.capabilities(),
+			},
+			RegisteredAt: e.RegisteredAt,
+			LastSeen:     e.LastSeen,
+		})
+	}
+	return roster, nil
+}
+
+// RestoreRoster registers again the musicians saved by a previous run
+// that still answer their health check. The others are forgotten, they
+// register again when they come back.
+func (c *ConductorNode) RestoreRoster() error {
+	roster, err := c.loadRoster()
+	if err != nil {
+		c.log.With("error", err).Warn("reading the roster, starting with an empty one")
+		roster = nil
+	}
+
+	alive := probe(roster, c.config.Roster.ProbeTimeout.Duration())
+	for i, r := range roster {
+		log := c.log.With("id", r.Musician.Id.Hex()).With("address", r.Musician.Address)
+		if !alive[i] {
+			log.Warn("musician of the roster did not answer, forgetting it")
+			continue
+		}
+
+		if err := c.baton.Restore(r); err != nil {
+			log.With("error", err).Warn("restoring musician")
+			continue
+		}
+		log.Info("musician restored")
+	}
+
+	return c.saveRoster()
+}
+
+// probe checks the health of the musicians concurrently and tells which
+// ones answered within the timeout
+func probe(roster []data.Registration, timeout time.Duration) []bool {
+	var mu sync.Mutex
+	alive := make([]bool, len(roster))
+
+	var wg sync.WaitGroup
+	for i, r := range roster {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cli, err := client.New(r.Musician.Address)
+			if err != nil || cli.HealthCheck() != nil {
+				return
+			}
+			mu.Lock()
+			alive[i] = true
+			mu.Unlock()
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return alive
+}
@@ -0,0 +1,104 @@
+package broker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() config.ConductorConf {
+	return config.ConductorConf{
+		MusicDir: "music",
+		Roster: config.Roster{
+			Lease:        typ.Duration(time.Minute),
+			ProbeTimeout: typ.Duration(time.Second),
+		},
+	}
+}
+
+func readRoster(t *testing.T, dir string) rosterFile {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join(dir, rosterFileName))
+	require.NoError(t, err)
+	var file rosterFile
+	require.NoError(t, json.Unmarshal(raw, &file))
+	return file
+}
+
+func TestRestoreRoster(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+	gone := httptest.NewServer(http.NotFoundHandler())
+	gone.Close()
+
+	dir := t.TempDir()
+	node, err := New(logging.Base(), dir, testConfig())
+	require.NoError(t, err)
+
+	// Without roster file the conductor starts empty
+	require.NoError(t, node.RestoreRoster())
+	assert.Empty(t, readRoster(t, dir).Musicians)
+
+	alive := data.Musician{Id: data.GenId(), Address: healthy.URL, Capabilities: &data.Capabilities{
+		Channels:   []uint8{0, 1},
+		Programs:   []uint8{40},
+		Polyphony:  8,
+		Percussion: true,
+		Latency:    20 * time.Millisecond,
+		Labels:     []string{"strings"},
+	}}
+	dead := data.Musician{Id: data.GenId(), Address: gone.URL}
+	require.NoError(t, node.RegisterMusician(alive))
+	require.NoError(t, node.RegisterMusician(dead))
+	registeredAt := node.baton.Roster()[0].RegisteredAt
+
+	file := readRoster(t, dir)
+	require.Len(t, file.Musicians, 2)
+	assert.Equal(t, alive.Id.Hex(), file.Musicians[0].Id)
+	assert.Equal(t, healthy.URL, file.Musicians[0].Address)
+	require.NoError(t, node.Stop())
+
+	// After a restart only the musician answering its health check is
+	// registered again
+	restarted, err := New(logging.Base(), dir, testConfig())
+	require.NoError(t, err)
+	require.NoError(t, restarted.RestoreRoster())
+
+	roster := restarted.baton.Roster()
+	require.Len(t, roster, 1)
+	assert.Equal(t, alive, roster[0].Musician)
+	assert.True(t, registeredAt.Equal(roster[0].RegisteredAt))
+	assert.Len(t, readRoster(t, dir).Musicians, 1)
+
+	require.NoError(t, restarted.UnregisterMusician(alive.Id))
+	assert.Empty(t, readRoster(t, dir).Musicians)
+
+	// No temporary file is left behind
+	matches, err := filepath.Glob(filepath.Join(dir, rosterPattern))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestRestoreCorruptRoster(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, rosterFileName), []byte("{"), 0o600))
+
+	node, err := New(logging.Base(), dir, testConfig())
+	require.NoError(t, err)
+	require.NoError(t, node.RestoreRoster())
+	assert.Empty(t, node.baton.Roster())
+	assert.Empty(t, readRoster(t, dir).Musicians)
+}
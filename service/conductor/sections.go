@@ -0,0 +1,108 @@
+package broker
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"crossjoin.com/gorxestra/data"
+)
+
+const (
+	// sectionsFileName is the file of the data directory holding the
+	// sections defined in the conductor
+	sectionsFileName = "sections.json"
+	sectionsPattern  = ".sections-*"
+)
+
+type sectionsFile struct {
+	Sections []sectionEntry `json:"sections"`
+}
+
+type sectionEntry struct {
+	Name      string   `json:"name"`
+	Labels    []string `json:"labels,omitempty"`
+	Musicians []string `json:"musicians,omitempty"`
+}
+
+func (c *ConductorNode) Sections() ([]data.Section, error) {
+	return c.baton.Sections(), nil
+}
+
+// SetSection defines a section, replacing the one of the same name
+func (c *ConductorNode) SetSection(s data.Section) error {
+	if err := c.baton.SetSection(s); err != nil {
+		return err
+	}
+
+	c.log.
+		With("name", s.Name).
+		Info("section defined")
+	c.persistSections()
+	return nil
+}
+
+func (c *ConductorNode) RemoveSection(name string) error {
+	if err := c.baton.RemoveSection(name); err != nil {
+		return err
+	}
+
+	c.log.
+		With("name", name).
+		Info("section removed")
+	c.persistSections()
+	return nil
+}
+
+// persistSections saves the sections after they changed
+func (c *ConductorNode) persistSections() {
+	c.sectionsMu.Lock()
+	defer c.sectionsMu.Unlock()
+
+	sections := c.baton.Sections()
+	file := sectionsFile{Sections: make([]sectionEntry, len(sections))}
+	for i, s := range sections {
+		file.Sections[i] = sectionEntry{Name: s.Name, Labels: s.Labels}
+		for _, id := range s.Musicians {
+			file.Sections[i].Musicians = append(file.Sections[i].Musicians, id.Hex())
+		}
+	}
+
+	if err := c.writeState(sectionsFileName, sectionsPattern, file); err != nil {
+		c.log.With("error", err).Warn("saving the sections")
+	}
+}
+
+// loadSections defines the sections saved by a previous run
+func (c *ConductorNode) loadSections() error {
+	raw, err := os.ReadFile(filepath.Join(c.rootDir, sectionsFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var file sectionsFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return err
+	}
+
+	for _, e := range file.Sections {
+		s := data.Section{Name: e.Name, Labels: e.Labels}
+		for _, hex := range e.Musicians {
+			id, err := data.IdFromHex(hex)
+			if err != nil {
+				c.log.With("id", hex).Warn("skipping invalid musician of a section")
+				continue
+			}
+			s.Musicians = append(s.Musicians, id)
+		}
+		if err := c.baton.SetSection(s); err != nil {
+			c.log.With("name", e.Name).Warn("skipping invalid section")
+		}
+	}
+	return nil
+}
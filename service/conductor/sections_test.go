@@ -0,0 +1,33 @@
+package broker
+
+import (
+	"testing"
+
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSectionsPersistence(t *testing.T) {
+	dir := t.TempDir()
+	node, err := New(logging.Base(), dir, testConfig())
+	require.NoError(t, err)
+
+	violin := data.GenId()
+	strings := data.Section{Name: "strings", Labels: []string{"strings"}, Musicians: []data.ID{violin}}
+	brass := data.Section{Name: "brass", Labels: []string{"brass"}}
+	require.NoError(t, node.SetSection(strings))
+	require.NoError(t, node.SetSection(brass))
+	assert.ErrorIs(t, node.SetSection(data.Section{Name: "-"}), data.ErrInvalidSection)
+	require.NoError(t, node.RemoveSection("brass"))
+	assert.ErrorIs(t, node.RemoveSection("brass"), data.ErrSectionNotFound)
+	require.NoError(t, node.Stop())
+
+	// The sections are defined again after a restart
+	restarted, err := New(logging.Base(), dir, testConfig())
+	require.NoError(t, err)
+	sections, err := restarted.Sections()
+	require.NoError(t, err)
+	assert.Equal(t, []data.Section{strings}, sections)
+}
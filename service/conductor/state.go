@@ -0,0 +1,37 @@
+package broker
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// writeState writes v as JSON to the file name of the data directory. The
+// file is replaced at once so that a crash never leaves half of it, the
+// temporary file is named after pattern.
+func (c *ConductorNode) writeState(name, pattern string, v any) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.rootDir, pattern)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(c.rootDir, name))
+}
@@ -0,0 +1,76 @@
+package musician
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+)
+
+// capabilitiesOf reads the capabilities of the configuration
+func capabilitiesOf(cfg config.Capabilities) (data.Capabilities, error) {
+	channels, err := parseRanges(cfg.Channels, 15)
+	if err != nil {
+		return data.Capabilities{}, fmt.Errorf("invalid channels: %w", err)
+	}
+
+	programs, err := parseRanges(cfg.Programs, 127)
+	if err != nil {
+		return data.Capabilities{}, fmt.Errorf("invalid programs: %w", err)
+	}
+
+	if cfg.Polyphony < 0 || cfg.Latency.Duration() < 0 {
+		return data.Capabilities{}, data.ErrInvalidCapabilities
+	}
+
+	var labels []string
+	for _, label := range cfg.Labels {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+
+	return data.Capabilities{
+		Channels:   channels,
+		Programs:   programs,
+		Polyphony:  cfg.Polyphony,
+		Percussion: cfg.Percussion,
+		Latency:    cfg.Latency.Duration(),
+		Labels:     labels,
+	}, nil
+}
+
+// parseRanges parses numbers and ranges of numbers such as "0-7", none
+// above limit
+func parseRanges(raw []string, limit int) ([]uint8, error) {
+	var values []uint8
+	for _, r := range raw {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+
+		lowRaw, highRaw, isRange := strings.Cut(r, "-")
+		if !isRange {
+			highRaw = lowRaw
+		}
+		low, err := strconv.Atoi(strings.TrimSpace(lowRaw))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", r)
+		}
+		high, err := strconv.Atoi(strings.TrimSpace(highRaw))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", r)
+		}
+		if low < 0 || high > limit || low > high {
+			return nil, fmt.Errorf("%q out of 0-%d", r, limit)
+		}
+
+		for v := low; v <= high; v++ {
+			values = append(values, uint8(v))
+		}
+	}
+	return values, nil
+}
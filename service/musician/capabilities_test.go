@@ -0,0 +1,50 @@
+package musician
+
+import (
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/util/conf/typ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRanges(t *testing.T) {
+	values, err := parseRanges([]string{"", "0-2", " 9 ", "12 - 13"}, 15)
+	require.NoError(t, err)
+	assert.Equal(t, []uint8{0, 1, 2, 9, 12, 13}, values)
+
+	// The configuration of an empty list is a single empty value
+	values, err = parseRanges([]string{""}, 15)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	for _, raw := range []string{"16", "3-1", "-1", "a", "1-b"} {
+		_, err := parseRanges([]string{raw}, 15)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	c, err := capabilitiesOf(config.Capabilities{
+		Channels:   []string{"0-1"},
+		Programs:   []string{"40-41"},
+		Polyphony:  8,
+		Percussion: false,
+		Latency:    typ.Duration(15 * time.Millisecond),
+		Labels:     []string{"", "strings "},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, data.Capabilities{
+		Channels:  []uint8{0, 1},
+		Programs:  []uint8{40, 41},
+		Polyphony: 8,
+		Latency:   15 * time.Millisecond,
+		Labels:    []string{"strings"},
+	}, c)
+
+	_, err = capabilitiesOf(config.Capabilities{Polyphony: -1})
+	assert.ErrorIs(t, err, data.ErrInvalidCapabilities)
+}
@@ -0,0 +1,109 @@
+package musician
+
+import (
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/util/clocksync"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// burstInterval spaces the clock exchanges made on start
+const burstInterval = 50 * time.Millisecond
+
+var (
+	clockOffsetGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gorxestra",
+		Subsystem: "musician",
+		Name:      "clock_offset_seconds",
+		Help:      "Estimated offset of the conductor clock from the musician clock.",
+	})
+	clockRoundTripGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gorxestra",
+		Subsystem: "musician",
+		Name:      "clock_round_trip_seconds",
+		Help:      "Round trip of the clock exchange the offset estimate is based on.",
+	})
+)
+
+// ClockStatus is the clock synchronization state reported in /ready
+type ClockStatus struct {
+	Synchronized bool      `json:"synchronized"`
+	OffsetNs     int64     `json:"offsetNs"`
+	RoundTripNs  int64     `json:"roundTripNs"`
+	Samples      int       `json:"samples"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// now returns the current time of the conductor clock as estimated by
+// the musician
+func (m *MusicianNode) now() time.Time {
+	return time.Now().Add(m.clock.Offset())
+}
+
+// Clock answers a clock exchange with the conductor time estimate, so that
+// the conductor measures the remaining skew of the musician
+func (m *MusicianNode) Clock(originate time.Time) (data.ClockSample, error) {
+	receive := m.now()
+	return data.ClockSample{
+		Originate: originate,
+		Receive:   receive,
+		Transmit:  m.now(),
+	}, nil
+}
+
+// syncClock keeps the clock offset estimate up to date until the node
+// stops. It starts with a burst of exchanges to fill the estimate window.
+func (m *MusicianNode) syncClock() {
+	cfg := m.config.ClockSync
+	for i := 0; i < cfg.Window; i++ {
+		m.measureClock()
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-time.After(burstInterval):
+		}
+	}
+
+	ticker := time.NewTicker(cfg.Interval.Duration())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.measureClock()
+		}
+	}
+}
+
+func (m *MusicianNode) measureClock() {
+	originate := time.Now()
+	sample, err := m.cli.Clock(originate)
+	if err != nil {
+		m.log.With("error", err).Warn("measuring conductor clock")
+		return
+	}
+
+	m.clock.Add(clocksync.Measure(originate, sample.Receive, sample.Transmit, time.Now()))
+
+	est := m.clock.Estimate()
+	clockOffsetGauge.Set(est.Offset.Seconds())
+	clockRoundTripGauge.Set(est.RoundTrip.Seconds())
+	m.log.
+		With("offset", est.Offset).
+		With("roundTrip", est.RoundTrip).
+		Debug("conductor clock measured")
+}
+
+func (m *MusicianNode) clockStatus() ClockStatus {
+	est := m.clock.Estimate()
+	return ClockStatus{
+		Synchronized: est.Synchronized(),
+		OffsetNs:     est.Offset.Nanoseconds(),
+		RoundTripNs:  est.RoundTrip.Nanoseconds(),
+		Samples:      est.Samples,
+		UpdatedAt:    est.UpdatedAt,
+	}
+}
@@ -0,0 +1,46 @@
+package musician
+
+import (
+	"errors"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+)
+
+// heartbeat renews the registration with the conductor until the node
+// stops. The renewals are spaced so that at least three of them fit in
+// the lease given by the conductor. When the conductor forgot about the
+// musician, because its lease lapsed or the conductor restarted, the
+// musician registers again.
+func (m *MusicianNode) heartbeat() {
+	configured := m.config.Conductor.Heartbeat.Duration()
+	interval := configured
+
+	// The first heartbeat is sent right away to learn the lease
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		lease, err := m.cli.Heartbeat(m.id)
+		switch {
+		case errors.Is(err, data.ErrMusicianNotFound):
+			m.log.Warn("registration lost, registering again")
+			if err := m.registerMusician(); err != nil {
+				return
+			}
+			timer.Reset(0)
+			continue
+		case err != nil:
+			m.log.With("error", err).Warn("renewing registration")
+		case lease > 0:
+			interval = min(configured, lease/3)
+		}
+
+		timer.Reset(interval)
+	}
+}
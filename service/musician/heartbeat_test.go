@@ -0,0 +1,89 @@
+package musician
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/daemon/conductord/api/client/v1"
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/service/musician/output"
+	"crossjoin.com/gorxestra/util/conf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// forgetfulConductor is a conductor answering the heartbeats, once
+// released, with a registration lost
+type forgetfulConductor struct {
+	client.ClientDaemon
+
+	beating chan struct{}
+	release chan struct{}
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (c *forgetfulConductor) record(call string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+}
+
+func (c *forgetfulConductor) RegisterMusician(data.Musician) error {
+	c.record("register")
+	return nil
+}
+
+func (c *forgetfulConductor) UnregisterMusician(data.ID) error {
+	c.record("unregister")
+	return nil
+}
+
+func (c *forgetfulConductor) Heartbeat(data.ID) (time.Duration, error) {
+	close(c.beating)
+	<-c.release
+	return 0, data.ErrMusicianNotFound
+}
+
+func (c *forgetfulConductor) Clock(time.Time) (data.ClockSample, error) {
+	return data.ClockSample{}, errors.New("no clock")
+}
+
+// TestStopDuringHeartbeat checks that a heartbeat in flight when the
+// musician stops does not register it again once unregistered
+func TestStopDuringHeartbeat(t *testing.T) {
+	var cfg config.MusicianConf
+	_, err := conf.ParseConfig(&cfg, conf.WithSources())
+	require.NoError(t, err)
+	cfg.Output.Backend = output.BackendMemory
+
+	m, err := New(logging.Base(), t.TempDir(), cfg)
+	require.NoError(t, err)
+	cli := &forgetfulConductor{beating: make(chan struct{}), release: make(chan struct{})}
+	m.cli = cli
+	require.NoError(t, m.Start())
+	<-cli.beating
+
+	stopped := make(chan error)
+	go func() {
+		stopped <- m.Stop()
+	}()
+
+	// The musician unregisters once the heartbeat is over
+	select {
+	case <-stopped:
+		t.Fatal("stopped during the heartbeat")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(cli.release)
+	require.NoError(t, <-stopped)
+
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	assert.Equal(t, []string{"register", "unregister"}, cli.calls)
+}
@@ -0,0 +1,53 @@
+package musician
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"crossjoin.com/gorxestra/data"
+)
+
+// idFileName is the file of the data directory holding the id of the
+// musician, so that it stays the same musician for the conductor across
+// restarts
+const idFileName = "musician.id"
+
+// loadId returns the id stored in the data directory. The first time it
+// generates the id and stores it.
+func loadId(rootDir string) (data.ID, error) {
+	path := filepath.Join(rootDir, idFileName)
+	raw, err := os.ReadFile(path)
+	if err == nil {
+		id, err := data.IdFromHex(strings.TrimSpace(string(raw)))
+		if err != nil {
+			return data.ID{}, fmt.Errorf("invalid musician id in %s: %w", path, err)
+		}
+		return id, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return data.ID{}, err
+	}
+
+	id := data.GenId()
+	tmp, err := os.CreateTemp(rootDir, "."+idFileName+"-*")
+	if err != nil {
+		return data.ID{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := fmt.Fprintln(tmp, id.Hex()); err != nil {
+		tmp.Close()
+		return data.ID{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return data.ID{}, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return data.ID{}, err
+	}
+	return id, nil
+}
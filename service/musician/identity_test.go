@@ -0,0 +1,30 @@
+package musician
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadId(t *testing.T) {
+	dir := t.TempDir()
+
+	id, err := loadId(dir)
+	require.NoError(t, err)
+
+	// The id is kept across restarts
+	again, err := loadId(dir)
+	require.NoError(t, err)
+	assert.Equal(t, id, again)
+
+	raw, err := os.ReadFile(filepath.Join(dir, idFileName))
+	require.NoError(t, err)
+	assert.Equal(t, id.Hex()+"\n", string(raw))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, idFileName), []byte("not an id"), 0o600))
+	_, err = loadId(dir)
+	assert.Error(t, err)
+}
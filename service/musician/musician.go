@@ -2,17 +2,18 @@ package musician
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"crossjoin.com/gorxestra/config"
 	"crossjoin.com/gorxestra/daemon/conductord/api/client/v1"
 	"crossjoin.com/gorxestra/data"
 	"crossjoin.com/gorxestra/logging"
-	"gitlab.com/gomidi/midi/v2"
-
-	"gitlab.com/gomidi/midi/v2/drivers"
-	_ "gitlab.com/gomidi/midi/v2/drivers/portmididrv" // autoregisters driver
+	"crossjoin.com/gorxestra/service/musician/output"
+	"crossjoin.com/gorxestra/service/musician/scheduler"
+	"crossjoin.com/gorxestra/util/clocksync"
 )
 
 type MusicianNode struct {
@@ -21,74 +22,154 @@ type MusicianNode struct {
 
 	config config.MusicianConf
 	cli    client.ClientDaemon
+	id     data.ID
+	clock  *clocksync.Estimator
+	// capabilities are advertised to the conductor
+	capabilities data.Capabilities
 
-	out    drivers.Out
+	out    output.Output
+	sched  *scheduler.Scheduler
 	ctx    context.Context
 	cancel context.CancelFunc
+	// beating waits for the heartbeat, which may register the musician
+	// again, before unregistering it
+	beating sync.WaitGroup
 }
 
 func New(log logging.Logger, rootDir string, cfg config.MusicianConf) (*MusicianNode, error) {
+	id, err := loadId(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading musician id: %w", err)
+	}
+
+	capabilities, err := capabilitiesOf(cfg.Capabilities)
+	if err != nil {
+		return nil, fmt.Errorf("reading capabilities: %w", err)
+	}
+	if cfg.ClockSync.Window < 1 {
+		return nil, fmt.Errorf("invalid clock sync window %d, at least one exchange is needed", cfg.ClockSync.Window)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	cli, err := client.New(cfg.Conductor.ConductorAddr)
 
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 
 	m := MusicianNode{
-		out:     nil,
-		log:     log,
-		rootDir: rootDir,
-		config:  cfg,
-		cli:     cli,
-		ctx:     ctx,
-		cancel:  cancel,
+		out:          nil,
+		log:          log,
+		rootDir:      rootDir,
+		config:       cfg,
+		cli:          cli,
+		id:           id,
+		capabilities: capabilities,
+		clock:        clocksync.NewEstimator(cfg.ClockSync.Window),
+		ctx:          ctx,
+		cancel:       cancel,
 	}
 
 	return &m, nil
 }
 
-func (m *MusicianNode) Play(bs []byte) error {
-	m.log.With("note", bs).Info("playing sound")
-	return m.out.Send(bs)
+func (m *MusicianNode) Play(note data.Note) error {
+	m.log.
+		With("note", note.Message).
+		With("at", note.At).
+		Info("scheduling sound")
+	m.sched.Schedule(note)
+	return nil
 }
 
+// registerMusician registers the musician with the conductor, trying
+// again until it succeeds or the node stops
 func (m *MusicianNode) registerMusician() error {
-
 	for {
-		m.log.Info("attemp to register node")
+		// A stopping musician must not register again
+		if err := m.ctx.Err(); err != nil {
+			return err
+		}
+
+		m.log.With("id", m.id.Hex()).Info("attemp to register node")
 
 		err := m.cli.RegisterMusician(data.Musician{
-			Id:      data.GenId(),
-			Address: m.config.Conductor.AdvertiseAddr,
+			Id:           m.id,
+			Address:      m.config.Conductor.AdvertiseAddr,
+			Capabilities: &m.capabilities,
 		})
 		if err == nil {
 			return nil
 		}
 
 		m.log.With("error", err).Warn("attempting again to connect")
-		time.Sleep(1 * time.Second)
+		select {
+		case <-m.ctx.Done():
+			return m.ctx.Err()
+		case <-time.After(1 * time.Second):
+		}
 	}
 }
 
+// Capabilities returns what the musician advertises to the conductor
+func (m *MusicianNode) Capabilities() (data.Capabilities, error) {
+	return m.capabilities, nil
+}
+
 func (m *MusicianNode) unregisterMusician() error {
-	//TODO currently not implemented. Not needed for the challenge
-	return nil
+	m.log.With("id", m.id.Hex()).Info("unregistering node")
+
+	err := m.cli.UnregisterMusician(m.id)
+	if errors.Is(err, data.ErrMusicianNotFound) {
+		// The conductor already forgot about us
+		return nil
+	}
+
+	return err
 }
 
 func (m *MusicianNode) Start() error {
-	out, err := midi.OutPort(3) //midi.FindOutPort("qsynth")
+	out, err := output.New(m.log, m.rootDir, m.config.Output)
 	if err != nil {
-		return fmt.Errorf("could not open midi port:%w", err)
+		return fmt.Errorf("could not open midi output: %w", err)
 	}
+	m.log.With("backend", m.config.Output.Backend).Info("midi output opened")
 
 	m.out = out
+	m.sched = scheduler.New(m.log, out, m.now)
+	go m.sched.Run(m.ctx)
 
-	return m.registerMusician()
+	err = m.registerMusician()
+	if err != nil {
+		return err
+	}
+
+	go m.syncClock()
+	m.beating.Add(1)
+	go func() {
+		defer m.beating.Done()
+		m.heartbeat()
+	}()
+	return nil
 }
 
 func (m *MusicianNode) Stop() error {
-	midi.CloseDriver()
+	m.cancel()
+	m.beating.Wait()
+
+	err := m.unregisterMusician()
+	if err != nil {
+		m.log.With("error", err).Warn("unregistering node")
+	}
+
+	if m.out != nil {
+		err = m.out.Close()
+		if err != nil {
+			m.log.With("error", err).Warn("closing midi output")
+		}
+	}
+
 	return nil
 }
 
@@ -96,6 +177,27 @@ func (m *MusicianNode) Config() config.MusicianConf {
 	return m.config
 }
 
+// Id returns the id the musician registers with
+func (m *MusicianNode) Id() data.ID {
+	return m.id
+}
+
+// Output returns the output the musician plays on, nil until it started
+func (m *MusicianNode) Output() output.Output {
+	return m.out
+}
+
 func (m *MusicianNode) Status() error {
 	return nil
 }
+
+// Status is the detailed status of the musician
+type Status struct {
+	Clock ClockStatus `json:"clock"`
+}
+
+func (m *MusicianNode) StatusReport() any {
+	return Status{
+		Clock: m.clockStatus(),
+	}
+}
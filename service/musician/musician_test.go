@@ -1 +1,24 @@
 package musician
+
+import (
+	"testing"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/util/conf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClockSyncWindow(t *testing.T) {
+	var cfg config.MusicianConf
+	_, err := conf.ParseConfig(&cfg, conf.WithSources())
+	require.NoError(t, err)
+
+	_, err = New(logging.Base(), t.TempDir(), cfg)
+	require.NoError(t, err)
+
+	cfg.ClockSync.Window = 0
+	_, err = New(logging.Base(), t.TempDir(), cfg)
+	assert.ErrorContains(t, err, "clock sync window")
+}
@@ -0,0 +1,27 @@
+package output
+
+import (
+	"crossjoin.com/gorxestra/logging"
+	"gitlab.com/gomidi/midi/v2"
+)
+
+// Log only logs the messages, which lets a musician run without any
+// MIDI device
+type Log struct {
+	log logging.Logger
+}
+
+func NewLog(log logging.Logger) *Log {
+	return &Log{
+		log: log,
+	}
+}
+
+func (l *Log) Send(msg []byte) error {
+	l.log.With("message", midi.Message(msg).String()).Info("playing sound")
+	return nil
+}
+
+func (l *Log) Close() error {
+	return nil
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Played is a message received by the memory output
+type Played struct {
+	Message []byte
+	At      time.Time
+}
+
+// Memory keeps the messages it receives, for tests
+type Memory struct {
+	mu     sync.Mutex
+	played []Played
+	closed bool
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		mu: sync.Mutex{},
+	}
+}
+
+func (m *Memory) Send(msg []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+
+	m.played = append(m.played, Played{
+		Message: slices.Clone(msg),
+		At:      time.Now(),
+	})
+	return nil
+}
+
+func (m *Memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
+
+// Played returns the messages received so far
+func (m *Memory) Played() []Played {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.played)
+}
@@ -0,0 +1,55 @@
+// Package output provides the backends a musician plays its notes on
+package output
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/logging"
+)
+
+// Backend names as set in config.Output
+const (
+	BackendPort   = "port"
+	BackendSMF    = "smf"
+	BackendLog    = "log"
+	BackendMemory = "memory"
+)
+
+// ErrClosed is returned when sending to a closed output
+var ErrClosed = errors.New("output closed")
+
+// Output is where a musician sends the MIDI messages it plays
+type Output interface {
+	// Send plays a raw MIDI message
+	Send(msg []byte) error
+	// Close releases the output
+	Close() error
+}
+
+// New opens the output backend selected in the configuration. Relative
+// file paths are resolved from rootDir.
+func New(log logging.Logger, rootDir string, cfg config.Output) (Output, error) {
+	switch cfg.Backend {
+	case BackendPort:
+		port, err := OpenPort(cfg.Port)
+		if err != nil {
+			return nil, err
+		}
+		return port, nil
+	case BackendSMF:
+		path := cfg.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(rootDir, path)
+		}
+		return NewSMF(path), nil
+	case BackendLog:
+		return NewLog(log), nil
+	case BackendMemory:
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("unknown output backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/config"
+	"crossjoin.com/gorxestra/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+func TestNewSelectsBackend(t *testing.T) {
+	dir := t.TempDir()
+
+	out, err := New(logging.Base(), dir, config.Output{Backend: BackendMemory})
+	require.NoError(t, err)
+	assert.IsType(t, &Memory{}, out)
+
+	out, err = New(logging.Base(), dir, config.Output{Backend: BackendSMF, File: "rec.mid"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "rec.mid"), out.(*SMF).path)
+
+	_, err = New(logging.Base(), dir, config.Output{Backend: "speaker"})
+	assert.Error(t, err)
+}
+
+func TestMemory(t *testing.T) {
+	m := NewMemory()
+	require.NoError(t, m.Send(midi.NoteOn(0, 60, 100)))
+	require.NoError(t, m.Close())
+	assert.ErrorIs(t, m.Send(midi.NoteOff(0, 60)), ErrClosed)
+
+	played := m.Played()
+	require.Len(t, played, 1)
+	assert.Equal(t, []byte(midi.NoteOn(0, 60, 100)), played[0].Message)
+}
+
+func TestSMFRecordsTiming(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec.mid")
+	s := NewSMF(path)
+
+	require.NoError(t, s.Send(midi.NoteOn(0, 60, 100)))
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, s.Send(midi.NoteOff(0, 60)))
+	require.NoError(t, s.Close())
+
+	var got []smf.TrackEvent
+	smf.ReadTracks(path).Only(midi.NoteOnMsg, midi.NoteOffMsg).Do(func(ev smf.TrackEvent) {
+		got = append(got, ev)
+	})
+
+	require.Len(t, got, 2)
+	assert.True(t, got[0].Message.Is(midi.NoteOnMsg))
+	assert.True(t, got[1].Message.Is(midi.NoteOffMsg))
+	elapsed := time.Duration(got[1].AbsMicroSeconds-got[0].AbsMicroSeconds) * time.Microsecond
+	assert.GreaterOrEqual(t, elapsed, 45*time.Millisecond)
+}
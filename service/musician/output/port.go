@@ -0,0 +1,61 @@
+package output
+
+import (
+	"fmt"
+	"sync"
+
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/drivers"
+)
+
+// Port plays the messages on a MIDI out port of the system driver
+type Port struct {
+	mu  sync.Mutex
+	out drivers.Out
+}
+
+// OpenPort opens the first MIDI out port whose name contains name. The
+// MIDI driver is compiled in with the portmidi build tag.
+func OpenPort(name string) (*Port, error) {
+	if drivers.Get() == nil {
+		return nil, fmt.Errorf("no MIDI driver available, build with the portmidi tag")
+	}
+
+	out, err := midi.FindOutPort(name)
+	if err != nil {
+		return nil, fmt.Errorf("finding midi port %q: %w", name, err)
+	}
+
+	if err := out.Open(); err != nil {
+		return nil, fmt.Errorf("opening midi port %q: %w", name, err)
+	}
+
+	return &Port{
+		mu:  sync.Mutex{},
+		out: out,
+	}, nil
+}
+
+func (p *Port) Send(msg []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.out == nil {
+		return ErrClosed
+	}
+	return p.out.Send(msg)
+}
+
+func (p *Port) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.out == nil {
+		return nil
+	}
+
+	err := p.out.Close()
+	p.out = nil
+	midi.CloseDriver()
+	return err
+}
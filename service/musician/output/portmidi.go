@@ -0,0 +1,7 @@
+//go:build portmidi
+
+package output
+
+import (
+	_ "gitlab.com/gomidi/midi/v2/drivers/portmididrv" // autoregisters driver
+)
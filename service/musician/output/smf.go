@@ -0,0 +1,87 @@
+package output
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+const (
+	// smfResolution is the number of ticks per quarter note of the
+	// recorded file
+	smfResolution = 960
+	// smfTempo is the tempo of the recorded file, only used to convert
+	// the time between messages to ticks
+	smfTempo = 120.0
+)
+
+// SMF records the messages with their timing and writes them to a
+// Standard MIDI File when closed
+type SMF struct {
+	path string
+
+	mu     sync.Mutex
+	track  smf.Track
+	last   time.Time
+	closed bool
+}
+
+func NewSMF(path string) *SMF {
+	return &SMF{
+		path: path,
+		mu:   sync.Mutex{},
+	}
+}
+
+func (s *SMF) Send(msg []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+
+	now := time.Now()
+	var delta uint32
+	if !s.last.IsZero() {
+		delta = smf.MetricTicks(smfResolution).Ticks(smfTempo, now.Sub(s.last))
+	}
+	s.last = now
+
+	s.track.Add(delta, slices.Clone(msg))
+	return nil
+}
+
+func (s *SMF) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	file := smf.New()
+	file.TimeFormat = smf.MetricTicks(smfResolution)
+
+	var meta smf.Track
+	meta.Add(0, smf.MetaTempo(smfTempo))
+	meta.Close(0)
+	s.track.Close(0)
+
+	if err := file.Add(meta); err != nil {
+		return fmt.Errorf("recording midi file: %w", err)
+	}
+	if err := file.Add(s.track); err != nil {
+		return fmt.Errorf("recording midi file: %w", err)
+	}
+
+	if err := file.WriteFile(s.path); err != nil {
+		return fmt.Errorf("writing midi file: %w", err)
+	}
+
+	return nil
+}
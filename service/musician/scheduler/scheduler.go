@@ -0,0 +1,131 @@
+// Package scheduler buffers the notes received by a musician and sends
+// them to the MIDI output at their play time.
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"crossjoin.com/gorxestra/service/musician/output"
+)
+
+// Scheduler plays notes on an output at the time they were scheduled for
+type Scheduler struct {
+	log logging.Logger
+	out output.Output
+	now func() time.Time
+
+	mu    sync.Mutex
+	queue noteQueue
+	wake  chan struct{}
+}
+
+// New returns a scheduler playing on out. Play times are compared to the
+// time returned by now, which defaults to the local clock when nil.
+func New(log logging.Logger, out output.Output, now func() time.Time) *Scheduler {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Scheduler{
+		log:   log,
+		out:   out,
+		now:   now,
+		mu:    sync.Mutex{},
+		queue: make(noteQueue, 0, 256),
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// Schedule buffers a note until its play time. Notes without play time
+// or whose play time already passed are played as soon as possible.
+func (s *Scheduler) Schedule(n data.Note) {
+	s.mu.Lock()
+	heap.Push(&s.queue, n)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Pending returns the number of notes waiting for their play time
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// Run sends the notes to the output until the context is done
+func (s *Scheduler) Run(ctx context.Context) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		wait := s.playDue()
+
+		timer.Reset(wait)
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// playDue sends every note whose play time has come and returns how long
+// to wait for the next one
+func (s *Scheduler) playDue() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for len(s.queue) > 0 {
+		next := s.queue[0]
+		if wait := next.At.Sub(now); !next.At.IsZero() && wait > 0 {
+			return wait
+		}
+
+		heap.Pop(&s.queue)
+		if err := s.out.Send(next.Message); err != nil {
+			s.log.With("error", err).Error("playing sound")
+		}
+	}
+
+	return time.Hour
+}
+
+// noteQueue is a heap of notes ordered by play time and sequence
+type noteQueue []data.Note
+
+func (q noteQueue) Len() int {
+	return len(q)
+}
+
+func (q noteQueue) Less(i, j int) bool {
+	if q[i].At.Equal(q[j].At) {
+		return q[i].Seq < q[j].Seq
+	}
+	return q[i].At.Before(q[j].At)
+}
+
+func (q noteQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *noteQueue) Push(x any) {
+	*q = append(*q, x.(data.Note))
+}
+
+func (q *noteQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
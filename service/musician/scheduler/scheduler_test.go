@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"crossjoin.com/gorxestra/data"
+	"crossjoin.com/gorxestra/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+type sent struct {
+	msg []byte
+	at  time.Time
+}
+
+type fakeOut struct {
+	mu   sync.Mutex
+	sent []sent
+}
+
+func (f *fakeOut) Open() error             { return nil }
+func (f *fakeOut) Close() error            { return nil }
+func (f *fakeOut) IsOpen() bool            { return true }
+func (f *fakeOut) Number() int             { return 0 }
+func (f *fakeOut) String() string          { return "fake" }
+func (f *fakeOut) Underlying() interface{} { return nil }
+
+func (f *fakeOut) Send(bs []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, sent{msg: bs, at: time.Now()})
+	return nil
+}
+
+func (f *fakeOut) get() []sent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]sent(nil), f.sent...)
+}
+
+func TestSchedulerPlaysInTimeOrder(t *testing.T) {
+	out := &fakeOut{}
+	s := New(logging.Base(), out, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	start := time.Now()
+	s.Schedule(data.Note{Seq: 3, At: start.Add(60 * time.Millisecond), Message: []byte{3}})
+	s.Schedule(data.Note{Seq: 1, At: start.Add(20 * time.Millisecond), Message: []byte{1}})
+	s.Schedule(data.Note{Seq: 2, At: start.Add(20 * time.Millisecond), Message: []byte{2}})
+	s.Schedule(data.Note{Seq: 0, Message: []byte{0}})
+
+	assert.Eventually(t, func() bool {
+		return len(out.get()) == 4
+	}, time.Second, 5*time.Millisecond)
+
+	got := out.get()
+	for i := range got {
+		assert.Equal(t, []byte{byte(i)}, got[i].msg)
+	}
+
+	assert.False(t, got[1].at.Before(start.Add(20*time.Millisecond)))
+	assert.False(t, got[3].at.Before(start.Add(60*time.Millisecond)))
+	assert.Equal(t, 0, s.Pending())
+}
+
+func TestSchedulerUsesGivenClock(t *testing.T) {
+	out := &fakeOut{}
+	// The reference clock is one second ahead of the local clock
+	offset := time.Second
+	s := New(logging.Base(), out, func() time.Time {
+		return time.Now().Add(offset)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	start := time.Now()
+	s.Schedule(data.Note{Seq: 1, At: start.Add(offset + 30*time.Millisecond), Message: []byte{1}})
+
+	assert.Eventually(t, func() bool {
+		return len(out.get()) == 1
+	}, 500*time.Millisecond, 5*time.Millisecond)
+
+	got := out.get()[0]
+	assert.False(t, got.at.Before(start.Add(30*time.Millisecond)))
+}
@@ -0,0 +1,86 @@
+// Package broadcast delivers values to many subscribers without letting a
+// slow subscriber hold back the publisher or the other subscribers
+package broadcast
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Broadcaster hands every published value to all its subscribers
+type Broadcaster[T any] struct {
+	mu   sync.Mutex
+	subs map[*Subscription[T]]struct{}
+}
+
+func New[T any]() *Broadcaster[T] {
+	return &Broadcaster[T]{
+		mu:   sync.Mutex{},
+		subs: make(map[*Subscription[T]]struct{}),
+	}
+}
+
+// Publish hands the value to every subscriber. It never blocks, the value
+// is dropped for the subscribers whose buffer is full.
+func (b *Broadcaster[T]) Publish(v T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subs {
+		select {
+		case s.ch <- v:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+}
+
+// Subscribe returns a subscription buffering up to buffer values
+func (b *Broadcaster[T]) Subscribe(buffer int) *Subscription[T] {
+	s := &Subscription[T]{
+		b:  b,
+		ch: make(chan T, buffer),
+	}
+
+	b.mu.Lock()
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+
+	return s
+}
+
+// Subscribers returns the number of subscriptions
+func (b *Broadcaster[T]) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// Subscription receives the values published after it was created
+type Subscription[T any] struct {
+	b       *Broadcaster[T]
+	ch      chan T
+	dropped atomic.Uint64
+	once    sync.Once
+}
+
+// C returns the channel the values are delivered on. It is closed when
+// the subscription is closed.
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// TakeDropped returns the number of values dropped since the last call
+func (s *Subscription[T]) TakeDropped() uint64 {
+	return s.dropped.Swap(0)
+}
+
+// Close ends the subscription
+func (s *Subscription[T]) Close() {
+	s.once.Do(func() {
+		s.b.mu.Lock()
+		delete(s.b.subs, s)
+		close(s.ch)
+		s.b.mu.Unlock()
+	})
+}
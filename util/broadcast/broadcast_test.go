@@ -0,0 +1,34 @@
+package broadcast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowSubscriberDropsValues(t *testing.T) {
+	b := New[int]()
+	fast := b.Subscribe(10)
+	slow := b.Subscribe(1)
+
+	for i := 0; i < 3; i++ {
+		b.Publish(i)
+	}
+
+	assert.Len(t, fast.C(), 3)
+	assert.Equal(t, uint64(0), fast.TakeDropped())
+
+	assert.Equal(t, 0, <-slow.C())
+	assert.Equal(t, uint64(2), slow.TakeDropped())
+	assert.Equal(t, uint64(0), slow.TakeDropped())
+
+	slow.Close()
+	slow.Close()
+	assert.Equal(t, 1, b.Subscribers())
+	_, open := <-slow.C()
+	assert.False(t, open)
+
+	// Publishing after a subscription closed does not panic
+	b.Publish(3)
+	assert.Len(t, fast.C(), 4)
+}
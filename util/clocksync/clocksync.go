@@ -0,0 +1,107 @@
+// Package clocksync estimates the offset between the local clock and a
+// remote clock with NTP style exchanges.
+//
+// An exchange records four timestamps: the local time the request left
+// (originate), the remote time it arrived (receive), the remote time the
+// answer left (transmit) and the local time the answer arrived
+// (destination).
+package clocksync
+
+import (
+	"sync"
+	"time"
+)
+
+// Measurement is the result of a complete clock exchange
+type Measurement struct {
+	// Offset is how far ahead the remote clock is from the local clock
+	Offset time.Duration
+	// RoundTrip is the network delay of the exchange
+	RoundTrip time.Duration
+}
+
+// Measure computes the offset and round trip of a clock exchange from its
+// four timestamps
+func Measure(originate, receive, transmit, destination time.Time) Measurement {
+	return Measurement{
+		Offset:    (receive.Sub(originate) + transmit.Sub(destination)) / 2,
+		RoundTrip: destination.Sub(originate) - transmit.Sub(receive),
+	}
+}
+
+// Best returns the measurement with the lowest round trip, which is the
+// one whose offset is the least affected by network jitter
+func Best(ms []Measurement) Measurement {
+	var best Measurement
+	for i := range ms {
+		if i == 0 || ms[i].RoundTrip < best.RoundTrip {
+			best = ms[i]
+		}
+	}
+	return best
+}
+
+// Estimate is the running estimation of the offset to a remote clock
+type Estimate struct {
+	Measurement
+	// Samples is the number of measurements the estimate is based on
+	Samples int
+	// UpdatedAt is the local time of the last measurement
+	UpdatedAt time.Time
+}
+
+// Synchronized tells if at least one measurement was made
+func (e Estimate) Synchronized() bool {
+	return e.Samples > 0
+}
+
+// Estimator keeps a running estimate of the offset to a remote clock
+// based on its most recent measurements
+type Estimator struct {
+	mu      sync.Mutex
+	window  []Measurement
+	next    int
+	total   int
+	updated time.Time
+}
+
+// NewEstimator returns an estimator keeping the given number of
+// measurements, at least one
+func NewEstimator(size int) *Estimator {
+	return &Estimator{
+		mu:     sync.Mutex{},
+		window: make([]Measurement, 0, max(size, 1)),
+	}
+}
+
+// Add records a measurement
+func (e *Estimator) Add(m Measurement) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.window) < cap(e.window) {
+		e.window = append(e.window, m)
+	} else {
+		e.window[e.next] = m
+	}
+	e.next = (e.next + 1) % cap(e.window)
+	e.total++
+	e.updated = time.Now()
+}
+
+// Estimate returns the current estimate
+func (e *Estimator) Estimate() Estimate {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return Estimate{
+		Measurement: Best(e.window),
+		Samples:     e.total,
+		UpdatedAt:   e.updated,
+	}
+}
+
+// Offset returns the current offset estimate
+func (e *Estimator) Offset() time.Duration {
+	return e.Estimate().Offset
+}
@@ -0,0 +1,48 @@
+package clocksync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasure(t *testing.T) {
+	local := time.Unix(1000, 0)
+	offset := 30 * time.Millisecond
+
+	// 4ms to reach the remote, 1ms of processing, 6ms to come back
+	m := Measure(
+		local,
+		local.Add(offset+4*time.Millisecond),
+		local.Add(offset+5*time.Millisecond),
+		local.Add(11*time.Millisecond),
+	)
+
+	assert.Equal(t, 10*time.Millisecond, m.RoundTrip)
+	// Asymmetric paths bias the offset by half the asymmetry
+	assert.Equal(t, offset-time.Millisecond, m.Offset)
+}
+
+func TestEstimatorKeepsLowestRoundTrip(t *testing.T) {
+	e := NewEstimator(2)
+	assert.False(t, e.Estimate().Synchronized())
+
+	e.Add(Measurement{Offset: time.Millisecond, RoundTrip: time.Millisecond})
+	e.Add(Measurement{Offset: 9 * time.Millisecond, RoundTrip: 20 * time.Millisecond})
+	assert.Equal(t, time.Millisecond, e.Offset())
+
+	// The oldest measurement leaves the window
+	e.Add(Measurement{Offset: 5 * time.Millisecond, RoundTrip: 10 * time.Millisecond})
+	assert.Equal(t, 5*time.Millisecond, e.Offset())
+	assert.Equal(t, 3, e.Estimate().Samples)
+}
+
+func TestEstimatorEmptyWindow(t *testing.T) {
+	// A window of no measurement keeps the last one
+	e := NewEstimator(0)
+	e.Add(Measurement{Offset: time.Millisecond, RoundTrip: time.Millisecond})
+	e.Add(Measurement{Offset: 5 * time.Millisecond, RoundTrip: 10 * time.Millisecond})
+	assert.Equal(t, 5*time.Millisecond, e.Offset())
+	assert.Equal(t, 2, e.Estimate().Samples)
+}
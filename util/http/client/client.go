@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"crossjoin.com/gorxestra/util/http/client/protocol"
 	"crossjoin.com/gorxestra/util/http/common"
@@ -47,6 +48,8 @@ type ErrorMapper func(errStr string) (error, bool)
 type RestClient struct {
 	serverURL url.URL
 	errMapper ErrorMapper
+	// timeout bounds each request, zero for no limit
+	timeout time.Duration
 }
 
 // MakeRestClient is the factory for constructing a RestClient for a given endpoint
@@ -65,6 +68,13 @@ func MakeRestClientWithMapper(url url.URL, mapper ErrorMapper) RestClient {
 	}
 }
 
+// WithTimeout returns a copy of the client whose requests fail once they
+// take longer than the timeout, zero for no limit
+func (client RestClient) WithTimeout(timeout time.Duration) RestClient {
+	client.timeout = timeout
+	return client
+}
+
 // filterASCII filter out the non-ascii printable characters out of the given input string.
 // It's used as a security qualifier before adding network provided data into an error message.
 // The function allows only characters in the range of [32..126], which excludes all the
@@ -146,11 +156,9 @@ func (client RestClient) submitForm(
 	payloadProcessor protocol.PayloadProcessor, expectNoContent bool,
 ) error {
 	var err error
-	queryURL := client.serverURL
-	queryURL.Path, err = url.JoinPath(queryURL.Path, request.Path)
-	if err != nil {
-		return err
-	}
+	// The request path is already escaped, escaped segments such as
+	// names with spaces are kept as they are
+	queryURL := *client.serverURL.JoinPath(request.Path)
 
 	var req *http.Request
 	var v url.Values
@@ -181,7 +189,7 @@ func (client RestClient) submitForm(
 		Transport:     nil,
 		CheckRedirect: nil,
 		Jar:           nil,
-		Timeout:       0,
+		Timeout:       client.timeout,
 	}
 
 	defer httpClient.CloseIdleConnections()
@@ -225,3 +233,13 @@ func (client RestClient) JsonSubmitForm(response interface{}, request Request) e
 	}
 	return client.submitForm(response, request, p, false)
 }
+
+// MultipartSubmitForm performs a request with a protocol.MultipartBody
+// body against the path, the response is decoded as json
+func (client RestClient) MultipartSubmitForm(response interface{}, request Request) error {
+	p, err := protocol.NewPayloadProcessor(protocol.ContentTypeMultipart)
+	if err != nil {
+		return err
+	}
+	return client.submitForm(response, request, p, false)
+}
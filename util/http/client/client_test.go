@@ -0,0 +1,30 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestClientTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	cli := MakeRestClient(*u).WithTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	err = cli.JsonSubmitForm(nil, Request{Path: "/v1/hang", Method: http.MethodGet})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 )
 
 type ContentType string
 
 const (
-	ContentTypeJSON   ContentType = "application/json"
-	HeaderContentType string      = "Content-Type"
+	ContentTypeJSON      ContentType = "application/json"
+	ContentTypeMultipart ContentType = "multipart/form-data"
+	HeaderContentType    string      = "Content-Type"
 )
 
 type PayloadProcessor struct {
@@ -33,6 +36,15 @@ func NewPayloadProcessor(typ ContentType) (PayloadProcessor, error) {
 			Decoder:     jsonDecoder,
 			ContentType: ContentTypeJSON,
 		}, nil
+	case ContentTypeMultipart:
+		// The boundary is part of the content type, it is chosen once
+		// for the encoder and the header
+		boundary := multipart.NewWriter(io.Discard).Boundary()
+		return PayloadProcessor{
+			Encoder:     multipartEncoder(boundary),
+			Decoder:     jsonDecoder,
+			ContentType: ContentType(mime.FormatMediaType(string(ContentTypeMultipart), map[string]string{"boundary": boundary})),
+		}, nil
 	}
 	return PayloadProcessor{}, fmt.Errorf("payload type not implemented")
 }
@@ -45,6 +57,55 @@ func jsonEncoder(data interface{}) (*bytes.Buffer, error) {
 	return bytes.NewBuffer(jsonValue), nil
 }
 
+// FormFile is a file sent in a multipart body
+type FormFile struct {
+	Field    string
+	FileName string
+	Content  io.Reader
+}
+
+// MultipartBody is the body of a multipart request
+type MultipartBody struct {
+	Fields map[string]string
+	Files  []FormFile
+}
+
+func multipartEncoder(boundary string) BodyEncoder {
+	return func(data interface{}) (*bytes.Buffer, error) {
+		body, ok := data.(MultipartBody)
+		if !ok {
+			return nil, fmt.Errorf("multipart payload must be a MultipartBody, got %T", data)
+		}
+
+		buf := new(bytes.Buffer)
+		w := multipart.NewWriter(buf)
+		if err := w.SetBoundary(boundary); err != nil {
+			return nil, err
+		}
+
+		for field, value := range body.Fields {
+			if err := w.WriteField(field, value); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, file := range body.Files {
+			part, err := w.CreateFormFile(file.Field, file.FileName)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := io.Copy(part, file.Content); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf, nil
+	}
+}
+
 func jsonDecoder(result interface{}, encoded io.ReadCloser) error {
 	dec := NewJSONDecoder(encoded)
 	return dec.Decode(&result)
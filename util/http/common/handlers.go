@@ -53,8 +53,13 @@ func (c *CommonApi) Ready(ctx lib.ReqContext, context echo.Context) {
 		ctx.Log.Error(err)
 	}
 
+	var report any
+	if reporter, ok := ctx.Node.(lib.StatusReporter); ok {
+		report = reporter.StatusReport()
+	}
+
 	w.WriteHeader(code)
-	_ = json.NewEncoder(w).Encode(nil)
+	_ = json.NewEncoder(w).Encode(report)
 }
 
 // InfoHandler is an httpHandler for route GET /info
@@ -22,6 +22,12 @@ type NodeInterface interface {
 	Status() error
 }
 
+// StatusReporter is implemented by nodes detailing their status in the
+// readiness check
+type StatusReporter interface {
+	StatusReport() any
+}
+
 // HandlerFunc defines a wrapper for http.HandlerFunc that includes a context
 type HandlerFunc func(ReqContext, echo.Context)
 